@@ -0,0 +1,183 @@
+package calendar
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/eviltomorrow/robber-core/pkg/ztime"
+	"github.com/eviltomorrow/robber-repository/internal/model"
+	"github.com/eviltomorrow/robber-repository/internal/repository"
+)
+
+// Calendar 交易日历，周六、周日及节假日休市
+type Calendar struct {
+	holidays map[string]string
+}
+
+// New 根据节假日创建交易日历
+func New(holidays []*model.Holiday) *Calendar {
+	var c = &Calendar{holidays: make(map[string]string, len(holidays))}
+	for _, holiday := range holidays {
+		c.holidays[holiday.Date.Format("2006-01-02")] = holiday.Name
+	}
+	return c
+}
+
+// Load 从 repo 加载全部节假日创建交易日历
+func Load(repo repository.Repository, timeout time.Duration) (*Calendar, error) {
+	holidays, err := repo.HolidayWithSelectMany("0000-01-01", "9999-12-31", timeout)
+	if err != nil {
+		return nil, err
+	}
+	return New(holidays), nil
+}
+
+// LoadFile 读取节假日文件，每行为 "日期 名称"，如 "2022-01-03 元旦"，空行及 # 开头的行忽略
+func LoadFile(path string) ([]*model.Holiday, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var (
+		holidays = make([]*model.Holiday, 0, 32)
+		scanner  = bufio.NewScanner(file)
+		line     int
+	)
+	for scanner.Scan() {
+		line++
+		var text = strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		var fields = strings.Fields(text)
+		date, err := time.ParseInLocation("2006-01-02", fields[0], time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date at line %d, nest error: %v", line, err)
+		}
+		holidays = append(holidays, &model.Holiday{Date: date, Name: strings.Join(fields[1:], " ")})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return holidays, nil
+}
+
+// IsTradingDay 判断 date 是否为交易日
+func (c *Calendar) IsTradingDay(date time.Time) bool {
+	if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+		return false
+	}
+	_, ok := c.holidays[date.Format("2006-01-02")]
+	return !ok
+}
+
+// PreviousTradingDays 返回 date 之前(不含 date)的 n 个交易日，按日期升序
+func (c *Calendar) PreviousTradingDays(date time.Time, n int) []time.Time {
+	if n <= 0 {
+		return nil
+	}
+
+	var days = make([]time.Time, n)
+	var t = truncateDay(date)
+	for i := n - 1; i >= 0; i-- {
+		t = t.AddDate(0, 0, -1)
+		for !c.IsTradingDay(t) {
+			t = t.AddDate(0, 0, -1)
+		}
+		days[i] = t
+	}
+	return days
+}
+
+// LastTradingDayOfWeek 返回 date 所在周的最后一个交易日，整周休市时返回 false
+func (c *Calendar) LastTradingDayOfWeek(date time.Time) (time.Time, bool) {
+	return c.lastTradingDay(model.Week, date)
+}
+
+// LastTradingDayOfMonth 返回 date 所在月的最后一个交易日
+func (c *Calendar) LastTradingDayOfMonth(date time.Time) (time.Time, bool) {
+	return c.lastTradingDay(model.Month, date)
+}
+
+// LastTradingDayOfQuarter 返回 date 所在季的最后一个交易日
+func (c *Calendar) LastTradingDayOfQuarter(date time.Time) (time.Time, bool) {
+	return c.lastTradingDay(model.Quarter, date)
+}
+
+// LastTradingDayOfYear 返回 date 所在年的最后一个交易日
+func (c *Calendar) LastTradingDayOfYear(date time.Time) (time.Time, bool) {
+	return c.lastTradingDay(model.Year, date)
+}
+
+// PeriodOf 返回 date 所在 mode 周期的第一天、最后一个交易日及周期序号，周期以周一/月初/季初/年初开始
+// 整个周期休市时以周期最后一个自然日作为最后一个交易日
+func (c *Calendar) PeriodOf(mode string, date time.Time) (time.Time, time.Time, int, error) {
+	begin, end, err := Period(mode, date)
+	if err != nil {
+		return time.Time{}, time.Time{}, 0, err
+	}
+	if last, ok := c.lastTradingDay(mode, date); ok {
+		end = last
+	}
+
+	switch mode {
+	case model.Week:
+		return begin, end, ztime.YearWeek(end), nil
+	case model.Month:
+		return begin, end, int(begin.Month()), nil
+	case model.Quarter:
+		return begin, end, (int(begin.Month())-1)/3 + 1, nil
+	default:
+		return begin, end, begin.Year(), nil
+	}
+}
+
+// IsPeriodEnd 判断 date 是否为 mode 周期的最后一个交易日
+func (c *Calendar) IsPeriodEnd(mode string, date time.Time) bool {
+	last, ok := c.lastTradingDay(mode, date)
+	return ok && last.Equal(truncateDay(date))
+}
+
+func (c *Calendar) lastTradingDay(mode string, date time.Time) (time.Time, bool) {
+	begin, end, err := Period(mode, date)
+	if err != nil {
+		return time.Time{}, false
+	}
+	for t := end; !t.Before(begin); t = t.AddDate(0, 0, -1) {
+		if c.IsTradingDay(t) {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Period 返回 date 所在 mode 周期的第一个及最后一个自然日
+func Period(mode string, date time.Time) (time.Time, time.Time, error) {
+	var day = truncateDay(date)
+	switch mode {
+	case model.Week:
+		var begin = day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+		return begin, begin.AddDate(0, 0, 6), nil
+	case model.Month:
+		var begin = time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+		return begin, begin.AddDate(0, 1, -1), nil
+	case model.Quarter:
+		var begin = time.Date(day.Year(), time.Month((int(day.Month())-1)/3*3+1), 1, 0, 0, 0, 0, day.Location())
+		return begin, begin.AddDate(0, 3, -1), nil
+	case model.Year:
+		var begin = time.Date(day.Year(), time.January, 1, 0, 0, 0, 0, day.Location())
+		return begin, begin.AddDate(1, 0, -1), nil
+	default:
+		return time.Time{}, time.Time{}, fmt.Errorf("not support period mode[%s]", mode)
+	}
+}
+
+func truncateDay(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+}
@@ -0,0 +1,123 @@
+package calendar
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/eviltomorrow/robber-repository/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.Local)
+}
+
+func TestIsPeriodEnd(t *testing.T) {
+	_assert := assert.New(t)
+	var cal = New(nil)
+
+	var (
+		friday    = day(2021, time.December, 17)
+		monthEnd  = day(2021, time.April, 30)
+		quarter   = day(2021, time.September, 30)
+		weekend   = day(2022, time.July, 29) // 2022-07-31 为周日
+		yearEnd   = day(2021, time.December, 31)
+		monthMid  = day(2021, time.December, 30)
+		notFriday = day(2021, time.December, 16)
+	)
+	_assert.True(cal.IsPeriodEnd(model.Week, friday))
+	_assert.False(cal.IsPeriodEnd(model.Week, notFriday))
+	_assert.True(cal.IsPeriodEnd(model.Month, monthEnd))
+	_assert.False(cal.IsPeriodEnd(model.Quarter, monthEnd))
+	_assert.True(cal.IsPeriodEnd(model.Quarter, quarter))
+	_assert.True(cal.IsPeriodEnd(model.Month, weekend))
+	_assert.False(cal.IsPeriodEnd(model.Month, monthMid))
+	_assert.True(cal.IsPeriodEnd(model.Year, yearEnd))
+	_assert.False(cal.IsPeriodEnd(model.Year, quarter))
+}
+
+func TestHoliday(t *testing.T) {
+	_assert := assert.New(t)
+
+	// 2021-10-01 ~ 2021-10-07 国庆节，2021-12-31 假设休市
+	var holidays = make([]*model.Holiday, 0, 8)
+	for d := 1; d <= 7; d++ {
+		holidays = append(holidays, &model.Holiday{Date: day(2021, time.October, d), Name: "国庆节"})
+	}
+	holidays = append(holidays, &model.Holiday{Date: day(2021, time.December, 31), Name: "休市"})
+	var cal = New(holidays)
+
+	_assert.False(cal.IsTradingDay(day(2021, time.October, 1)))
+	_assert.False(cal.IsTradingDay(day(2021, time.October, 9)))
+	_assert.True(cal.IsTradingDay(day(2021, time.October, 8)))
+
+	last, ok := cal.LastTradingDayOfWeek(day(2021, time.October, 6))
+	_assert.True(ok)
+	_assert.Equal(day(2021, time.October, 8), last)
+
+	// 整周休市
+	_, ok = New(append(holidays, &model.Holiday{Date: day(2021, time.October, 8)})).LastTradingDayOfWeek(day(2021, time.October, 6))
+	_assert.False(ok)
+
+	// 2021-12-31 休市，周线、月线、季线、年线均以 2021-12-30 结束
+	last, ok = cal.LastTradingDayOfWeek(day(2021, time.December, 28))
+	_assert.True(ok)
+	_assert.Equal(day(2021, time.December, 30), last)
+	last, ok = cal.LastTradingDayOfMonth(day(2021, time.December, 1))
+	_assert.True(ok)
+	_assert.Equal(day(2021, time.December, 30), last)
+	for _, mode := range []string{model.Week, model.Month, model.Quarter, model.Year} {
+		_assert.True(cal.IsPeriodEnd(mode, day(2021, time.December, 30)), mode)
+		_assert.False(cal.IsPeriodEnd(mode, day(2021, time.December, 31)), mode)
+	}
+
+	_assert.Equal([]time.Time{day(2021, time.September, 29), day(2021, time.September, 30), day(2021, time.October, 8)}, cal.PreviousTradingDays(day(2021, time.October, 11), 3))
+	_assert.Nil(cal.PreviousTradingDays(day(2021, time.October, 11), 0))
+}
+
+func TestPeriodOf(t *testing.T) {
+	_assert := assert.New(t)
+	var (
+		cal  = New([]*model.Holiday{{Date: day(2021, time.December, 17)}})
+		date = time.Date(2021, time.December, 15, 10, 0, 0, 0, time.Local)
+	)
+
+	begin, end, num, err := cal.PeriodOf(model.Week, date)
+	_assert.Nil(err)
+	_assert.Equal(day(2021, time.December, 13), begin)
+	_assert.Equal(day(2021, time.December, 16), end)
+	_assert.Equal(51, num)
+
+	begin, end, num, err = cal.PeriodOf(model.Quarter, date)
+	_assert.Nil(err)
+	_assert.Equal(day(2021, time.October, 1), begin)
+	_assert.Equal(day(2021, time.December, 31), end)
+	_assert.Equal(4, num)
+
+	_, end, _, err = cal.PeriodOf(model.Month, day(2022, time.April, 2))
+	_assert.Nil(err)
+	_assert.Equal(day(2022, time.April, 29), end)
+
+	_, _, _, err = cal.PeriodOf(model.Day, date)
+	_assert.NotNil(err)
+}
+
+func TestLoadFile(t *testing.T) {
+	_assert := assert.New(t)
+
+	var path = filepath.Join(t.TempDir(), "holiday.txt")
+	_assert.Nil(os.WriteFile(path, []byte("# 2022 年休市安排\n2022-01-03 元旦\n\n2022-01-31 春节\n"), 0644))
+
+	holidays, err := LoadFile(path)
+	_assert.Nil(err)
+	if _assert.Len(holidays, 2) {
+		_assert.Equal(day(2022, time.January, 3), holidays[0].Date)
+		_assert.Equal("春节", holidays[1].Name)
+	}
+
+	_assert.Nil(os.WriteFile(path, []byte("2022/01/03 元旦\n"), 0644))
+	_, err = LoadFile(path)
+	_assert.NotNil(err)
+}
@@ -0,0 +1,103 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/eviltomorrow/robber-repository/internal/calendar"
+	"github.com/eviltomorrow/robber-repository/pkg/pb"
+	"github.com/spf13/cobra"
+)
+
+var calendarCmd = &cobra.Command{
+	Use:   "calendar",
+	Short: "Manage trading calendar of robber-repository",
+	Long:  ``,
+}
+
+var calendarLoadCmd = &cobra.Command{
+	Use:   "load",
+	Short: "Load holidays from file",
+	Long:  "  \r\nLoad holidays from file, one holiday per line formatted as \"2006-01-02 name\", blank lines and lines beginning with # are ignored",
+	Run: func(cmd *cobra.Command, args []string) {
+		holidays, err := calendar.LoadFile(calendarFile)
+		if err != nil {
+			log.Fatalf("[Fatal] Load holiday file failure, nest error: %v\r\n", err)
+		}
+
+		client, close := setupClient()
+		defer close()
+
+		ctx, cannel := context.WithTimeout(context.Background(), calendarTimeout)
+		defer cannel()
+
+		stream, err := client.PushHoliday(ctx)
+		if err != nil {
+			log.Fatalf("[Fatal] Push holiday failure, nest error: %v\r\n", err)
+		}
+		for _, holiday := range holidays {
+			if err := stream.Send(&pb.Holiday{Date: holiday.Date.Format("2006-01-02"), Name: holiday.Name}); err != nil {
+				log.Fatalf("[Fatal] Push holiday failure, nest error: %v\r\n", err)
+			}
+		}
+		count, err := stream.CloseAndRecv()
+		if err != nil {
+			log.Fatalf("[Fatal] Push holiday failure, nest error: %v\r\n", err)
+		}
+		fmt.Printf("Holiday: %d\r\n", count.Value)
+	},
+}
+
+var calendarListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List holidays",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		client, close := setupClient()
+		defer close()
+
+		ctx, cannel := context.WithTimeout(context.Background(), calendarTimeout)
+		defer cannel()
+
+		resp, err := client.GetHoliday(ctx, &pb.HolidayRequest{Begin: calendarBegin, End: calendarEnd})
+		if err != nil {
+			log.Fatalf("[Fatal] List holiday failure, nest error: %v\r\n", err)
+		}
+
+		var buf bytes.Buffer
+		for {
+			holiday, err := resp.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				log.Fatalf("[Fatal] List holiday failure, nest error: %v\r\n", err)
+			}
+			buf.WriteString(fmt.Sprintf("   %s %s\r\n", holiday.Date, holiday.Name))
+		}
+		fmt.Println(buf.String())
+	},
+}
+
+var (
+	calendarFile    string
+	calendarBegin   string
+	calendarEnd     string
+	calendarTimeout = 1 * time.Minute
+)
+
+func init() {
+	calendarCmd.PersistentFlags().StringVarP(&cfgPath, "config", "c", "config.toml", "robber-repository's config file")
+	calendarLoadCmd.Flags().StringVarP(&calendarFile, "file", "f", "", "holiday file")
+	calendarLoadCmd.MarkFlagRequired("file")
+	calendarListCmd.Flags().StringVar(&calendarBegin, "begin", "", "begin date, format: 2006-01-02")
+	calendarListCmd.Flags().StringVar(&calendarEnd, "end", "", "end date, format: 2006-01-02")
+
+	calendarCmd.AddCommand(calendarLoadCmd)
+	calendarCmd.AddCommand(calendarListCmd)
+	rootCmd.AddCommand(calendarCmd)
+}
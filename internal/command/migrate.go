@@ -0,0 +1,118 @@
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+
+	"github.com/eviltomorrow/robber-repository/internal/migration"
+	"github.com/eviltomorrow/robber-repository/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Manage database schema of robber-repository",
+	Long:  ``,
+}
+
+var migrateUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Apply all pending migrations",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		migrator, close := setupMigrator()
+		defer close()
+
+		migrations, err := migrator.Up()
+		for _, m := range migrations {
+			fmt.Printf("Applied: %04d_%s\r\n", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatalf("[Fatal] Migrate up failure, nest error: %v\r\n", err)
+		}
+		if len(migrations) == 0 {
+			fmt.Println("No pending migration")
+		}
+	},
+}
+
+var migrateDownCmd = &cobra.Command{
+	Use:   "down",
+	Short: "Rollback the latest applied migrations",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		migrator, close := setupMigrator()
+		defer close()
+
+		migrations, err := migrator.Down(migrateSteps)
+		for _, m := range migrations {
+			fmt.Printf("Rollback: %04d_%s\r\n", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatalf("[Fatal] Migrate down failure, nest error: %v\r\n", err)
+		}
+		if len(migrations) == 0 {
+			fmt.Println("No applied migration")
+		}
+	},
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Print status of migrations",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		migrator, close := setupMigrator()
+		defer close()
+
+		status, err := migrator.Status()
+		if err != nil {
+			log.Fatalf("[Fatal] Get migrate status failure, nest error: %v\r\n", err)
+		}
+
+		var buf bytes.Buffer
+		buf.WriteString(fmt.Sprintf("Driver: %s\r\n", cfg.Storage.Driver))
+		for _, s := range status {
+			var state = "pending"
+			if s.Applied {
+				state = fmt.Sprintf("applied at %s", s.AppliedAt)
+			}
+			buf.WriteString(fmt.Sprintf("   %04d_%s: %s\r\n", s.Version, s.Name, state))
+		}
+		fmt.Println(buf.String())
+	},
+}
+
+var migrateSteps int
+
+func init() {
+	migrateCmd.PersistentFlags().StringVarP(&cfgPath, "config", "c", "config.toml", "robber-repository's config file")
+	migrateDownCmd.Flags().IntVarP(&migrateSteps, "steps", "n", 1, "number of migrations to rollback")
+
+	migrateCmd.AddCommand(migrateUpCmd)
+	migrateCmd.AddCommand(migrateDownCmd)
+	migrateCmd.AddCommand(migrateStatusCmd)
+	rootCmd.AddCommand(migrateCmd)
+}
+
+func setupMigrator() (*migration.Migrator, func()) {
+	path, err := findCfg()
+	if err != nil {
+		log.Fatalf("[Fatal] Find config file failure, nest error: %v\r\n", err)
+	}
+	if err := cfg.Load(path, nil); err != nil {
+		log.Fatalf("[Fatal] Load config file failure, nest error: %v\r\n", err)
+	}
+
+	db, driver, err := repository.OpenDB(cfg)
+	if err != nil {
+		log.Fatalf("[Fatal] Open database failure, nest error: %v\r\n", err)
+	}
+	migrator, err := migration.New(db, driver)
+	if err != nil {
+		db.Close()
+		log.Fatalf("[Fatal] Create migrator failure, nest error: %v\r\n", err)
+	}
+	return migrator, func() { db.Close() }
+}
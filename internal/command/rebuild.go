@@ -0,0 +1,102 @@
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/eviltomorrow/robber-repository/internal/calendar"
+	"github.com/eviltomorrow/robber-repository/internal/model"
+	"github.com/eviltomorrow/robber-repository/internal/repository"
+	"github.com/eviltomorrow/robber-repository/internal/service"
+	"github.com/spf13/cobra"
+)
+
+var rebuildCmd = &cobra.Command{
+	Use:   "rebuild",
+	Short: "Rebuild quotes and adjustment factors from quote_day",
+	Long:  "  \r\nRebuild day adjustment factors (--period day) or aggregated bars (--period week/month/quarter/year/all) from quote_day",
+	Run: func(cmd *cobra.Command, args []string) {
+		from, err := time.ParseInLocation("2006-01-02", rebuildFrom, time.Local)
+		if err != nil {
+			log.Fatalf("[Fatal] Invalid from[%s], nest error: %v\r\n", rebuildFrom, err)
+		}
+		to, err := time.ParseInLocation("2006-01-02", rebuildTo, time.Local)
+		if err != nil {
+			log.Fatalf("[Fatal] Invalid to[%s], nest error: %v\r\n", rebuildTo, err)
+		}
+
+		var modes = []string{rebuildPeriod}
+		if rebuildPeriod == "all" {
+			modes = []string{model.Day, model.Week, model.Month, model.Quarter, model.Year}
+		}
+
+		path, err := findCfg()
+		if err != nil {
+			log.Fatalf("[Fatal] Find config file failure, nest error: %v\r\n", err)
+		}
+		if err := cfg.Load(path, nil); err != nil {
+			log.Fatalf("[Fatal] Load config file failure, nest error: %v\r\n", err)
+		}
+		repo, err := repository.Build(cfg)
+		if err != nil {
+			log.Fatalf("[Fatal] Build repository failure, nest error: %v\r\n", err)
+		}
+		defer repo.Close()
+
+		cal, err := calendar.Load(repo, 60*time.Second)
+		if err != nil {
+			log.Fatalf("[Fatal] Load calendar failure, nest error: %v\r\n", err)
+		}
+
+		for _, mode := range modes {
+			result, err := service.Rebuild(repo, cal, mode, rebuildCodes, from, to, rebuildBatch, rebuildDryRun, 60*time.Second)
+			if result != nil {
+				printRebuildResult(result)
+			}
+			if err != nil {
+				log.Fatalf("[Fatal] Rebuild %s failure, nest error: %v\r\n", mode, err)
+			}
+		}
+	},
+}
+
+var (
+	rebuildPeriod string
+	rebuildFrom   string
+	rebuildTo     string
+	rebuildCodes  []string
+	rebuildBatch  int
+	rebuildDryRun bool
+)
+
+func init() {
+	rebuildCmd.Flags().StringVarP(&cfgPath, "config", "c", "config.toml", "robber-repository's config file")
+	rebuildCmd.Flags().StringVar(&rebuildPeriod, "period", model.Week, "period to rebuild: day, week, month, quarter, year or all")
+	rebuildCmd.Flags().StringVar(&rebuildFrom, "from", "", "begin date, format: 2006-01-02")
+	rebuildCmd.Flags().StringVar(&rebuildTo, "to", time.Now().Format("2006-01-02"), "end date, format: 2006-01-02")
+	rebuildCmd.Flags().StringSliceVar(&rebuildCodes, "codes", nil, "codes to rebuild, all stocks when empty")
+	rebuildCmd.Flags().IntVar(&rebuildBatch, "batch", 50, "number of codes written in one transaction")
+	rebuildCmd.Flags().BoolVar(&rebuildDryRun, "dry-run", false, "print differences against stored quotes without writing")
+	rebuildCmd.MarkFlagRequired("from")
+
+	rootCmd.AddCommand(rebuildCmd)
+}
+
+func printRebuildResult(result *service.RebuildResult) {
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("Period: %s, Codes: %d, Rebuilt: %d, Inserted: %d, Updated: %d, Deleted: %d\r\n", result.Mode, result.Codes, result.Rebuilt, result.Inserted, result.Updated, result.Deleted))
+	for _, diff := range result.Diffs {
+		switch {
+		case diff.Stored == nil:
+			buf.WriteString(fmt.Sprintf("   + %s %s: %s\r\n", diff.Code, diff.Date, diff.Rebuilt.String()))
+		case diff.Rebuilt == nil:
+			buf.WriteString(fmt.Sprintf("   - %s %s: %s (removed)\r\n", diff.Code, diff.Date, diff.Stored.String()))
+		default:
+			buf.WriteString(fmt.Sprintf("   - %s %s: %s\r\n", diff.Code, diff.Date, diff.Stored.String()))
+			buf.WriteString(fmt.Sprintf("   + %s %s: %s\r\n", diff.Code, diff.Date, diff.Rebuilt.String()))
+		}
+	}
+	fmt.Println(buf.String())
+}
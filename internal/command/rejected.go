@@ -0,0 +1,163 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/eviltomorrow/robber-repository/pkg/pb"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var rejectedCmd = &cobra.Command{
+	Use:   "rejected",
+	Short: "Manage rejected metadata of robber-repository",
+	Long:  ``,
+}
+
+var rejectedListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List rejected metadata",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		client, close := setupClient()
+		defer close()
+
+		ctx, cannel := context.WithTimeout(context.Background(), rejectedTimeout)
+		defer cannel()
+
+		resp, err := client.ListRejected(ctx, &pb.RejectedRequest{Code: rejectedCode, Offset: rejectedOffset, Limit: rejectedLimit})
+		if err != nil {
+			log.Fatalf("[Fatal] List rejected metadata failure, nest error: %v\r\n", err)
+		}
+
+		var buf bytes.Buffer
+		for {
+			record, err := resp.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				log.Fatalf("[Fatal] List rejected metadata failure, nest error: %v\r\n", err)
+			}
+			buf.WriteString(fmt.Sprintf("   %d: %s %s %s\r\n", record.Id, record.Metadata.Code, record.Metadata.Date, record.Reason))
+		}
+		fmt.Println(buf.String())
+	},
+}
+
+var rejectedShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Print detail of rejected metadata",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			log.Fatalf("[Fatal] Invalid id[%s], nest error: %v\r\n", args[0], err)
+		}
+
+		client, close := setupClient()
+		defer close()
+
+		ctx, cannel := context.WithTimeout(context.Background(), rejectedTimeout)
+		defer cannel()
+
+		record, err := client.GetRejected(ctx, &wrapperspb.Int64Value{Value: id})
+		if err != nil {
+			log.Fatalf("[Fatal] Get rejected metadata failure, nest error: %v\r\n", err)
+		}
+		buf, err := protojson.MarshalOptions{Multiline: true, Indent: "   "}.Marshal(record)
+		if err != nil {
+			log.Fatalf("[Fatal] Marshal rejected metadata failure, nest error: %v\r\n", err)
+		}
+		fmt.Println(string(buf))
+	},
+}
+
+var rejectedReplayCmd = &cobra.Command{
+	Use:   "replay [id...]",
+	Short: "Replay rejected metadata through the normal ingestion path",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !rejectedAll && len(args) == 0 {
+			log.Fatalf("[Fatal] Replay rejected metadata failure, nest error: no id specified, use --all to replay all\r\n")
+		}
+
+		var ids = make([]int64, 0, len(args))
+		for _, arg := range args {
+			id, err := strconv.ParseInt(arg, 10, 64)
+			if err != nil {
+				log.Fatalf("[Fatal] Invalid id[%s], nest error: %v\r\n", arg, err)
+			}
+			ids = append(ids, id)
+		}
+
+		client, close := setupClient()
+		defer close()
+
+		ctx, cannel := context.WithTimeout(context.Background(), rejectedTimeout)
+		defer cannel()
+
+		count, err := client.ReplayRejected(ctx, &pb.ReplayRequest{Ids: ids, All: rejectedAll})
+		if err != nil {
+			log.Fatalf("[Fatal] Replay rejected metadata failure, nest error: %v\r\n", err)
+		}
+
+		var buf bytes.Buffer
+		buf.WriteString(fmt.Sprintf("Stock: %d, Day: %d, Week: %d, Month: %d, Quarter: %d, Year: %d\r\n", count.Stock, count.Day, count.Week, count.Month, count.Quarter, count.Year))
+		for _, r := range count.Rejected {
+			buf.WriteString(fmt.Sprintf("   Rejected: %s %s %s\r\n", r.Code, r.Date, r.Reason))
+		}
+		fmt.Println(buf.String())
+	},
+}
+
+var (
+	rejectedCode    string
+	rejectedOffset  int64
+	rejectedLimit   int64
+	rejectedAll     bool
+	rejectedTimeout = 5 * time.Minute
+)
+
+func init() {
+	rejectedCmd.PersistentFlags().StringVarP(&cfgPath, "config", "c", "config.toml", "robber-repository's config file")
+	rejectedListCmd.Flags().StringVar(&rejectedCode, "code", "", "only list rejected metadata of code")
+	rejectedListCmd.Flags().Int64Var(&rejectedOffset, "offset", 0, "offset of rejected metadata")
+	rejectedListCmd.Flags().Int64Var(&rejectedLimit, "limit", 100, "number of rejected metadata, 0 means no limit")
+	rejectedReplayCmd.Flags().BoolVar(&rejectedAll, "all", false, "replay all rejected metadata")
+
+	rejectedCmd.AddCommand(rejectedListCmd)
+	rejectedCmd.AddCommand(rejectedShowCmd)
+	rejectedCmd.AddCommand(rejectedReplayCmd)
+	rootCmd.AddCommand(rejectedCmd)
+}
+
+func setupClient() (pb.ServiceClient, func()) {
+	path, err := findCfg()
+	if err != nil {
+		log.Fatalf("[Fatal] Find config file failure, nest error: %v\r\n", err)
+	}
+	if err := cfg.Load(path, nil); err != nil {
+		log.Fatalf("[Fatal] Load config file failure, nest error: %v\r\n", err)
+	}
+
+	var host = cfg.Server.Host
+	if host == "" || host == "0.0.0.0" {
+		host = "127.0.0.1"
+	}
+	conn, err := grpc.Dial(fmt.Sprintf("%s:%d", host, cfg.Server.Port), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("[Fatal] Dial robber-repository failure, nest error: %v\r\n", err)
+	}
+	return pb.NewServiceClient(conn), func() { conn.Close() }
+}
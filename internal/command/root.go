@@ -8,13 +8,14 @@ import (
 	"os/signal"
 	"path/filepath"
 	"syscall"
+	"time"
 
-	"github.com/eviltomorrow/robber-core/pkg/mysql"
 	"github.com/eviltomorrow/robber-core/pkg/pid"
 	"github.com/eviltomorrow/robber-core/pkg/system"
 	"github.com/eviltomorrow/robber-core/pkg/zlog"
 	"github.com/eviltomorrow/robber-core/pkg/znet"
 	"github.com/eviltomorrow/robber-repository/internal/config"
+	"github.com/eviltomorrow/robber-repository/internal/repository"
 	"github.com/eviltomorrow/robber-repository/internal/server"
 	"github.com/eviltomorrow/robber-repository/pkg/client"
 	"github.com/spf13/cobra"
@@ -48,9 +49,11 @@ var rootCmd = &cobra.Command{
 
 		setupCfg()
 		setupVars()
-		if err := mysql.Build(); err != nil {
-			zlog.Fatal("Build mysql connection failure", zap.Error(err))
+		repo, err := repository.Build(cfg)
+		if err != nil {
+			zlog.Fatal("Build repository failure", zap.String("driver", cfg.Storage.Driver), zap.Error(err))
 		}
+		server.Repository = repo
 
 		if err := server.StartupGRPC(); err != nil {
 			zlog.Fatal("Startup GRPC service failure", zap.Error(err))
@@ -97,6 +100,7 @@ func blockingUntilTermination() {
 func registerCleanFuncs() {
 	cleanFuncs = append(cleanFuncs, server.RevokeEtcdConn)
 	cleanFuncs = append(cleanFuncs, server.ShutdownGRPC)
+	cleanFuncs = append(cleanFuncs, server.Repository.Close)
 	cleanFuncs = append(cleanFuncs, pid.DestroyFile)
 }
 
@@ -154,8 +158,13 @@ func setupVars() {
 	server.Host = cfg.Server.Host
 	server.Port = cfg.Server.Port
 	server.Endpoints = cfg.Etcd.Endpoints
-
-	mysql.DSN = cfg.MySQL.DSN
+	server.BatchSize = cfg.Ingest.BatchSize
+	server.Parallelism = cfg.Ingest.Parallelism
+	server.CountTolerance = cfg.Ingest.CountTolerance
+	server.CallbackMaxAttempts = cfg.Callback.MaxAttempts
+	server.CallbackBackoff = time.Duration(cfg.Callback.Backoff) * time.Second
+	server.CallbackMaxBackoff = time.Duration(cfg.Callback.MaxBackoff) * time.Second
+	server.CallbackTimeout = time.Duration(cfg.Callback.Timeout) * time.Second
 
 	client.EtcdEndpoints = cfg.Etcd.Endpoints
 }
@@ -0,0 +1,155 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/eviltomorrow/robber-repository/pkg/pb"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var taskCmd = &cobra.Command{
+	Use:   "task",
+	Short: "Inspect daily tasks of robber-repository",
+	Long:  ``,
+}
+
+var taskListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List tasks, latest date first",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		client, close := setupClient()
+		defer close()
+
+		ctx, cannel := context.WithTimeout(context.Background(), taskTimeout)
+		defer cannel()
+
+		resp, err := client.ListTasks(ctx, &pb.TaskListRequest{From: taskFrom, To: taskTo, State: taskState, Offset: taskOffset, Limit: taskLimit})
+		if err != nil {
+			log.Fatalf("[Fatal] List task failure, nest error: %v\r\n", err)
+		}
+
+		var buf bytes.Buffer
+		for {
+			task, err := resp.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				log.Fatalf("[Fatal] List task failure, nest error: %v\r\n", err)
+			}
+			var mark string
+			if task.Inconsistent {
+				mark = fmt.Sprintf("(inconsistent, actual Stock: %d, Day: %d, Week: %d)", task.ActualStockCount, task.ActualDayCount, task.ActualWeekCount)
+			}
+			buf.WriteString(fmt.Sprintf("   %s %-16s Metadata: %d, Stock: %d, Day: %d, Week: %d %s %s\r\n", task.Date, task.State, task.MetadataCount, task.StockCount, task.DayCount, task.WeekCount, mark, task.LastError))
+		}
+		fmt.Println(buf.String())
+	},
+}
+
+var taskShowCmd = &cobra.Command{
+	Use:   "show <date>",
+	Short: "Print detail of task",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client, close := setupClient()
+		defer close()
+
+		ctx, cannel := context.WithTimeout(context.Background(), taskTimeout)
+		defer cannel()
+
+		task, err := client.GetTask(ctx, &wrapperspb.StringValue{Value: args[0]})
+		if err != nil {
+			log.Fatalf("[Fatal] Get task failure, nest error: %v\r\n", err)
+		}
+		buf, err := protojson.MarshalOptions{Multiline: true, Indent: "   "}.Marshal(task)
+		if err != nil {
+			log.Fatalf("[Fatal] Marshal task failure, nest error: %v\r\n", err)
+		}
+		fmt.Println(string(buf))
+	},
+}
+
+var taskRedeliverCmd = &cobra.Command{
+	Use:   "redeliver <date>",
+	Short: "Redeliver callback of failed or callback_pending task",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client, close := setupClient()
+		defer close()
+
+		ctx, cannel := context.WithTimeout(context.Background(), taskTimeout)
+		defer cannel()
+
+		if _, err := client.RedeliverCallback(ctx, &wrapperspb.StringValue{Value: args[0]}); err != nil {
+			log.Fatalf("[Fatal] Redeliver callback failure, nest error: %v\r\n", err)
+		}
+		fmt.Printf("Redeliver callback of task[%s] success\r\n", args[0])
+	},
+}
+
+var taskAttemptsCmd = &cobra.Command{
+	Use:   "attempts <date>",
+	Short: "List callback attempts of task",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client, close := setupClient()
+		defer close()
+
+		ctx, cannel := context.WithTimeout(context.Background(), taskTimeout)
+		defer cannel()
+
+		resp, err := client.ListCallbackAttempts(ctx, &wrapperspb.StringValue{Value: args[0]})
+		if err != nil {
+			log.Fatalf("[Fatal] List callback attempts failure, nest error: %v\r\n", err)
+		}
+
+		var buf bytes.Buffer
+		for {
+			attempt, err := resp.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				log.Fatalf("[Fatal] List callback attempts failure, nest error: %v\r\n", err)
+			}
+			buf.WriteString(fmt.Sprintf("   %s #%d Status: %d, Latency: %dms %s %s\r\n", attempt.CreateTimestamp, attempt.Attempt, attempt.StatusCode, attempt.Latency, attempt.Error, attempt.Body))
+		}
+		fmt.Println(buf.String())
+	},
+}
+
+var (
+	taskFrom    string
+	taskTo      string
+	taskState   string
+	taskOffset  int64
+	taskLimit   int64
+	taskTimeout = 1 * time.Minute
+)
+
+func init() {
+	taskCmd.PersistentFlags().StringVarP(&cfgPath, "config", "c", "config.toml", "robber-repository's config file")
+	taskListCmd.Flags().StringVar(&taskFrom, "from", "", "begin date, format: 2006-01-02")
+	taskListCmd.Flags().StringVar(&taskTo, "to", "", "end date, format: 2006-01-02")
+	taskListCmd.Flags().StringVar(&taskState, "state", "", "only list tasks in state: created, ingesting, verifying, callback_pending, completed, failed")
+	taskListCmd.Flags().Int64Var(&taskOffset, "offset", 0, "offset of tasks")
+	taskListCmd.Flags().Int64Var(&taskLimit, "limit", 30, "number of tasks, 0 means no limit")
+
+	taskCmd.AddCommand(taskListCmd)
+	taskCmd.AddCommand(taskShowCmd)
+	taskCmd.AddCommand(taskRedeliverCmd)
+	taskCmd.AddCommand(taskAttemptsCmd)
+	rootCmd.AddCommand(taskCmd)
+}
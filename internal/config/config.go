@@ -7,10 +7,14 @@ import (
 )
 
 type Config struct {
-	Log    Log    `json:"log" toml:"log"`
-	MySQL  MySQL  `json:"mysql" toml:"mysql"`
-	Etcd   Etcd   `json:"etcd" toml:"etcd"`
-	Server Server `json:"server" toml:"server"`
+	Log      Log      `json:"log" toml:"log"`
+	Storage  Storage  `json:"storage" toml:"storage"`
+	MySQL    MySQL    `json:"mysql" toml:"mysql"`
+	SQLite   SQLite   `json:"sqlite" toml:"sqlite"`
+	Etcd     Etcd     `json:"etcd" toml:"etcd"`
+	Server   Server   `json:"server" toml:"server"`
+	Ingest   Ingest   `json:"ingest" toml:"ingest"`
+	Callback Callback `json:"callback" toml:"callback"`
 }
 
 type Log struct {
@@ -21,12 +25,20 @@ type Log struct {
 	MaxSize          int    `json:"maxsize" toml:"maxsize"`
 }
 
+type Storage struct {
+	Driver string `json:"driver" toml:"driver"`
+}
+
 type MySQL struct {
 	DSN     string `json:"dsn" toml:"dsn"`
 	MinOpen int    `json:"min-open" toml:"min-open"`
 	MaxOpen int    `json:"max-open" toml:"max-open"`
 }
 
+type SQLite struct {
+	Path string `json:"path" toml:"path"`
+}
+
 type Etcd struct {
 	Endpoints []string `json:"endpoints" toml:"endpoints"`
 }
@@ -36,6 +48,20 @@ type Server struct {
 	Port int    `json:"port" toml:"port"`
 }
 
+type Ingest struct {
+	BatchSize      int     `json:"batch-size" toml:"batch-size"`
+	Parallelism    int     `json:"parallelism" toml:"parallelism"`
+	CountTolerance float64 `json:"count-tolerance" toml:"count-tolerance"`
+}
+
+// Callback 回调投递的重试策略，时间单位均为秒
+type Callback struct {
+	MaxAttempts int64 `json:"max-attempts" toml:"max-attempts"`
+	Backoff     int64 `json:"backoff" toml:"backoff"`
+	MaxBackoff  int64 `json:"max-backoff" toml:"max-backoff"`
+	Timeout     int64 `json:"timeout" toml:"timeout"`
+}
+
 func (c *Config) Load(path string, override func(cfg *Config)) error {
 	if path == "" {
 		return nil
@@ -60,11 +86,17 @@ var GlobalConfig = &Config{
 		FileName:         "/tmp/robber-repository/data.log",
 		MaxSize:          20,
 	},
+	Storage: Storage{
+		Driver: "mysql",
+	},
 	MySQL: MySQL{
 		DSN:     "root:root@tcp(127.0.0.1:3306)/robber?charset=utf8mb4&parseTime=true&loc=Local",
 		MinOpen: 5,
 		MaxOpen: 10,
 	},
+	SQLite: SQLite{
+		Path: "/tmp/robber-repository/robber.db",
+	},
 	Etcd: Etcd{
 		Endpoints: []string{
 			"127.0.0.1:2379",
@@ -74,4 +106,15 @@ var GlobalConfig = &Config{
 		Host: "0.0.0.0",
 		Port: 27321,
 	},
+	Ingest: Ingest{
+		BatchSize:      500,
+		Parallelism:    8,
+		CountTolerance: 0,
+	},
+	Callback: Callback{
+		MaxAttempts: 5,
+		Backoff:     30,
+		MaxBackoff:  1800,
+		Timeout:     10,
+	},
 }
@@ -0,0 +1,365 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed mysql/*.sql sqlite/*.sql
+var files embed.FS
+
+const (
+	DriverMySQL  = "mysql"
+	DriverSQLite = "sqlite"
+)
+
+var timeout = 60 * time.Second
+
+var dialects = map[string]dialect{
+	DriverMySQL: {
+		createTable: "create table if not exists `schema_migrations` (" +
+			"`version` BIGINT NOT NULL PRIMARY KEY COMMENT '版本', " +
+			"`name` VARCHAR(128) NOT NULL COMMENT '名称', " +
+			"`create_timestamp` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '执行时间')",
+		now:       "now()",
+		timestamp: "date_format(create_timestamp, '%Y-%m-%d %H:%i:%s')",
+		// 查询及迁移使用窗口函数，须为 MySQL 8.0 及以上版本
+		version: "select version()",
+		major:   8,
+	},
+	DriverSQLite: {
+		createTable: "create table if not exists schema_migrations (" +
+			"version INTEGER NOT NULL PRIMARY KEY, " +
+			"name VARCHAR(128) NOT NULL, " +
+			"create_timestamp TEXT NOT NULL)",
+		now:       "datetime('now', 'localtime')",
+		timestamp: "create_timestamp",
+	},
+}
+
+type dialect struct {
+	createTable string
+	now         string
+	timestamp   string
+	// version 查询数据库版本的语句，major 支持的最低主版本号，version 为空时不校验
+	version string
+	major   int64
+}
+
+// Migration 单个版本的表结构变更
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status 版本执行状态
+type Status struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt string
+}
+
+// Migrator 表结构版本管理
+type Migrator struct {
+	db         *sql.DB
+	dialect    dialect
+	migrations []*Migration
+}
+
+// New 创建 driver 对应的 Migrator
+func New(db *sql.DB, driver string) (*Migrator, error) {
+	d, ok := dialects[driver]
+	if !ok {
+		return nil, fmt.Errorf("not support migration driver[%s]", driver)
+	}
+	migrations, err := Load(driver)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, dialect: d, migrations: migrations}, nil
+}
+
+// Load 加载 driver 对应的内嵌迁移文件，按版本升序排列
+func Load(driver string) ([]*Migration, error) {
+	entries, err := files.ReadDir(driver)
+	if err != nil {
+		return nil, err
+	}
+
+	var cache = make(map[int64]*Migration, len(entries))
+	for _, entry := range entries {
+		// 0001_init.up.sql
+		var (
+			name  = entry.Name()
+			parts = strings.SplitN(strings.TrimSuffix(name, ".sql"), "_", 2)
+		)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid migration file name[%s]", name)
+		}
+		version, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration file name[%s], nest error: %v", name, err)
+		}
+
+		buf, err := files.ReadFile(path.Join(driver, name))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := cache[version]
+		if !ok {
+			m = &Migration{Version: version}
+			cache[version] = m
+		}
+		switch {
+		case strings.HasSuffix(parts[1], ".up"):
+			m.Name = strings.TrimSuffix(parts[1], ".up")
+			m.Up = string(buf)
+		case strings.HasSuffix(parts[1], ".down"):
+			m.Name = strings.TrimSuffix(parts[1], ".down")
+			m.Down = string(buf)
+		default:
+			return nil, fmt.Errorf("invalid migration file name[%s], must be end with .up.sql or .down.sql", name)
+		}
+	}
+
+	var migrations = make([]*Migration, 0, len(cache))
+	for _, m := range cache {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration[%04d_%s] missing up file", m.Version, m.Name)
+		}
+		migrations = append(migrations, m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Latest 当前程序所需的表结构版本
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version 数据库当前的表结构版本
+func (m *Migrator) Version() (int64, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return 0, err
+	}
+
+	var version int64
+	for v := range applied {
+		if v > version {
+			version = v
+		}
+	}
+	return version, nil
+}
+
+// Status 列出全部版本及其执行状态
+func (m *Migrator) Status() ([]*Status, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var status = make([]*Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		at, ok := applied[migration.Version]
+		status = append(status, &Status{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			Applied:   ok,
+			AppliedAt: at,
+		})
+	}
+	return status, nil
+}
+
+// Check 校验数据库版本是否受支持，以及数据库表结构是否落后于程序
+func (m *Migrator) Check() error {
+	if err := m.checkServer(); err != nil {
+		return err
+	}
+	version, err := m.Version()
+	if err != nil {
+		return err
+	}
+	if latest := m.Latest(); version < latest {
+		return fmt.Errorf("database schema version[%d] is behind binary version[%d], please run `robber-repository migrate up`", version, latest)
+	}
+	return nil
+}
+
+// Up 执行全部未执行的版本
+func (m *Migrator) Up() ([]*Migration, error) {
+	if err := m.checkServer(); err != nil {
+		return nil, err
+	}
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var result = make([]*Migration, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		if err := m.apply(migration, true); err != nil {
+			return result, fmt.Errorf("migrate up [%04d_%s] failure, nest error: %v", migration.Version, migration.Name, err)
+		}
+		result = append(result, migration)
+	}
+	return result, nil
+}
+
+// Down 按版本倒序回滚 steps 个已执行的版本
+func (m *Migrator) Down(steps int) ([]*Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var result = make([]*Migration, 0, steps)
+	for i := len(m.migrations) - 1; i >= 0 && len(result) < steps; i-- {
+		var migration = m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		if migration.Down == "" {
+			return result, fmt.Errorf("migration[%04d_%s] missing down file", migration.Version, migration.Name)
+		}
+		if err := m.apply(migration, false); err != nil {
+			return result, fmt.Errorf("migrate down [%04d_%s] failure, nest error: %v", migration.Version, migration.Name, err)
+		}
+		result = append(result, migration)
+	}
+	return result, nil
+}
+
+// checkServer 校验数据库主版本号不低于 dialect 要求的最低版本
+func (m *Migrator) checkServer() error {
+	if m.dialect.version == "" {
+		return nil
+	}
+
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var version string
+	if err := m.db.QueryRowContext(ctx, m.dialect.version).Scan(&version); err != nil {
+		return err
+	}
+	// 8.0.28、5.7.36-log
+	major, err := strconv.ParseInt(strings.SplitN(version, ".", 2)[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid database version[%s], nest error: %v", version, err)
+	}
+	if major < m.dialect.major {
+		return fmt.Errorf("database version[%s] is not supported, %d.0 or later is required", version, m.dialect.major)
+	}
+	return nil
+}
+
+func (m *Migrator) applied() (map[int64]string, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	if _, err := m.db.ExecContext(ctx, m.dialect.createTable); err != nil {
+		return nil, err
+	}
+
+	rows, err := m.db.QueryContext(ctx, fmt.Sprintf("select version, %s from schema_migrations", m.dialect.timestamp))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var applied = make(map[int64]string, len(m.migrations))
+	for rows.Next() {
+		var (
+			version int64
+			at      string
+		)
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		applied[version] = at
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return applied, nil
+}
+
+// apply 在同一事务内执行 migration 的全部语句并登记 schema_migrations，失败时整体回滚
+// SQLite 支持事务内执行 DDL；MySQL 执行 DDL 时隐式提交，失败时无法整体回滚，因此 MySQL 的 migration 须为单条 DDL 语句(多个变更合并为一条 alter table)，
+// 或仅包含 DML 及 create table if not exists 等可重复执行的语句，数据回填放在单独的版本中，见 TestMySQLMigrationStatements
+func (m *Migrator) apply(migration *Migration, up bool) error {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	var content = migration.Down
+	if up {
+		content = migration.Up
+	}
+	for _, stmt := range split(content) {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if up {
+		_, err = tx.ExecContext(ctx, fmt.Sprintf("insert into schema_migrations (version, name, create_timestamp) values (?, ?, %s)", m.dialect.now), migration.Version, migration.Name)
+	} else {
+		_, err = tx.ExecContext(ctx, "delete from schema_migrations where version = ?", migration.Version)
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// split 按 ";" 拆分 SQL 语句，忽略 "--" 开头的注释行
+func split(content string) []string {
+	var (
+		stmts []string
+		buf   strings.Builder
+	)
+	for _, line := range strings.Split(content, "\n") {
+		var trimed = strings.TrimSpace(line)
+		if trimed == "" || strings.HasPrefix(trimed, "--") {
+			continue
+		}
+		buf.WriteString(line)
+		buf.WriteString("\n")
+		if strings.HasSuffix(trimed, ";") {
+			stmts = append(stmts, strings.TrimSuffix(strings.TrimSpace(buf.String()), ";"))
+			buf.Reset()
+		}
+	}
+	if s := strings.TrimSpace(buf.String()); s != "" {
+		stmts = append(stmts, s)
+	}
+	return stmts
+}
@@ -0,0 +1,207 @@
+package migration
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	_ "modernc.org/sqlite"
+)
+
+func newDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "robber.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestLoad(t *testing.T) {
+	_assert := assert.New(t)
+	for _, driver := range []string{DriverMySQL, DriverSQLite} {
+		migrations, err := Load(driver)
+		_assert.Nil(err)
+		_assert.NotEqual(0, len(migrations))
+		for i, m := range migrations {
+			_assert.Equal(int64(i+1), m.Version, driver)
+			_assert.NotEqual("", m.Up, driver)
+			_assert.NotEqual("", m.Down, driver)
+		}
+	}
+
+	// mysql 与 sqlite 版本须保持一致
+	mysql, _ := Load(DriverMySQL)
+	sqlite, _ := Load(DriverSQLite)
+	_assert.Equal(len(mysql), len(sqlite))
+}
+
+func TestMigrator(t *testing.T) {
+	_assert := assert.New(t)
+	db := newDB(t)
+
+	migrator, err := New(db, DriverSQLite)
+	_assert.Nil(err)
+	_assert.NotNil(migrator.Check())
+
+	migrations, err := migrator.Up()
+	_assert.Nil(err)
+	_assert.Equal(len(migrator.migrations), len(migrations))
+	_assert.Nil(migrator.Check())
+
+	version, err := migrator.Version()
+	_assert.Nil(err)
+	_assert.Equal(migrator.Latest(), version)
+
+	migrations, err = migrator.Up()
+	_assert.Nil(err)
+	_assert.Equal(0, len(migrations))
+
+	migrations, err = migrator.Down(1)
+	_assert.Nil(err)
+	_assert.Equal(1, len(migrations))
+	_assert.Equal(migrator.Latest(), migrations[0].Version)
+	_assert.NotNil(migrator.Check())
+
+	status, err := migrator.Status()
+	_assert.Nil(err)
+	_assert.False(status[len(status)-1].Applied)
+	_assert.True(status[0].Applied)
+	_assert.NotEqual("", status[0].AppliedAt)
+
+	_, err = migrator.Down(len(migrator.migrations))
+	_assert.Nil(err)
+	version, err = migrator.Version()
+	_assert.Nil(err)
+	_assert.Equal(int64(0), version)
+
+	_, err = migrator.Up()
+	_assert.Nil(err)
+	_assert.Nil(migrator.Check())
+
+	_, err = New(db, "oracle")
+	_assert.NotNil(err)
+}
+
+func TestMigratorRollback(t *testing.T) {
+	_assert := assert.New(t)
+	db := newDB(t)
+
+	migrator, err := New(db, DriverSQLite)
+	_assert.Nil(err)
+	migrator.migrations = []*Migration{
+		{Version: 1, Name: "a", Up: "create table a (id INT);", Down: "drop table a;"},
+		{Version: 2, Name: "b", Up: "create table b (id INT);\ninsert into b (id) values (1);\ninsert into c (id) values (1);", Down: "drop table b;"},
+	}
+
+	// 执行失败的版本整体回滚，不登记版本，修复后可重新执行
+	migrations, err := migrator.Up()
+	_assert.NotNil(err)
+	_assert.Equal(1, len(migrations))
+	version, err := migrator.Version()
+	_assert.Nil(err)
+	_assert.Equal(int64(1), version)
+	var count int
+	_assert.NotNil(db.QueryRow("select count(1) from b").Scan(&count))
+
+	migrator.migrations[1].Up = "create table b (id INT);\ninsert into b (id) values (1);"
+	migrations, err = migrator.Up()
+	_assert.Nil(err)
+	_assert.Equal(1, len(migrations))
+	_assert.Nil(db.QueryRow("select count(1) from b").Scan(&count))
+	_assert.Equal(1, count)
+}
+
+func TestMigratorCheckServer(t *testing.T) {
+	_assert := assert.New(t)
+	db := newDB(t)
+
+	migrator, err := New(db, DriverSQLite)
+	_assert.Nil(err)
+	_, err = migrator.Up()
+	_assert.Nil(err)
+
+	migrator.dialect.major = dialects[DriverMySQL].major
+	for version, ok := range map[string]bool{"8.0.28": true, "10.6.5-MariaDB": true, "5.7.36-log": false, "unknown": false} {
+		migrator.dialect.version = fmt.Sprintf("select '%s'", version)
+		_assert.Equal(ok, migrator.Check() == nil, version)
+		_, err = migrator.Up()
+		_assert.Equal(ok, err == nil, version)
+	}
+}
+
+func TestMigratorRetry(t *testing.T) {
+	_assert := assert.New(t)
+	db := newDB(t)
+
+	migrator, err := New(db, DriverSQLite)
+	_assert.Nil(err)
+
+	// 依次在每个版本的第一条语句之后注入失败，失败的版本不登记，恢复后重新执行 Up 从该版本继续
+	for i, m := range migrator.migrations {
+		var (
+			up    = m.Up
+			stmts = split(up)
+		)
+		if len(stmts) == 0 {
+			continue
+		}
+		m.Up = stmts[0] + ";\ninsert into not_exists (id) values (1);\n" + strings.Join(stmts[1:], ";\n")
+		_, err = migrator.Up()
+		_assert.NotNil(err, m.Name)
+		_assert.NotNil(migrator.Check())
+		version, err := migrator.Version()
+		_assert.Nil(err)
+		_assert.Equal(int64(i), version, m.Name)
+		m.Up = up
+	}
+
+	migrations, err := migrator.Up()
+	_assert.Nil(err)
+	_assert.Equal(1, len(migrations))
+	_assert.Nil(migrator.Check())
+}
+
+func TestMySQLMigrationStatements(t *testing.T) {
+	_assert := assert.New(t)
+	migrations, err := Load(DriverMySQL)
+	_assert.Nil(err)
+
+	// MySQL 执行 DDL 时隐式提交，不可重复执行的 DDL 须为 migration 的唯一语句，失败后重新执行不会遇到已部分执行的变更
+	var rerunnable = []string{"create table if not exists", "drop table if exists"}
+	for _, m := range migrations {
+		for _, content := range []string{m.Up, m.Down} {
+			var stmts = split(content)
+			for _, stmt := range stmts {
+				var lower = strings.ToLower(stmt)
+				if !strings.HasPrefix(lower, "alter ") && !strings.HasPrefix(lower, "create ") && !strings.HasPrefix(lower, "drop ") && !strings.HasPrefix(lower, "rename ") {
+					continue
+				}
+				var ok = len(stmts) == 1
+				for _, prefix := range rerunnable {
+					if strings.HasPrefix(lower, prefix) {
+						ok = true
+					}
+				}
+				_assert.True(ok, "%04d_%s: %s", m.Version, m.Name, stmt)
+			}
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	_assert := assert.New(t)
+	stmts := split(`-- comment
+create table a (
+    id INT
+);
+
+drop table b;
+select 1`)
+	_assert.Equal([]string{"create table a (\n    id INT\n)", "drop table b", "select 1"}, stmts)
+}
@@ -0,0 +1,4 @@
+drop table if exists `task`;
+drop table if exists `stock`;
+drop table if exists `quote_week`;
+drop table if exists `quote_day`;
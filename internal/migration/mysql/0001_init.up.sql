@@ -1,6 +1,4 @@
--- create table quote_day
-drop table if exists `robber`.`quote_day`;
-create table `robber`.`quote_day` (
+create table if not exists `quote_day` (
     `id` BIGINT NOT NULL PRIMARY KEY AUTO_INCREMENT,
     `code` CHAR(8) NOT NULL COMMENT '股票代码',
     `open` DECIMAL(10,2) NOT NULL COMMENT '开盘价',
@@ -14,12 +12,11 @@ create table `robber`.`quote_day` (
     `num_of_year` INT NOT NULL COMMENT '天数',
     `xd` DOUBLE NOT NULL COMMENT '前复权比例',
     `create_timestamp` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
-    `modify_timestamp` TIMESTAMP COMMENT '修改时间'
+    `modify_timestamp` TIMESTAMP NULL COMMENT '修改时间',
+    INDEX `idx_code_date` (`code`, `date`)
 );
-create index idx_code_date on `robber`.`quote_day`(`code`,`date`);
 
-drop table if exists `robber`.`quote_week`;
-create table `robber`.`quote_week` (
+create table if not exists `quote_week` (
     `id` BIGINT NOT NULL PRIMARY KEY AUTO_INCREMENT,
     `code` CHAR(8) NOT NULL COMMENT '股票代码',
     `open` DECIMAL(10,2) NOT NULL COMMENT '开盘价',
@@ -33,6 +30,28 @@ create table `robber`.`quote_week` (
     `num_of_year` INT NOT NULL COMMENT '周数',
     `xd` DOUBLE NOT NULL COMMENT '前复权比例',
     `create_timestamp` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
-    `modify_timestamp` TIMESTAMP COMMENT '修改时间'
+    `modify_timestamp` TIMESTAMP NULL COMMENT '修改时间',
+    INDEX `idx_code_date_end` (`code`, `date`)
+);
+
+create table if not exists `stock` (
+    `code` CHAR(8) NOT NULL COMMENT '股票代码',
+    `name` VARCHAR(32) NOT NULL COMMENT '名称',
+    `suspend` VARCHAR(32) NOT NULL COMMENT '停牌状态',
+    `create_timestamp` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `modify_timestamp` TIMESTAMP NULL COMMENT '修改时间',
+    PRIMARY KEY (`code`)
+);
+
+create table if not exists `task` (
+    `date` VARCHAR(32) NOT NULL COMMENT '日期',
+    `completed` TINYINT NOT NULL COMMENT '是否完成',
+    `metadata_count` INT NOT NULL COMMENT '元数据量',
+    `stock_count` INT NOT NULL COMMENT 'stock 数据量',
+    `day_count` INT NOT NULL COMMENT 'day 数据量',
+    `week_count` INT NOT NULL COMMENT 'week 数据量',
+    `callback_url` TEXT NOT NULL COMMENT 'callback url',
+    `create_timestamp` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `modify_timestamp` TIMESTAMP NULL COMMENT '修改时间',
+    PRIMARY KEY (`date`)
 );
-create index idx_code_date_end on `robber`.`quote_week`(`code`,`date`);
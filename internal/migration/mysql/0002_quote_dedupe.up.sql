@@ -0,0 +1,3 @@
+-- 清理重复数据，保留最新写入的记录，0003、0004 在此基础上建立唯一索引
+delete a from `quote_day` a join `quote_day` b on a.`code` = b.`code` and a.`date` = b.`date` and a.`id` < b.`id`;
+delete a from `quote_week` a join `quote_week` b on a.`code` = b.`code` and a.`date` = b.`date` and a.`id` < b.`id`;
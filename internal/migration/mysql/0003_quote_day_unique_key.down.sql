@@ -0,0 +1 @@
+alter table `quote_day` drop index `uk_code_date`, add index `idx_code_date` (`code`, `date`);
@@ -0,0 +1 @@
+alter table `quote_day` drop index `idx_code_date`, add unique key `uk_code_date` (`code`, `date`);
@@ -0,0 +1 @@
+alter table `quote_week` drop index `uk_code_date`, add index `idx_code_date_end` (`code`, `date`), modify column `date` TIMESTAMP NOT NULL COMMENT '开始时期';
@@ -0,0 +1 @@
+alter table `quote_week` drop index `idx_code_date_end`, add unique key `uk_code_date` (`code`, `date`), modify column `date` TIMESTAMP NOT NULL COMMENT '日期(周最后一个交易日)';
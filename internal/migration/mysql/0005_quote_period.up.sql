@@ -0,0 +1,53 @@
+create table if not exists `quote_month` (
+    `id` BIGINT NOT NULL PRIMARY KEY AUTO_INCREMENT,
+    `code` CHAR(8) NOT NULL COMMENT '股票代码',
+    `open` DECIMAL(10,2) NOT NULL COMMENT '开盘价',
+    `close` DECIMAL(10,2) NOT NULL COMMENT '收盘价',
+    `high` DECIMAL(10,2) NOT NULL COMMENT '最高价',
+    `low` DECIMAL(10,2) NOT NULL COMMENT '最低价',
+    `yesterday_closed` DECIMAL(10,2) NOT NULL COMMENT '昨日收盘价',
+    `volume` BIGINT NOT NULL COMMENT '交易量',
+    `account` DECIMAL(18,2) NOT NULL COMMENT '金额',
+    `date` TIMESTAMP NOT NULL COMMENT '日期(月最后一个交易日)',
+    `num_of_year` INT NOT NULL COMMENT '月份',
+    `xd` DOUBLE NOT NULL COMMENT '前复权比例',
+    `create_timestamp` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `modify_timestamp` TIMESTAMP NULL COMMENT '修改时间',
+    UNIQUE KEY `uk_code_date` (`code`, `date`)
+);
+
+create table if not exists `quote_quarter` (
+    `id` BIGINT NOT NULL PRIMARY KEY AUTO_INCREMENT,
+    `code` CHAR(8) NOT NULL COMMENT '股票代码',
+    `open` DECIMAL(10,2) NOT NULL COMMENT '开盘价',
+    `close` DECIMAL(10,2) NOT NULL COMMENT '收盘价',
+    `high` DECIMAL(10,2) NOT NULL COMMENT '最高价',
+    `low` DECIMAL(10,2) NOT NULL COMMENT '最低价',
+    `yesterday_closed` DECIMAL(10,2) NOT NULL COMMENT '昨日收盘价',
+    `volume` BIGINT NOT NULL COMMENT '交易量',
+    `account` DECIMAL(18,2) NOT NULL COMMENT '金额',
+    `date` TIMESTAMP NOT NULL COMMENT '日期(季最后一个交易日)',
+    `num_of_year` INT NOT NULL COMMENT '季度',
+    `xd` DOUBLE NOT NULL COMMENT '前复权比例',
+    `create_timestamp` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `modify_timestamp` TIMESTAMP NULL COMMENT '修改时间',
+    UNIQUE KEY `uk_code_date` (`code`, `date`)
+);
+
+create table if not exists `quote_year` (
+    `id` BIGINT NOT NULL PRIMARY KEY AUTO_INCREMENT,
+    `code` CHAR(8) NOT NULL COMMENT '股票代码',
+    `open` DECIMAL(10,2) NOT NULL COMMENT '开盘价',
+    `close` DECIMAL(10,2) NOT NULL COMMENT '收盘价',
+    `high` DECIMAL(10,2) NOT NULL COMMENT '最高价',
+    `low` DECIMAL(10,2) NOT NULL COMMENT '最低价',
+    `yesterday_closed` DECIMAL(10,2) NOT NULL COMMENT '昨日收盘价',
+    `volume` BIGINT NOT NULL COMMENT '交易量',
+    `account` DECIMAL(18,2) NOT NULL COMMENT '金额',
+    `date` TIMESTAMP NOT NULL COMMENT '日期(年最后一个交易日)',
+    `num_of_year` INT NOT NULL COMMENT '年份',
+    `xd` DOUBLE NOT NULL COMMENT '前复权比例',
+    `create_timestamp` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `modify_timestamp` TIMESTAMP NULL COMMENT '修改时间',
+    UNIQUE KEY `uk_code_date` (`code`, `date`)
+);
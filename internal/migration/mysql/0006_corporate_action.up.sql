@@ -0,0 +1,10 @@
+create table if not exists `corporate_action` (
+    `code` CHAR(8) NOT NULL COMMENT '股票代码',
+    `date` DATE NOT NULL COMMENT '除权除息日',
+    `kind` VARCHAR(16) NOT NULL COMMENT '类型(dividend/bonus/split/rights)',
+    `value` DECIMAL(18,6) NOT NULL COMMENT '每股派息/每股送转股数/拆股比例/每股配股数',
+    `price` DECIMAL(10,2) NOT NULL COMMENT '配股价',
+    `create_timestamp` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `modify_timestamp` TIMESTAMP NULL COMMENT '修改时间',
+    PRIMARY KEY (`code`, `date`, `kind`)
+);
@@ -0,0 +1 @@
+alter table `quote_day` drop column `factor`;
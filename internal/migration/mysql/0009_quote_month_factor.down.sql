@@ -0,0 +1 @@
+alter table `quote_month` drop column `factor`;
@@ -0,0 +1 @@
+alter table `quote_quarter` drop column `factor`;
@@ -0,0 +1 @@
+alter table `quote_quarter` add column `factor` DOUBLE NOT NULL DEFAULT 1 COMMENT '累计复权因子' after `xd`;
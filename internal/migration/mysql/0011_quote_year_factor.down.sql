@@ -0,0 +1 @@
+alter table `quote_year` drop column `factor`;
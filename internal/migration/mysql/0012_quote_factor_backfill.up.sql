@@ -0,0 +1,5 @@
+update `quote_day` q join (select `id`, exp(sum(case when `xd` > 0 then ln(`xd`) else 0 end) over (partition by `code` order by `date`)) as `factor` from `quote_day`) t on q.`id` = t.`id` set q.`factor` = t.`factor`;
+update `quote_week` q join (select `id`, exp(sum(case when `xd` > 0 then ln(`xd`) else 0 end) over (partition by `code` order by `date`)) as `factor` from `quote_week`) t on q.`id` = t.`id` set q.`factor` = t.`factor`;
+update `quote_month` q join (select `id`, exp(sum(case when `xd` > 0 then ln(`xd`) else 0 end) over (partition by `code` order by `date`)) as `factor` from `quote_month`) t on q.`id` = t.`id` set q.`factor` = t.`factor`;
+update `quote_quarter` q join (select `id`, exp(sum(case when `xd` > 0 then ln(`xd`) else 0 end) over (partition by `code` order by `date`)) as `factor` from `quote_quarter`) t on q.`id` = t.`id` set q.`factor` = t.`factor`;
+update `quote_year` q join (select `id`, exp(sum(case when `xd` > 0 then ln(`xd`) else 0 end) over (partition by `code` order by `date`)) as `factor` from `quote_year`) t on q.`id` = t.`id` set q.`factor` = t.`factor`;
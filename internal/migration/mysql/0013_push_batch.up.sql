@@ -0,0 +1,12 @@
+create table if not exists `push_batch` (
+    `id` VARCHAR(64) NOT NULL COMMENT '批次 ID',
+    `stock_count` INT NOT NULL COMMENT 'stock 数据量',
+    `day_count` INT NOT NULL COMMENT 'day 数据量',
+    `week_count` INT NOT NULL COMMENT 'week 数据量',
+    `month_count` INT NOT NULL COMMENT 'month 数据量',
+    `quarter_count` INT NOT NULL COMMENT 'quarter 数据量',
+    `year_count` INT NOT NULL COMMENT 'year 数据量',
+    `create_timestamp` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `modify_timestamp` TIMESTAMP NULL COMMENT '修改时间',
+    PRIMARY KEY (`id`)
+);
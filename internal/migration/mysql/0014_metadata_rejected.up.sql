@@ -0,0 +1,11 @@
+create table if not exists `metadata_rejected` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT COMMENT '自增 ID',
+    `code` VARCHAR(32) NOT NULL COMMENT '股票代码',
+    `date` VARCHAR(32) NOT NULL COMMENT '日期(原始数据)',
+    `reason` TEXT NOT NULL COMMENT '失败原因',
+    `metadata` TEXT NOT NULL COMMENT '原始数据(JSON)',
+    `create_timestamp` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `modify_timestamp` TIMESTAMP NULL COMMENT '修改时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_code_date` (`code`, `date`)
+);
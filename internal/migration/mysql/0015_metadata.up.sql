@@ -0,0 +1,17 @@
+create table if not exists `metadata` (
+    `id` BIGINT NOT NULL PRIMARY KEY AUTO_INCREMENT,
+    `code` CHAR(8) NOT NULL COMMENT '股票代码',
+    `name` VARCHAR(32) NOT NULL COMMENT '名称',
+    `open` DOUBLE NOT NULL COMMENT '开盘价',
+    `yesterday_closed` DOUBLE NOT NULL COMMENT '昨日收盘价',
+    `latest` DOUBLE NOT NULL COMMENT '最新价',
+    `high` DOUBLE NOT NULL COMMENT '最高价',
+    `low` DOUBLE NOT NULL COMMENT '最低价',
+    `volume` BIGINT UNSIGNED NOT NULL COMMENT '交易量',
+    `account` DOUBLE NOT NULL COMMENT '金额',
+    `date` DATE NOT NULL COMMENT '日期',
+    `time` VARCHAR(16) NOT NULL COMMENT '时间',
+    `suspend` VARCHAR(32) NOT NULL COMMENT '停牌状态',
+    `create_timestamp` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    INDEX `idx_code_date` (`code`, `date`)
+);
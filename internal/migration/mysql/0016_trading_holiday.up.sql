@@ -0,0 +1,7 @@
+create table if not exists `trading_holiday` (
+    `date` DATE NOT NULL COMMENT '休市日期',
+    `name` VARCHAR(64) NOT NULL COMMENT '名称',
+    `create_timestamp` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `modify_timestamp` TIMESTAMP NULL COMMENT '修改时间',
+    PRIMARY KEY (`date`)
+);
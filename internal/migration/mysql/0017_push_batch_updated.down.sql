@@ -0,0 +1,7 @@
+alter table `push_batch`
+    drop column `stock_updated`,
+    drop column `day_updated`,
+    drop column `week_updated`,
+    drop column `month_updated`,
+    drop column `quarter_updated`,
+    drop column `year_updated`;
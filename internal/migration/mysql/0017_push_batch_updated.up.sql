@@ -0,0 +1,8 @@
+-- 批次写入数量中覆盖更新已有数据的数量，新增数量 = *_count - *_updated
+alter table `push_batch`
+    add column `stock_updated` INT NOT NULL DEFAULT 0 COMMENT 'stock 更新数量' after `year_count`,
+    add column `day_updated` INT NOT NULL DEFAULT 0 COMMENT 'day 更新数量' after `stock_updated`,
+    add column `week_updated` INT NOT NULL DEFAULT 0 COMMENT 'week 更新数量' after `day_updated`,
+    add column `month_updated` INT NOT NULL DEFAULT 0 COMMENT 'month 更新数量' after `week_updated`,
+    add column `quarter_updated` INT NOT NULL DEFAULT 0 COMMENT 'quarter 更新数量' after `month_updated`,
+    add column `year_updated` INT NOT NULL DEFAULT 0 COMMENT 'year 更新数量' after `quarter_updated`;
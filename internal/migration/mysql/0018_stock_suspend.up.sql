@@ -0,0 +1,9 @@
+create table if not exists `stock_suspend` (
+    `code` CHAR(8) NOT NULL COMMENT '股票代码',
+    `date` DATE NOT NULL COMMENT '日期',
+    `suspend` VARCHAR(32) NOT NULL COMMENT '停牌状态',
+    `create_timestamp` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `modify_timestamp` TIMESTAMP NULL COMMENT '修改时间',
+    PRIMARY KEY (`code`, `date`),
+    INDEX `idx_date` (`date`)
+);
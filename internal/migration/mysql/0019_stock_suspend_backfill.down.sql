@@ -0,0 +1 @@
+-- 回填的停牌状态随 0018 回滚删除
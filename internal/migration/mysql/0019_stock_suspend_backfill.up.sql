@@ -0,0 +1,3 @@
+-- 由归档的原始数据回填每日停牌状态，同一 code、date 以最后写入的为准
+insert ignore into `stock_suspend` (`code`, `date`, `suspend`, `create_timestamp`) select `code`, `date`, `suspend`, now() from `metadata` where `id` in (select max(`id`) from `metadata` group by `code`, `date`);
+update `stock` s join `stock_suspend` h on h.`code` = s.`code` and h.`date` = (select max(`date`) from `stock_suspend` where `code` = s.`code`) set s.`suspend` = h.`suspend`;
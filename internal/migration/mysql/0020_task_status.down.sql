@@ -0,0 +1,8 @@
+alter table `task`
+    drop column `status`,
+    drop column `last_error`,
+    drop column `ingesting_timestamp`,
+    drop column `verifying_timestamp`,
+    drop column `callback_pending_timestamp`,
+    drop column `completed_timestamp`,
+    drop column `failed_timestamp`;
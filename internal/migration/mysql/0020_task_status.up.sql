@@ -0,0 +1,9 @@
+-- task 状态: created、ingesting、verifying、callback_pending、completed、failed
+alter table `task`
+    add column `status` VARCHAR(32) NOT NULL DEFAULT 'created' COMMENT '状态' after `date`,
+    add column `last_error` VARCHAR(1024) NOT NULL DEFAULT '' COMMENT '最近一次错误' after `status`,
+    add column `ingesting_timestamp` TIMESTAMP NULL COMMENT '开始写入时间' after `callback_url`,
+    add column `verifying_timestamp` TIMESTAMP NULL COMMENT '开始校验时间' after `ingesting_timestamp`,
+    add column `callback_pending_timestamp` TIMESTAMP NULL COMMENT '等待回调时间' after `verifying_timestamp`,
+    add column `completed_timestamp` TIMESTAMP NULL COMMENT '完成时间' after `callback_pending_timestamp`,
+    add column `failed_timestamp` TIMESTAMP NULL COMMENT '失败时间' after `completed_timestamp`;
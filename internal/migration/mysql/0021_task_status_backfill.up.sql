@@ -0,0 +1 @@
+update `task` set `status` = 'completed', `completed_timestamp` = `modify_timestamp` where `completed` = 1;
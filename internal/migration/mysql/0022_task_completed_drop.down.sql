@@ -0,0 +1 @@
+alter table `task` add column `completed` TINYINT NOT NULL DEFAULT 0 COMMENT '是否完成' after `date`;
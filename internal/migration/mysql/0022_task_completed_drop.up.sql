@@ -0,0 +1,2 @@
+-- 是否完成由 status 表示
+alter table `task` drop column `completed`;
@@ -0,0 +1,3 @@
+alter table `task`
+    drop column `next_callback_timestamp`,
+    drop column `callback_attempts`;
@@ -0,0 +1,4 @@
+-- 进入 callback_pending 后本轮已投递的次数及下次投递时间，重新投递时清零
+alter table `task`
+    add column `callback_attempts` INT NOT NULL DEFAULT 0 COMMENT '回调投递次数' after `callback_url`,
+    add column `next_callback_timestamp` TIMESTAMP NULL COMMENT '下次回调时间' after `callback_attempts`;
@@ -0,0 +1 @@
+update `task` set `next_callback_timestamp` = now() where `status` = 'callback_pending';
@@ -0,0 +1,12 @@
+create table if not exists `task_callback_attempt` (
+    `id` BIGINT NOT NULL PRIMARY KEY AUTO_INCREMENT,
+    `date` VARCHAR(32) NOT NULL COMMENT '日期',
+    `attempt` INT NOT NULL COMMENT '本轮第几次投递',
+    `url` TEXT NOT NULL COMMENT 'callback url',
+    `status_code` INT NOT NULL COMMENT 'HTTP 状态码，请求未完成时为 0',
+    `body` VARCHAR(512) NOT NULL COMMENT '响应内容摘要',
+    `latency` BIGINT NOT NULL COMMENT '耗时(ms)',
+    `error` VARCHAR(1024) NOT NULL COMMENT '错误',
+    `create_timestamp` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    INDEX `idx_date` (`date`)
+);
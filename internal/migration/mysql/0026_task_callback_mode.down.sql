@@ -0,0 +1,3 @@
+alter table `task`
+    drop column `callback_secret`,
+    drop column `callback_mode`;
@@ -0,0 +1,5 @@
+alter table `task`
+    drop column `inconsistent`,
+    drop column `actual_week_count`,
+    drop column `actual_day_count`,
+    drop column `actual_stock_count`;
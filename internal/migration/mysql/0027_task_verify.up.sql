@@ -0,0 +1,6 @@
+-- Complete 时服务端按 date 统计的实际数量，与上报数量的差异超出容差时 inconsistent 为 1
+alter table `task`
+    add column `actual_stock_count` INT NOT NULL DEFAULT 0 COMMENT '实际 stock 数据量' after `week_count`,
+    add column `actual_day_count` INT NOT NULL DEFAULT 0 COMMENT '实际 day 数据量' after `actual_stock_count`,
+    add column `actual_week_count` INT NOT NULL DEFAULT 0 COMMENT '实际 week 数据量' after `actual_day_count`,
+    add column `inconsistent` TINYINT NOT NULL DEFAULT 0 COMMENT '上报数量与实际数量是否不一致' after `actual_week_count`;
@@ -0,0 +1 @@
+alter table `push_batch` drop column `status`;
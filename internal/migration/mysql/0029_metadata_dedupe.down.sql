@@ -0,0 +1 @@
+-- 已删除的重复数据无法恢复
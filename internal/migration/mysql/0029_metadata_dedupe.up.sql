@@ -0,0 +1,2 @@
+-- 同一 code、date、time 的原始数据仅保留最早写入的一条，0030 在此基础上建立唯一索引
+delete m1 from `metadata` m1 join `metadata` m2 on m1.`code` = m2.`code` and m1.`date` = m2.`date` and m1.`time` = m2.`time` and m1.`id` > m2.`id`;
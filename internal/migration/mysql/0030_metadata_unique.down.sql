@@ -0,0 +1 @@
+alter table `metadata` drop index `uk_code_date_time`;
@@ -0,0 +1,2 @@
+-- 重复提交的原始数据不再追加
+alter table `metadata` add unique key `uk_code_date_time` (`code`, `date`, `time`);
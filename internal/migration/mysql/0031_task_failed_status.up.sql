@@ -0,0 +1,2 @@
+-- 流转到 failed 前的状态，仅回调投递失败的 task 可以重新投递
+alter table `task` add column `failed_status` VARCHAR(32) NOT NULL DEFAULT '' COMMENT '失败前状态' after `failed_timestamp`;
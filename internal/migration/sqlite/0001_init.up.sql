@@ -0,0 +1,55 @@
+create table if not exists stock (
+    code CHAR(8) NOT NULL PRIMARY KEY,
+    name VARCHAR(32) NOT NULL,
+    suspend VARCHAR(32) NOT NULL,
+    create_timestamp TEXT NOT NULL,
+    modify_timestamp TEXT
+);
+
+create table if not exists quote_day (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    code CHAR(8) NOT NULL,
+    open REAL NOT NULL,
+    close REAL NOT NULL,
+    high REAL NOT NULL,
+    low REAL NOT NULL,
+    yesterday_closed REAL NOT NULL,
+    volume INTEGER NOT NULL,
+    account REAL NOT NULL,
+    date TEXT NOT NULL,
+    num_of_year INTEGER NOT NULL,
+    xd REAL NOT NULL,
+    create_timestamp TEXT NOT NULL,
+    modify_timestamp TEXT
+);
+create index if not exists idx_quote_day_code_date on quote_day(code, date);
+
+create table if not exists quote_week (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    code CHAR(8) NOT NULL,
+    open REAL NOT NULL,
+    close REAL NOT NULL,
+    high REAL NOT NULL,
+    low REAL NOT NULL,
+    yesterday_closed REAL NOT NULL,
+    volume INTEGER NOT NULL,
+    account REAL NOT NULL,
+    date TEXT NOT NULL,
+    num_of_year INTEGER NOT NULL,
+    xd REAL NOT NULL,
+    create_timestamp TEXT NOT NULL,
+    modify_timestamp TEXT
+);
+create index if not exists idx_quote_week_code_date on quote_week(code, date);
+
+create table if not exists task (
+    date VARCHAR(32) NOT NULL PRIMARY KEY,
+    completed TINYINT NOT NULL,
+    metadata_count INTEGER NOT NULL,
+    stock_count INTEGER NOT NULL,
+    day_count INTEGER NOT NULL,
+    week_count INTEGER NOT NULL,
+    callback_url TEXT NOT NULL,
+    create_timestamp TEXT NOT NULL,
+    modify_timestamp TEXT
+);
@@ -0,0 +1,3 @@
+-- 清理重复数据，保留最新写入的记录，0003、0004 在此基础上建立唯一索引
+delete from quote_day where id not in (select max(id) from quote_day group by code, date);
+delete from quote_week where id not in (select max(id) from quote_week group by code, date);
@@ -0,0 +1,2 @@
+drop index if exists uk_quote_day_code_date;
+create index if not exists idx_quote_day_code_date on quote_day(code, date);
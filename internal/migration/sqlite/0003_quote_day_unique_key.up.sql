@@ -0,0 +1,2 @@
+drop index if exists idx_quote_day_code_date;
+create unique index if not exists uk_quote_day_code_date on quote_day(code, date);
@@ -0,0 +1,3 @@
+drop table if exists quote_month;
+drop table if exists quote_quarter;
+drop table if exists quote_year;
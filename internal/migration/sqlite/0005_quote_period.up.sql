@@ -0,0 +1,53 @@
+create table if not exists quote_month (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    code CHAR(8) NOT NULL,
+    open REAL NOT NULL,
+    close REAL NOT NULL,
+    high REAL NOT NULL,
+    low REAL NOT NULL,
+    yesterday_closed REAL NOT NULL,
+    volume INTEGER NOT NULL,
+    account REAL NOT NULL,
+    date TEXT NOT NULL,
+    num_of_year INTEGER NOT NULL,
+    xd REAL NOT NULL,
+    create_timestamp TEXT NOT NULL,
+    modify_timestamp TEXT
+);
+create unique index if not exists uk_quote_month_code_date on quote_month(code, date);
+
+create table if not exists quote_quarter (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    code CHAR(8) NOT NULL,
+    open REAL NOT NULL,
+    close REAL NOT NULL,
+    high REAL NOT NULL,
+    low REAL NOT NULL,
+    yesterday_closed REAL NOT NULL,
+    volume INTEGER NOT NULL,
+    account REAL NOT NULL,
+    date TEXT NOT NULL,
+    num_of_year INTEGER NOT NULL,
+    xd REAL NOT NULL,
+    create_timestamp TEXT NOT NULL,
+    modify_timestamp TEXT
+);
+create unique index if not exists uk_quote_quarter_code_date on quote_quarter(code, date);
+
+create table if not exists quote_year (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    code CHAR(8) NOT NULL,
+    open REAL NOT NULL,
+    close REAL NOT NULL,
+    high REAL NOT NULL,
+    low REAL NOT NULL,
+    yesterday_closed REAL NOT NULL,
+    volume INTEGER NOT NULL,
+    account REAL NOT NULL,
+    date TEXT NOT NULL,
+    num_of_year INTEGER NOT NULL,
+    xd REAL NOT NULL,
+    create_timestamp TEXT NOT NULL,
+    modify_timestamp TEXT
+);
+create unique index if not exists uk_quote_year_code_date on quote_year(code, date);
@@ -0,0 +1 @@
+drop table if exists corporate_action;
@@ -0,0 +1,10 @@
+create table if not exists corporate_action (
+    code CHAR(8) NOT NULL,
+    date TEXT NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    value REAL NOT NULL,
+    price REAL NOT NULL,
+    create_timestamp TEXT NOT NULL,
+    modify_timestamp TEXT,
+    PRIMARY KEY (code, date, kind)
+);
@@ -0,0 +1,2 @@
+-- 累计复权因子: 截止当日(含)全部复权比例的乘积，由 0012 回填
+alter table quote_day add column factor REAL NOT NULL DEFAULT 1;
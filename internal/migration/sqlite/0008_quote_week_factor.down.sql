@@ -0,0 +1 @@
+alter table quote_week drop column factor;
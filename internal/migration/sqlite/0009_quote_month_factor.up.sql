@@ -0,0 +1 @@
+alter table quote_month add column factor REAL NOT NULL DEFAULT 1;
@@ -0,0 +1 @@
+alter table quote_year add column factor REAL NOT NULL DEFAULT 1;
@@ -0,0 +1 @@
+-- 回填的复权因子随 0007 ~ 0011 回滚删除
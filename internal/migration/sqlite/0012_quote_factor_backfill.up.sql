@@ -0,0 +1,5 @@
+update quote_day set factor = t.factor from (select id, exp(sum(case when xd > 0 then ln(xd) else 0 end) over (partition by code order by date)) as factor from quote_day) t where t.id = quote_day.id;
+update quote_week set factor = t.factor from (select id, exp(sum(case when xd > 0 then ln(xd) else 0 end) over (partition by code order by date)) as factor from quote_week) t where t.id = quote_week.id;
+update quote_month set factor = t.factor from (select id, exp(sum(case when xd > 0 then ln(xd) else 0 end) over (partition by code order by date)) as factor from quote_month) t where t.id = quote_month.id;
+update quote_quarter set factor = t.factor from (select id, exp(sum(case when xd > 0 then ln(xd) else 0 end) over (partition by code order by date)) as factor from quote_quarter) t where t.id = quote_quarter.id;
+update quote_year set factor = t.factor from (select id, exp(sum(case when xd > 0 then ln(xd) else 0 end) over (partition by code order by date)) as factor from quote_year) t where t.id = quote_year.id;
@@ -0,0 +1,11 @@
+create table if not exists push_batch (
+    id VARCHAR(64) NOT NULL PRIMARY KEY,
+    stock_count INTEGER NOT NULL,
+    day_count INTEGER NOT NULL,
+    week_count INTEGER NOT NULL,
+    month_count INTEGER NOT NULL,
+    quarter_count INTEGER NOT NULL,
+    year_count INTEGER NOT NULL,
+    create_timestamp TEXT NOT NULL,
+    modify_timestamp TEXT
+);
@@ -0,0 +1,10 @@
+create table if not exists metadata_rejected (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    code VARCHAR(32) NOT NULL,
+    date VARCHAR(32) NOT NULL,
+    reason TEXT NOT NULL,
+    metadata TEXT NOT NULL,
+    create_timestamp TEXT NOT NULL,
+    modify_timestamp TEXT,
+    UNIQUE (code, date)
+);
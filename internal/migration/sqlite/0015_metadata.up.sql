@@ -0,0 +1,18 @@
+create table if not exists metadata (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    code CHAR(8) NOT NULL,
+    name VARCHAR(32) NOT NULL,
+    open REAL NOT NULL,
+    yesterday_closed REAL NOT NULL,
+    latest REAL NOT NULL,
+    high REAL NOT NULL,
+    low REAL NOT NULL,
+    volume INTEGER NOT NULL,
+    account REAL NOT NULL,
+    date TEXT NOT NULL,
+    time VARCHAR(16) NOT NULL,
+    suspend VARCHAR(32) NOT NULL,
+    create_timestamp TEXT NOT NULL
+);
+
+create index if not exists idx_metadata_code_date on metadata(code, date);
@@ -0,0 +1,6 @@
+create table if not exists trading_holiday (
+    date TEXT NOT NULL PRIMARY KEY,
+    name VARCHAR(64) NOT NULL,
+    create_timestamp TEXT NOT NULL,
+    modify_timestamp TEXT
+);
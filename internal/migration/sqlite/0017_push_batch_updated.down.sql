@@ -0,0 +1,6 @@
+alter table push_batch drop column stock_updated;
+alter table push_batch drop column day_updated;
+alter table push_batch drop column week_updated;
+alter table push_batch drop column month_updated;
+alter table push_batch drop column quarter_updated;
+alter table push_batch drop column year_updated;
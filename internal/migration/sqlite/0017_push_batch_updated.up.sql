@@ -0,0 +1,7 @@
+-- 批次写入数量中覆盖更新已有数据的数量，新增数量 = *_count - *_updated
+alter table push_batch add column stock_updated INTEGER NOT NULL DEFAULT 0;
+alter table push_batch add column day_updated INTEGER NOT NULL DEFAULT 0;
+alter table push_batch add column week_updated INTEGER NOT NULL DEFAULT 0;
+alter table push_batch add column month_updated INTEGER NOT NULL DEFAULT 0;
+alter table push_batch add column quarter_updated INTEGER NOT NULL DEFAULT 0;
+alter table push_batch add column year_updated INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,9 @@
+create table if not exists stock_suspend (
+    code CHAR(8) NOT NULL,
+    date TEXT NOT NULL,
+    suspend VARCHAR(32) NOT NULL,
+    create_timestamp TEXT NOT NULL,
+    modify_timestamp TEXT,
+    PRIMARY KEY (code, date)
+);
+create index if not exists idx_stock_suspend_date on stock_suspend(date);
@@ -0,0 +1,3 @@
+-- 由归档的原始数据回填每日停牌状态，同一 code、date 以最后写入的为准
+insert or ignore into stock_suspend (code, date, suspend, create_timestamp) select code, date, suspend, datetime('now', 'localtime') from metadata where id in (select max(id) from metadata group by code, date);
+update stock set suspend = (select suspend from stock_suspend h where h.code = stock.code order by h.date desc limit 1) where exists (select 1 from stock_suspend h where h.code = stock.code);
@@ -0,0 +1,7 @@
+alter table task drop column status;
+alter table task drop column last_error;
+alter table task drop column ingesting_timestamp;
+alter table task drop column verifying_timestamp;
+alter table task drop column callback_pending_timestamp;
+alter table task drop column completed_timestamp;
+alter table task drop column failed_timestamp;
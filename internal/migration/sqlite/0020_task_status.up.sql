@@ -0,0 +1,8 @@
+-- task 状态: created、ingesting、verifying、callback_pending、completed、failed
+alter table task add column status VARCHAR(32) NOT NULL DEFAULT 'created';
+alter table task add column last_error VARCHAR(1024) NOT NULL DEFAULT '';
+alter table task add column ingesting_timestamp TEXT;
+alter table task add column verifying_timestamp TEXT;
+alter table task add column callback_pending_timestamp TEXT;
+alter table task add column completed_timestamp TEXT;
+alter table task add column failed_timestamp TEXT;
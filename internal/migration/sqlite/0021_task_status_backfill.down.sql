@@ -0,0 +1 @@
+update task set completed = 1 where status = 'completed';
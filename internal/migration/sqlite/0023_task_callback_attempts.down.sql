@@ -0,0 +1,2 @@
+alter table task drop column next_callback_timestamp;
+alter table task drop column callback_attempts;
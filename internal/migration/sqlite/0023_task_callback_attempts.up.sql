@@ -0,0 +1,3 @@
+-- 进入 callback_pending 后本轮已投递的次数及下次投递时间，重新投递时清零
+alter table task add column callback_attempts INTEGER NOT NULL DEFAULT 0;
+alter table task add column next_callback_timestamp TEXT;
@@ -0,0 +1 @@
+-- 回填的下次回调时间随 0023 回滚删除
@@ -0,0 +1 @@
+update task set next_callback_timestamp = datetime('now', 'localtime') where status = 'callback_pending';
@@ -0,0 +1,2 @@
+drop index if exists idx_task_callback_attempt_date;
+drop table if exists task_callback_attempt;
@@ -0,0 +1,13 @@
+create table if not exists task_callback_attempt (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    date VARCHAR(32) NOT NULL,
+    attempt INTEGER NOT NULL,
+    url TEXT NOT NULL,
+    status_code INTEGER NOT NULL,
+    body VARCHAR(512) NOT NULL,
+    latency INTEGER NOT NULL,
+    error VARCHAR(1024) NOT NULL,
+    create_timestamp TEXT NOT NULL
+);
+
+create index if not exists idx_task_callback_attempt_date on task_callback_attempt(date);
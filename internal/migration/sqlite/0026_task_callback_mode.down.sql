@@ -0,0 +1,2 @@
+alter table task drop column callback_secret;
+alter table task drop column callback_mode;
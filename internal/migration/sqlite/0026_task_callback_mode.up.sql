@@ -0,0 +1,3 @@
+-- 回调方式: get 为无请求体的旧版回调，post 以 JSON 推送 task 统计并使用 callback_secret 签名
+alter table task add column callback_mode VARCHAR(16) NOT NULL DEFAULT 'get';
+alter table task add column callback_secret VARCHAR(256) NOT NULL DEFAULT '';
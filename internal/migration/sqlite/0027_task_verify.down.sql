@@ -0,0 +1,4 @@
+alter table task drop column inconsistent;
+alter table task drop column actual_week_count;
+alter table task drop column actual_day_count;
+alter table task drop column actual_stock_count;
@@ -0,0 +1,5 @@
+-- Complete 时服务端按 date 统计的实际数量，与上报数量的差异超出容差时 inconsistent 为 1
+alter table task add column actual_stock_count INTEGER NOT NULL DEFAULT 0;
+alter table task add column actual_day_count INTEGER NOT NULL DEFAULT 0;
+alter table task add column actual_week_count INTEGER NOT NULL DEFAULT 0;
+alter table task add column inconsistent TINYINT NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+-- PushData 开始处理前以 pending 状态预占批次 ID，处理完成后置为 done，已有批次均已处理完成
+alter table push_batch add column status VARCHAR(16) NOT NULL DEFAULT 'done';
@@ -0,0 +1,2 @@
+-- 同一 code、date、time 的原始数据仅保留最早写入的一条，0030 在此基础上建立唯一索引
+delete from metadata where id not in (select min(id) from metadata group by code, date, time);
@@ -0,0 +1 @@
+drop index if exists uk_metadata_code_date_time;
@@ -0,0 +1,2 @@
+-- 重复提交的原始数据不再追加
+create unique index if not exists uk_metadata_code_date_time on metadata(code, date, time);
@@ -0,0 +1 @@
+alter table task drop column failed_status;
@@ -0,0 +1 @@
+-- 回填的失败前状态随 0031 回滚删除
@@ -0,0 +1,2 @@
+-- 最近一次进入 callback_pending 晚于进入 verifying 时，失败发生在回调投递阶段
+update task set failed_status = 'callback_pending' where status = 'failed' and callback_pending_timestamp is not null and callback_pending_timestamp >= verifying_timestamp;
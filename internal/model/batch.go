@@ -0,0 +1,148 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/eviltomorrow/robber-core/pkg/mysql"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// 批次状态，pending 为正在处理，done 为已处理完成
+const (
+	BatchPending = "pending"
+	BatchDone    = "done"
+)
+
+func BatchWithSelectOne(exec mysql.Exec, id string, timeout time.Duration) (*Batch, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var _sql = `select id, status, stock_count, day_count, week_count, month_count, quarter_count, year_count, stock_updated, day_updated, week_updated, month_updated, quarter_updated, year_updated, create_timestamp, modify_timestamp from push_batch where id = ?`
+	row := exec.QueryRowContext(ctx, _sql, id)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	var batch = &Batch{}
+	if err := row.Scan(
+		&batch.Id,
+		&batch.Status,
+		&batch.StockCount,
+		&batch.DayCount,
+		&batch.WeekCount,
+		&batch.MonthCount,
+		&batch.QuarterCount,
+		&batch.YearCount,
+		&batch.StockUpdated,
+		&batch.DayUpdated,
+		&batch.WeekUpdated,
+		&batch.MonthUpdated,
+		&batch.QuarterUpdated,
+		&batch.YearUpdated,
+		&batch.CreateTimestamp,
+		&batch.ModifyTimestamp,
+	); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// BatchWithInsertOne 新增批次，id 已存在时不写入并返回 0，用于预占批次 ID
+func BatchWithInsertOne(exec mysql.Exec, batch *Batch, timeout time.Duration) (int64, error) {
+	if batch == nil {
+		return 0, nil
+	}
+
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var _sql = `insert ignore into push_batch(id, status, stock_count, day_count, week_count, month_count, quarter_count, year_count, stock_updated, day_updated, week_updated, month_updated, quarter_updated, year_updated, create_timestamp) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, now())`
+	result, err := exec.ExecContext(ctx, _sql, batch.Id, batch.Status, batch.StockCount, batch.DayCount, batch.WeekCount, batch.MonthCount, batch.QuarterCount, batch.YearCount, batch.StockUpdated, batch.DayUpdated, batch.WeekUpdated, batch.MonthUpdated, batch.QuarterUpdated, batch.YearUpdated)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// BatchWithUpdateOne 记录 pending 批次的写入数量并置为 batch.Status
+func BatchWithUpdateOne(exec mysql.Exec, batch *Batch, timeout time.Duration) (int64, error) {
+	if batch == nil {
+		return 0, nil
+	}
+
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var _sql = `update push_batch set status = ?, stock_count = ?, day_count = ?, week_count = ?, month_count = ?, quarter_count = ?, year_count = ?, stock_updated = ?, day_updated = ?, week_updated = ?, month_updated = ?, quarter_updated = ?, year_updated = ?, modify_timestamp = now() where id = ? and status = ?`
+	result, err := exec.ExecContext(ctx, _sql, batch.Status, batch.StockCount, batch.DayCount, batch.WeekCount, batch.MonthCount, batch.QuarterCount, batch.YearCount, batch.StockUpdated, batch.DayUpdated, batch.WeekUpdated, batch.MonthUpdated, batch.QuarterUpdated, batch.YearUpdated, batch.Id, BatchPending)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// BatchWithDeleteOne 删除 id 对应的 pending 批次以释放预占，before 不为零值时仅删除 before 之前创建的批次
+func BatchWithDeleteOne(exec mysql.Exec, id string, before time.Time, timeout time.Duration) (int64, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var (
+		_sql = `delete from push_batch where id = ? and status = ?`
+		args = []interface{}{id, BatchPending}
+	)
+	if !before.IsZero() {
+		_sql += ` and create_timestamp < ?`
+		args = append(args, before)
+	}
+	result, err := exec.ExecContext(ctx, _sql, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const (
+	FieldBatchID              = "id"
+	FieldBatchStatus          = "status"
+	FieldBatchStockCount      = "stock_count"
+	FieldBatchDayCount        = "day_count"
+	FieldBatchWeekCount       = "week_count"
+	FieldBatchMonthCount      = "month_count"
+	FieldBatchQuarterCount    = "quarter_count"
+	FieldBatchYearCount       = "year_count"
+	FieldBatchStockUpdated    = "stock_updated"
+	FieldBatchDayUpdated      = "day_updated"
+	FieldBatchWeekUpdated     = "week_updated"
+	FieldBatchMonthUpdated    = "month_updated"
+	FieldBatchQuarterUpdated  = "quarter_updated"
+	FieldBatchYearUpdated     = "year_updated"
+	FieldBatchCreateTimestamp = "create_timestamp"
+	FieldBatchModifyTimestamp = "modify_timestamp"
+)
+
+// Batch PushData 批次及其写入结果，*Count 为新增与更新数量之和，*Updated 为其中更新的数量
+type Batch struct {
+	Id              string       `json:"id"`
+	Status          string       `json:"status"`
+	StockCount      int64        `json:"stock_count"`
+	DayCount        int64        `json:"day_count"`
+	WeekCount       int64        `json:"week_count"`
+	MonthCount      int64        `json:"month_count"`
+	QuarterCount    int64        `json:"quarter_count"`
+	YearCount       int64        `json:"year_count"`
+	StockUpdated    int64        `json:"stock_updated"`
+	DayUpdated      int64        `json:"day_updated"`
+	WeekUpdated     int64        `json:"week_updated"`
+	MonthUpdated    int64        `json:"month_updated"`
+	QuarterUpdated  int64        `json:"quarter_updated"`
+	YearUpdated     int64        `json:"year_updated"`
+	CreateTimestamp time.Time    `json:"create_timestamp"`
+	ModifyTimestamp sql.NullTime `json:"modify_timestamp"`
+}
+
+func (b *Batch) String() string {
+	buf, _ := jsoniter.ConfigCompatibleWithStandardLibrary.Marshal(b)
+	return string(buf)
+}
@@ -0,0 +1,197 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eviltomorrow/robber-core/pkg/mysql"
+	jsoniter "github.com/json-iterator/go"
+)
+
+const (
+	ActionDividend = "dividend"
+	ActionBonus    = "bonus"
+	ActionSplit    = "split"
+	ActionRights   = "rights"
+)
+
+func CorporateActionWithInsertOrUpdateMany(exec mysql.Exec, actions []*CorporateAction, timeout time.Duration) (int64, error) {
+	if len(actions) == 0 {
+		return 0, nil
+	}
+
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var fields = make([]string, 0, len(actions))
+	var args = make([]interface{}, 0, 5*len(actions))
+	for _, action := range actions {
+		fields = append(fields, "(?, ?, ?, ?, ?, now(), null)")
+		args = append(args, action.Code)
+		args = append(args, action.Date.Format("2006-01-02"))
+		args = append(args, action.Kind)
+		args = append(args, action.Value)
+		args = append(args, action.Price)
+	}
+
+	var _sql = fmt.Sprintf("insert into corporate_action (%s) values %s on duplicate key update value = values(value), price = values(price), modify_timestamp = now()", strings.Join(corporateActionFields, ","), strings.Join(fields, ","))
+	result, err := exec.ExecContext(ctx, _sql, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// CorporateActionWithSelectMany 查询 code 在 [begin, end] 之间的公司行为，按 date 升序
+func CorporateActionWithSelectMany(exec mysql.Exec, code string, begin, end string, timeout time.Duration) ([]*CorporateAction, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var _sql = `select code, date, kind, value, price, create_timestamp, modify_timestamp from corporate_action where code = ? and date between ? and ? order by date asc, kind asc`
+	rows, err := exec.QueryContext(ctx, _sql, code, begin, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var actions = make([]*CorporateAction, 0, 4)
+	for rows.Next() {
+		var action = &CorporateAction{}
+		if err := rows.Scan(
+			&action.Code,
+			&action.Date,
+			&action.Kind,
+			&action.Value,
+			&action.Price,
+			&action.CreateTimestamp,
+			&action.ModifyTimestamp,
+		); err != nil {
+			return nil, err
+		}
+		actions = append(actions, action)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return actions, nil
+}
+
+// CorporateActionWithSelectManyByCodes 批量查询 codes 在 [begin, end] 之间的公司行为，按 code 分组，组内按 date 升序
+func CorporateActionWithSelectManyByCodes(exec mysql.Exec, codes []string, begin, end string, timeout time.Duration) (map[string][]*CorporateAction, error) {
+	if len(codes) == 0 {
+		return map[string][]*CorporateAction{}, nil
+	}
+
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var fields = make([]string, 0, len(codes))
+	var args = make([]interface{}, 0, len(codes)+2)
+	for _, code := range codes {
+		fields = append(fields, "?")
+		args = append(args, code)
+	}
+	args = append(args, begin, end)
+
+	var _sql = fmt.Sprintf("select code, date, kind, value, price, create_timestamp, modify_timestamp from corporate_action where code in (%s) and date between ? and ? order by code asc, date asc, kind asc", strings.Join(fields, ","))
+	rows, err := exec.QueryContext(ctx, _sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var data = make(map[string][]*CorporateAction, len(codes))
+	for rows.Next() {
+		var action = &CorporateAction{}
+		if err := rows.Scan(
+			&action.Code,
+			&action.Date,
+			&action.Kind,
+			&action.Value,
+			&action.Price,
+			&action.CreateTimestamp,
+			&action.ModifyTimestamp,
+		); err != nil {
+			return nil, err
+		}
+		data[action.Code] = append(data[action.Code], action)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ExRightFactor 根据除权除息日的公司行为计算复权比例
+// 除权参考价 = (前收盘价 - 每股派息 + 每股配股数 * 配股价) / ((1 + 每股送转股数 + 每股配股数) * 拆股比例)
+func ExRightFactor(actions []*CorporateAction, closed float64) float64 {
+	if len(actions) == 0 || closed <= 0 {
+		return 1.0
+	}
+
+	var (
+		dividend, bonus, rights, price float64
+		split                          = 1.0
+	)
+	for _, action := range actions {
+		switch action.Kind {
+		case ActionDividend:
+			dividend += action.Value
+		case ActionBonus:
+			bonus += action.Value
+		case ActionSplit:
+			if action.Value > 0 {
+				split *= action.Value
+			}
+		case ActionRights:
+			rights += action.Value
+			price = action.Price
+		}
+	}
+
+	var reference = (closed - dividend + rights*price) / ((1 + bonus + rights) * split)
+	if reference <= 0 {
+		return 1.0
+	}
+	return reference / closed
+}
+
+const (
+	FieldCorporateActionCode            = "code"
+	FieldCorporateActionDate            = "date"
+	FieldCorporateActionKind            = "kind"
+	FieldCorporateActionValue           = "value"
+	FieldCorporateActionPrice           = "price"
+	FieldCorporateActionCreateTimestamp = "create_timestamp"
+	FieldCorporateActionModifyTimestamp = "modify_timestamp"
+)
+
+var corporateActionFields = []string{
+	FieldCorporateActionCode,
+	FieldCorporateActionDate,
+	FieldCorporateActionKind,
+	FieldCorporateActionValue,
+	FieldCorporateActionPrice,
+	FieldCorporateActionCreateTimestamp,
+	FieldCorporateActionModifyTimestamp,
+}
+
+// CorporateAction 公司行为(派息、送转股、拆股、配股)
+// Value: 派息为每股现金，送转股、配股为每股股数，拆股为拆分后股数/拆分前股数；Price: 配股价
+type CorporateAction struct {
+	Code            string       `json:"code"`
+	Date            time.Time    `json:"date"`
+	Kind            string       `json:"kind"`
+	Value           float64      `json:"value"`
+	Price           float64      `json:"price"`
+	CreateTimestamp time.Time    `json:"create_timestamp"`
+	ModifyTimestamp sql.NullTime `json:"modify_timestamp"`
+}
+
+func (c *CorporateAction) String() string {
+	buf, _ := jsoniter.ConfigCompatibleWithStandardLibrary.Marshal(c)
+	return string(buf)
+}
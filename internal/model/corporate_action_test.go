@@ -0,0 +1,28 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExRightFactor(t *testing.T) {
+	_assert := assert.New(t)
+
+	var date = time.Date(2021, time.December, 14, 0, 0, 0, 0, time.Local)
+	_assert.Equal(1.0, ExRightFactor(nil, 10.00))
+	_assert.Equal(1.0, ExRightFactor([]*CorporateAction{{Kind: ActionSplit, Value: 2}}, 0))
+
+	// 10 派 1 元: (10.00 - 0.10) / 10.00
+	_assert.InDelta(0.99, ExRightFactor([]*CorporateAction{{Date: date, Kind: ActionDividend, Value: 0.10}}, 10.00), 1e-9)
+	// 1 拆 2
+	_assert.InDelta(0.5, ExRightFactor([]*CorporateAction{{Date: date, Kind: ActionSplit, Value: 2}}, 10.00), 1e-9)
+	// 10 送 5 且派 1 元: (10.00 - 0.10) / 1.5
+	_assert.InDelta(0.66, ExRightFactor([]*CorporateAction{
+		{Date: date, Kind: ActionDividend, Value: 0.10},
+		{Date: date, Kind: ActionBonus, Value: 0.5},
+	}, 10.00), 1e-9)
+	// 10 配 3，配股价 5 元: (10.00 + 0.3 * 5.00) / 1.3
+	_assert.InDelta(11.5/1.3/10.00, ExRightFactor([]*CorporateAction{{Date: date, Kind: ActionRights, Value: 0.3, Price: 5.00}}, 10.00), 1e-9)
+}
@@ -0,0 +1,94 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eviltomorrow/robber-core/pkg/mysql"
+	jsoniter "github.com/json-iterator/go"
+)
+
+func HolidayWithInsertOrUpdateMany(exec mysql.Exec, holidays []*Holiday, timeout time.Duration) (int64, error) {
+	if len(holidays) == 0 {
+		return 0, nil
+	}
+
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var fields = make([]string, 0, len(holidays))
+	var args = make([]interface{}, 0, 2*len(holidays))
+	for _, holiday := range holidays {
+		fields = append(fields, "(?, ?, now(), null)")
+		args = append(args, holiday.Date.Format("2006-01-02"))
+		args = append(args, holiday.Name)
+	}
+
+	var _sql = fmt.Sprintf("insert into trading_holiday (%s) values %s on duplicate key update name = values(name), modify_timestamp = now()", strings.Join(holidayFields, ","), strings.Join(fields, ","))
+	result, err := exec.ExecContext(ctx, _sql, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// HolidayWithSelectMany 查询 [begin, end] 之间的节假日，按 date 升序
+func HolidayWithSelectMany(exec mysql.Exec, begin, end string, timeout time.Duration) ([]*Holiday, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var _sql = `select date, name, create_timestamp, modify_timestamp from trading_holiday where date between ? and ? order by date asc`
+	rows, err := exec.QueryContext(ctx, _sql, begin, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var holidays = make([]*Holiday, 0, 16)
+	for rows.Next() {
+		var holiday = &Holiday{}
+		if err := rows.Scan(
+			&holiday.Date,
+			&holiday.Name,
+			&holiday.CreateTimestamp,
+			&holiday.ModifyTimestamp,
+		); err != nil {
+			return nil, err
+		}
+		holidays = append(holidays, holiday)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return holidays, nil
+}
+
+const (
+	FieldHolidayDate            = "date"
+	FieldHolidayName            = "name"
+	FieldHolidayCreateTimestamp = "create_timestamp"
+	FieldHolidayModifyTimestamp = "modify_timestamp"
+)
+
+var holidayFields = []string{
+	FieldHolidayDate,
+	FieldHolidayName,
+	FieldHolidayCreateTimestamp,
+	FieldHolidayModifyTimestamp,
+}
+
+// Holiday 工作日休市的节假日，周六、周日默认休市无需记录
+type Holiday struct {
+	Date            time.Time    `json:"date"`
+	Name            string       `json:"name"`
+	CreateTimestamp time.Time    `json:"create_timestamp"`
+	ModifyTimestamp sql.NullTime `json:"modify_timestamp"`
+}
+
+func (h *Holiday) String() string {
+	buf, _ := jsoniter.ConfigCompatibleWithStandardLibrary.Marshal(h)
+	return string(buf)
+}
@@ -0,0 +1,143 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eviltomorrow/robber-core/pkg/mysql"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// MetadataWithInsertMany 追加写入原始数据，同一 code、date、time 已存在时忽略，不覆盖已有数据，返回实际写入的数量
+func MetadataWithInsertMany(exec mysql.Exec, data []*Metadata, timeout time.Duration) (int64, error) {
+	if len(data) == 0 {
+		return 0, nil
+	}
+
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var fields = make([]string, 0, len(data))
+	var args = make([]interface{}, 0, 12*len(data))
+	for _, d := range data {
+		fields = append(fields, "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, now())")
+		args = append(args, d.Code)
+		args = append(args, d.Name)
+		args = append(args, d.Open)
+		args = append(args, d.YesterdayClosed)
+		args = append(args, d.Latest)
+		args = append(args, d.High)
+		args = append(args, d.Low)
+		args = append(args, d.Volume)
+		args = append(args, d.Account)
+		args = append(args, d.Date.Format("2006-01-02"))
+		args = append(args, d.Time)
+		args = append(args, d.Suspend)
+	}
+
+	var _sql = fmt.Sprintf("insert ignore into metadata (%s) values %s", strings.Join(metadataFields, ","), strings.Join(fields, ","))
+	result, err := exec.ExecContext(ctx, _sql, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// MetadataWithSelectBetweenByCodeAndDate 查询 code 在 [begin, end] 之间的原始数据，按 date、写入顺序升序
+func MetadataWithSelectBetweenByCodeAndDate(exec mysql.Exec, code string, begin, end string, timeout time.Duration) ([]*Metadata, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var _sql = `select id, code, name, open, yesterday_closed, latest, high, low, volume, account, date, time, suspend, create_timestamp from metadata where code = ? and date between ? and ? order by date asc, id asc`
+	rows, err := exec.QueryContext(ctx, _sql, code, begin, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var data = make([]*Metadata, 0, 8)
+	for rows.Next() {
+		var d = &Metadata{}
+		if err := rows.Scan(
+			&d.Id,
+			&d.Code,
+			&d.Name,
+			&d.Open,
+			&d.YesterdayClosed,
+			&d.Latest,
+			&d.High,
+			&d.Low,
+			&d.Volume,
+			&d.Account,
+			&d.Date,
+			&d.Time,
+			&d.Suspend,
+			&d.CreateTimestamp,
+		); err != nil {
+			return nil, err
+		}
+		data = append(data, d)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+const (
+	FieldMetadataID              = "id"
+	FieldMetadataCode            = "code"
+	FieldMetadataName            = "name"
+	FieldMetadataOpen            = "open"
+	FieldMetadataYesterdayClosed = "yesterday_closed"
+	FieldMetadataLatest          = "latest"
+	FieldMetadataHigh            = "high"
+	FieldMetadataLow             = "low"
+	FieldMetadataVolume          = "volume"
+	FieldMetadataAccount         = "account"
+	FieldMetadataDate            = "date"
+	FieldMetadataTime            = "time"
+	FieldMetadataSuspend         = "suspend"
+	FieldMetadataCreateTimestamp = "create_timestamp"
+)
+
+var metadataFields = []string{
+	FieldMetadataCode,
+	FieldMetadataName,
+	FieldMetadataOpen,
+	FieldMetadataYesterdayClosed,
+	FieldMetadataLatest,
+	FieldMetadataHigh,
+	FieldMetadataLow,
+	FieldMetadataVolume,
+	FieldMetadataAccount,
+	FieldMetadataDate,
+	FieldMetadataTime,
+	FieldMetadataSuspend,
+	FieldMetadataCreateTimestamp,
+}
+
+// Metadata 采集的原始数据，只追加不修改，可据此重建 quote
+type Metadata struct {
+	Id              int64     `json:"id"`
+	Code            string    `json:"code"`
+	Name            string    `json:"name"`
+	Open            float64   `json:"open"`
+	YesterdayClosed float64   `json:"yesterday_closed"`
+	Latest          float64   `json:"latest"`
+	High            float64   `json:"high"`
+	Low             float64   `json:"low"`
+	Volume          uint64    `json:"volume"`
+	Account         float64   `json:"account"`
+	Date            time.Time `json:"date"`
+	Time            string    `json:"time"`
+	Suspend         string    `json:"suspend"`
+	CreateTimestamp time.Time `json:"create_timestamp"`
+}
+
+func (m *Metadata) String() string {
+	buf, _ := jsoniter.ConfigCompatibleWithStandardLibrary.Marshal(m)
+	return string(buf)
+}
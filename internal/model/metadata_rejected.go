@@ -0,0 +1,166 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eviltomorrow/robber-core/pkg/mysql"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// MetadataRejectedWithInsertOrUpdateMany 写入未能处理的 metadata，同一 code、date 仅保留最近一次的数据及原因
+func MetadataRejectedWithInsertOrUpdateMany(exec mysql.Exec, records []*MetadataRejected, timeout time.Duration) (int64, error) {
+	if len(records) == 0 {
+		return 0, nil
+	}
+
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var fields = make([]string, 0, len(records))
+	var args = make([]interface{}, 0, 4*len(records))
+	for _, record := range records {
+		fields = append(fields, "(?, ?, ?, ?, now(), null)")
+		args = append(args, record.Code)
+		args = append(args, record.Date)
+		args = append(args, record.Reason)
+		args = append(args, record.Metadata)
+	}
+
+	var _sql = fmt.Sprintf("insert into metadata_rejected (%s) values %s on duplicate key update reason = values(reason), metadata = values(metadata), modify_timestamp = now()", strings.Join(metadataRejectedFields, ","), strings.Join(fields, ","))
+	result, err := exec.ExecContext(ctx, _sql, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// MetadataRejectedWithSelectRange 按 id 升序分页查询，code 为空时查询全部
+func MetadataRejectedWithSelectRange(exec mysql.Exec, code string, offset, limit int64, timeout time.Duration) ([]*MetadataRejected, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var (
+		_sql = `select id, code, date, reason, metadata, create_timestamp, modify_timestamp from metadata_rejected`
+		args = make([]interface{}, 0, 3)
+	)
+	if code != "" {
+		_sql += ` where code = ?`
+		args = append(args, code)
+	}
+	_sql += ` order by id asc limit ?, ?`
+	args = append(args, offset, limit)
+
+	rows, err := exec.QueryContext(ctx, _sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records = make([]*MetadataRejected, 0, limit)
+	for rows.Next() {
+		var record = &MetadataRejected{}
+		if err := rows.Scan(
+			&record.Id,
+			&record.Code,
+			&record.Date,
+			&record.Reason,
+			&record.Metadata,
+			&record.CreateTimestamp,
+			&record.ModifyTimestamp,
+		); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func MetadataRejectedWithSelectOne(exec mysql.Exec, id int64, timeout time.Duration) (*MetadataRejected, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var _sql = `select id, code, date, reason, metadata, create_timestamp, modify_timestamp from metadata_rejected where id = ?`
+	row := exec.QueryRowContext(ctx, _sql, id)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	var record = &MetadataRejected{}
+	if err := row.Scan(
+		&record.Id,
+		&record.Code,
+		&record.Date,
+		&record.Reason,
+		&record.Metadata,
+		&record.CreateTimestamp,
+		&record.ModifyTimestamp,
+	); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func MetadataRejectedWithDeleteMany(exec mysql.Exec, ids []int64, timeout time.Duration) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var fields = make([]string, 0, len(ids))
+	var args = make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		fields = append(fields, "?")
+		args = append(args, id)
+	}
+
+	var _sql = fmt.Sprintf("delete from metadata_rejected where id in (%s)", strings.Join(fields, ","))
+	result, err := exec.ExecContext(ctx, _sql, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const (
+	FieldMetadataRejectedID              = "id"
+	FieldMetadataRejectedCode            = "code"
+	FieldMetadataRejectedDate            = "date"
+	FieldMetadataRejectedReason          = "reason"
+	FieldMetadataRejectedMetadata        = "metadata"
+	FieldMetadataRejectedCreateTimestamp = "create_timestamp"
+	FieldMetadataRejectedModifyTimestamp = "modify_timestamp"
+)
+
+var metadataRejectedFields = []string{
+	FieldMetadataRejectedCode,
+	FieldMetadataRejectedDate,
+	FieldMetadataRejectedReason,
+	FieldMetadataRejectedMetadata,
+	FieldMetadataRejectedCreateTimestamp,
+	FieldMetadataRejectedModifyTimestamp,
+}
+
+// MetadataRejected 未能处理的 metadata，Metadata 为原始数据的 JSON，Date 为原始数据中的日期
+type MetadataRejected struct {
+	Id              int64        `json:"id"`
+	Code            string       `json:"code"`
+	Date            string       `json:"date"`
+	Reason          string       `json:"reason"`
+	Metadata        string       `json:"metadata"`
+	CreateTimestamp time.Time    `json:"create_timestamp"`
+	ModifyTimestamp sql.NullTime `json:"modify_timestamp"`
+}
+
+func (m *MetadataRejected) String() string {
+	buf, _ := jsoniter.ConfigCompatibleWithStandardLibrary.Marshal(m)
+	return string(buf)
+}
@@ -13,8 +13,17 @@ import (
 )
 
 const (
-	Day  = "day"
-	Week = "week"
+	Day     = "day"
+	Week    = "week"
+	Month   = "month"
+	Quarter = "quarter"
+	Year    = "year"
+)
+
+const (
+	AdjustNone     = "none"
+	AdjustForward  = "forward"
+	AdjustBackward = "backward"
 )
 
 func QuoteWithInsertMany(exec mysql.Exec, model string, data []*Quote, timeout time.Duration) (int64, error) {
@@ -26,9 +35,9 @@ func QuoteWithInsertMany(exec mysql.Exec, model string, data []*Quote, timeout t
 	defer cannel()
 
 	var FieldQuotes = make([]string, 0, len(data))
-	var args = make([]interface{}, 0, 11*len(data))
+	var args = make([]interface{}, 0, 12*len(data))
 	for _, m := range data {
-		FieldQuotes = append(FieldQuotes, "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, now())")
+		FieldQuotes = append(FieldQuotes, "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, now())")
 		args = append(args, m.Code)
 		args = append(args, m.Open)
 		args = append(args, m.Close)
@@ -40,6 +49,7 @@ func QuoteWithInsertMany(exec mysql.Exec, model string, data []*Quote, timeout t
 		args = append(args, m.Date)
 		args = append(args, m.NumOfYear)
 		args = append(args, m.Xd)
+		args = append(args, m.Factor)
 	}
 
 	var _sql = fmt.Sprintf("insert into quote_%s (%s) values %s", model, strings.Join(quoteFeilds, ","), strings.Join(FieldQuotes, ","))
@@ -50,6 +60,73 @@ func QuoteWithInsertMany(exec mysql.Exec, model string, data []*Quote, timeout t
 	return result.RowsAffected()
 }
 
+// QuoteWithInsertOrUpdateMany 按 code、date 新增或覆盖更新 data，返回新增及更新的数量
+// 需在事务内执行以保证数量准确，data 中不能有重复的 code、date
+func QuoteWithInsertOrUpdateMany(exec mysql.Exec, model string, data []*Quote, timeout time.Duration) (int64, int64, error) {
+	if len(data) == 0 {
+		return 0, 0, nil
+	}
+
+	exist, err := QuoteWithCountExist(exec, model, data, timeout)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var FieldQuotes = make([]string, 0, len(data))
+	var args = make([]interface{}, 0, 12*len(data))
+	for _, m := range data {
+		FieldQuotes = append(FieldQuotes, "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, now())")
+		args = append(args, m.Code)
+		args = append(args, m.Open)
+		args = append(args, m.Close)
+		args = append(args, m.High)
+		args = append(args, m.Low)
+		args = append(args, m.YesterdayClosed)
+		args = append(args, m.Volume)
+		args = append(args, m.Account)
+		args = append(args, m.Date)
+		args = append(args, m.NumOfYear)
+		args = append(args, m.Xd)
+		args = append(args, m.Factor)
+	}
+
+	var _sql = fmt.Sprintf("insert into quote_%s (%s) values %s on duplicate key update open = values(open), close = values(close), high = values(high), low = values(low), yesterday_closed = values(yesterday_closed), volume = values(volume), account = values(account), num_of_year = values(num_of_year), xd = values(xd), factor = values(factor), modify_timestamp = now()", model, strings.Join(quoteFeilds, ","), strings.Join(FieldQuotes, ","))
+	if _, err := exec.ExecContext(ctx, _sql, args...); err != nil {
+		return 0, 0, err
+	}
+	return int64(len(data)) - exist, exist, nil
+}
+
+// QuoteWithCountExist 查询 data 中已存在的 code、date 数量
+func QuoteWithCountExist(exec mysql.Exec, model string, data []*Quote, timeout time.Duration) (int64, error) {
+	if len(data) == 0 {
+		return 0, nil
+	}
+
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var FieldQuotes = make([]string, 0, len(data))
+	var args = make([]interface{}, 0, 2*len(data))
+	for _, m := range data {
+		FieldQuotes = append(FieldQuotes, "(?, ?)")
+		args = append(args, m.Code)
+		args = append(args, m.Date.Format("2006-01-02"))
+	}
+
+	var (
+		_sql  = fmt.Sprintf("select count(*) from quote_%s where (code, date) in (%s)", model, strings.Join(FieldQuotes, ","))
+		count int64
+	)
+	if err := exec.QueryRowContext(ctx, _sql, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 func QuoteWithDeleteManyByCodesAndDate(exec mysql.Exec, model string, codes []string, date string, timeout time.Duration) (int64, error) {
 	if len(codes) == 0 {
 		return 0, nil
@@ -74,11 +151,35 @@ func QuoteWithDeleteManyByCodesAndDate(exec mysql.Exec, model string, codes []st
 	return result.RowsAffected()
 }
 
+// QuoteWithDeleteManyByIds 删除 ids 对应的数据
+func QuoteWithDeleteManyByIds(exec mysql.Exec, model string, ids []int64, timeout time.Duration) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var FieldQuotes = make([]string, 0, len(ids))
+	var args = make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		FieldQuotes = append(FieldQuotes, "?")
+		args = append(args, id)
+	}
+
+	var _sql = fmt.Sprintf("delete from quote_%s where id in (%s)", model, strings.Join(FieldQuotes, ","))
+	result, err := exec.ExecContext(ctx, _sql, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 func QuoteWithSelectBetweenByCodeAndDate(exec mysql.Exec, model string, code string, begin, end string, timeout time.Duration) ([]*Quote, error) {
 	ctx, cannel := context.WithTimeout(context.Background(), timeout)
 	defer cannel()
 
-	var _sql = fmt.Sprintf("select id, code, open, close, high, low, yesterday_closed, volume, account, date, num_of_year, xd, create_timestamp, modify_timestamp from quote_%s where code = ? and date between ? and ? order by date asc", model)
+	var _sql = fmt.Sprintf("select id, code, open, close, high, low, yesterday_closed, volume, account, date, num_of_year, xd, factor, create_timestamp, modify_timestamp from quote_%s where code = ? and date between ? and ? order by date asc", model)
 	rows, err := exec.QueryContext(ctx, _sql, code, begin, end)
 	if err != nil {
 		return nil, err
@@ -101,6 +202,7 @@ func QuoteWithSelectBetweenByCodeAndDate(exec mysql.Exec, model string, code str
 			&m.Date,
 			&m.NumOfYear,
 			&m.Xd,
+			&m.Factor,
 			&m.CreateTimestamp,
 			&m.ModifyTimestamp,
 		); err != nil {
@@ -109,45 +211,14 @@ func QuoteWithSelectBetweenByCodeAndDate(exec mysql.Exec, model string, code str
 		data = append(data, &m)
 	}
 
-	var result = make([]*Quote, len(data))
-	var xd float64 = 1.0
-	for i := len(data) - 1; i >= 0; i-- {
-		var d = data[i]
-		if xd != 1.0 {
-			var n = &Quote{
-				Id:              d.Id,
-				Code:            d.Code,
-				Open:            zmath.Trunc2(d.Open * xd),
-				Close:           zmath.Trunc2(d.Close * xd),
-				High:            zmath.Trunc2(d.High * xd),
-				Low:             zmath.Trunc2(d.Low * xd),
-				YesterdayClosed: zmath.Trunc2(d.YesterdayClosed * xd),
-				Volume:          d.Volume,
-				Account:         d.Account,
-				Date:            d.Date,
-				NumOfYear:       d.NumOfYear,
-				Xd:              d.Xd,
-				CreateTimestamp: d.CreateTimestamp,
-				ModifyTimestamp: d.ModifyTimestamp,
-			}
-			result[i] = n
-		} else {
-			result[i] = d
-		}
-
-		if d.Xd != 1.0 {
-			xd = d.Xd
-		}
-	}
-
-	return result, nil
+	return data, nil
 }
 
 func QuoteWithSelectManyLatest(exec mysql.Exec, model string, code string, date string, limit int64, timeout time.Duration) ([]*Quote, error) {
 	ctx, cannel := context.WithTimeout(context.Background(), timeout)
 	defer cannel()
 
-	var _sql = fmt.Sprintf("select id, code, open, close, high, low, yesterday_closed, volume, account, date, num_of_year, xd, create_timestamp, modify_timestamp from quote_%s where code = ? and date <= ? order by date desc limit ?", model)
+	var _sql = fmt.Sprintf("select id, code, open, close, high, low, yesterday_closed, volume, account, date, num_of_year, xd, factor, create_timestamp, modify_timestamp from quote_%s where code = ? and date <= ? order by date desc limit ?", model)
 	rows, err := exec.QueryContext(ctx, _sql, code, date, limit)
 	if err != nil {
 		return nil, err
@@ -170,6 +241,7 @@ func QuoteWithSelectManyLatest(exec mysql.Exec, model string, code string, date
 			&m.Date,
 			&m.NumOfYear,
 			&m.Xd,
+			&m.Factor,
 			&m.CreateTimestamp,
 			&m.ModifyTimestamp,
 		); err != nil {
@@ -178,44 +250,137 @@ func QuoteWithSelectManyLatest(exec mysql.Exec, model string, code string, date
 		data = append(data, &m)
 	}
 
-	var result = make([]*Quote, 0, len(data))
-	var xd = 1.0
-	for _, d := range data {
-		if xd != 1.0 {
-			var n = &Quote{
-				Id:              d.Id,
-				Code:            d.Code,
-				Open:            zmath.Trunc2(d.Open * xd),
-				Close:           zmath.Trunc2(d.Close * xd),
-				High:            zmath.Trunc2(d.High * xd),
-				Low:             zmath.Trunc2(d.Low * xd),
-				YesterdayClosed: zmath.Trunc2(d.YesterdayClosed * xd),
-				Volume:          d.Volume,
-				Account:         d.Account,
-				Date:            d.Date,
-				NumOfYear:       d.NumOfYear,
-				Xd:              d.Xd,
-				CreateTimestamp: d.CreateTimestamp,
-				ModifyTimestamp: d.ModifyTimestamp,
-			}
-			result = append(result, n)
-		} else {
-			result = append(result, d)
+	return data, nil
+}
+
+// QuoteWithSelectManyLatestByCodes 批量查询 codes 截止 date 的最近 limit 条数据，按 code 分组，组内按 date 降序
+// 使用窗口函数 row_number()，须为 MySQL 8.0 及以上版本
+func QuoteWithSelectManyLatestByCodes(exec mysql.Exec, model string, codes []string, date string, limit int64, timeout time.Duration) (map[string][]*Quote, error) {
+	if len(codes) == 0 {
+		return map[string][]*Quote{}, nil
+	}
+
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var FieldQuotes = make([]string, 0, len(codes))
+	var args = make([]interface{}, 0, len(codes)+2)
+	for _, code := range codes {
+		FieldQuotes = append(FieldQuotes, "?")
+		args = append(args, code)
+	}
+	args = append(args, date, limit)
+
+	var _sql = fmt.Sprintf("select id, code, open, close, high, low, yesterday_closed, volume, account, date, num_of_year, xd, factor, create_timestamp, modify_timestamp from (select id, code, open, close, high, low, yesterday_closed, volume, account, date, num_of_year, xd, factor, create_timestamp, modify_timestamp, row_number() over (partition by code order by date desc) as rn from quote_%s where code in (%s) and date <= ?) t where rn <= ? order by code asc, date desc", model, strings.Join(FieldQuotes, ","))
+	rows, err := exec.QueryContext(ctx, _sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var data = make(map[string][]*Quote, len(codes))
+	for rows.Next() {
+		var m = Quote{}
+		if err := rows.Scan(
+			&m.Id,
+			&m.Code,
+			&m.Open,
+			&m.Close,
+			&m.High,
+			&m.Low,
+			&m.YesterdayClosed,
+			&m.Volume,
+			&m.Account,
+			&m.Date,
+			&m.NumOfYear,
+			&m.Xd,
+			&m.Factor,
+			&m.CreateTimestamp,
+			&m.ModifyTimestamp,
+		); err != nil {
+			return nil, err
 		}
+		data[m.Code] = append(data[m.Code], &m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// QuoteWithSelectManyFactorByCodes 查询 codes 最新一条数据的累计复权因子
+func QuoteWithSelectManyFactorByCodes(exec mysql.Exec, model string, codes []string, timeout time.Duration) (map[string]float64, error) {
+	if len(codes) == 0 {
+		return map[string]float64{}, nil
+	}
 
-		if d.Xd != 1.0 {
-			xd = d.Xd
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var FieldQuotes = make([]string, 0, len(codes))
+	var args = make([]interface{}, 0, len(codes))
+	for _, code := range codes {
+		FieldQuotes = append(FieldQuotes, "?")
+		args = append(args, code)
+	}
+
+	var _sql = fmt.Sprintf("select code, factor from quote_%s q where code in (%s) and date = (select max(date) from quote_%s where code = q.code)", model, strings.Join(FieldQuotes, ","), model)
+	rows, err := exec.QueryContext(ctx, _sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var data = make(map[string]float64, len(codes))
+	for rows.Next() {
+		var (
+			code   string
+			factor float64
+		)
+		if err := rows.Scan(&code, &factor); err != nil {
+			return nil, err
 		}
+		data[code] = factor
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// QuoteWithUpdateFactorAfterDate 将 code 在 date 之后全部数据的累计复权因子乘以 ratio
+func QuoteWithUpdateFactorAfterDate(exec mysql.Exec, model string, code string, date string, ratio float64, timeout time.Duration) (int64, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
 
-	return result, nil
+	var _sql = fmt.Sprintf("update quote_%s set factor = factor * ? where code = ? and date > ?", model)
+	result, err := exec.ExecContext(ctx, _sql, ratio, code, date)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// QuoteWithCountByDate 统计 date 当日的 K 线数量
+func QuoteWithCountByDate(exec mysql.Exec, model string, date string, timeout time.Duration) (int64, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var (
+		_sql  = fmt.Sprintf("select count(1) from quote_%s where date = ?", model)
+		count int64
+	)
+	if err := exec.QueryRowContext(ctx, _sql, date).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
 }
 
 func QuoteWithSelectRangeByDate(exec mysql.Exec, model string, date string, offset, limit int64, timeout time.Duration) ([]*Quote, error) {
 	ctx, cannel := context.WithTimeout(context.Background(), timeout)
 	defer cannel()
 
-	var _sql = fmt.Sprintf("select id, code, open, close, high, low, yesterday_closed, volume, account, date, num_of_year, xd, create_timestamp, modify_timestamp from quote_%s where date = ? limit ?, ?", model)
+	var _sql = fmt.Sprintf("select id, code, open, close, high, low, yesterday_closed, volume, account, date, num_of_year, xd, factor, create_timestamp, modify_timestamp from quote_%s where date = ? order by code limit ?, ?", model)
 	rows, err := exec.QueryContext(ctx, _sql, date, offset, limit)
 	if err != nil {
 		return nil, err
@@ -238,6 +403,7 @@ func QuoteWithSelectRangeByDate(exec mysql.Exec, model string, date string, offs
 			&m.Date,
 			&m.NumOfYear,
 			&m.Xd,
+			&m.Factor,
 			&m.CreateTimestamp,
 			&m.ModifyTimestamp,
 		); err != nil {
@@ -253,7 +419,7 @@ func QuoteWithSelectOneByCodeAndDate(exec mysql.Exec, model string, code string,
 	ctx, cannel := context.WithTimeout(context.Background(), timeout)
 	defer cannel()
 
-	var _sql = fmt.Sprintf("select id, code, open, close, high, low, yesterday_closed, volume, account, date, num_of_year, xd, create_timestamp, modify_timestamp from quote_%s where code = ? and date = ?", model)
+	var _sql = fmt.Sprintf("select id, code, open, close, high, low, yesterday_closed, volume, account, date, num_of_year, xd, factor, create_timestamp, modify_timestamp from quote_%s where code = ? and date = ?", model)
 	row := exec.QueryRowContext(ctx, _sql, code, date)
 	if row.Err() != nil {
 		return nil, row.Err()
@@ -272,6 +438,7 @@ func QuoteWithSelectOneByCodeAndDate(exec mysql.Exec, model string, code string,
 		&m.Date,
 		&m.NumOfYear,
 		&m.Xd,
+		&m.Factor,
 		&m.CreateTimestamp,
 		&m.ModifyTimestamp,
 	); err != nil {
@@ -280,6 +447,52 @@ func QuoteWithSelectOneByCodeAndDate(exec mysql.Exec, model string, code string,
 	return &m, nil
 }
 
+// AdjustQuotes 按 adjust 对 data 复权，latest 为同一 code 最新一条数据的累计复权因子
+// 前复权以最新价格为基准: 价格 * latest / factor；后复权以首日价格为基准: 价格 / factor
+func AdjustQuotes(data []*Quote, latest float64, adjust string) []*Quote {
+	if adjust == AdjustNone {
+		return data
+	}
+
+	var result = make([]*Quote, 0, len(data))
+	for _, d := range data {
+		var ratio = 1.0
+		if d.Factor > 0 {
+			switch adjust {
+			case AdjustForward:
+				if latest > 0 {
+					ratio = latest / d.Factor
+				}
+			case AdjustBackward:
+				ratio = 1 / d.Factor
+			}
+		}
+
+		if ratio == 1.0 {
+			result = append(result, d)
+			continue
+		}
+		result = append(result, &Quote{
+			Id:              d.Id,
+			Code:            d.Code,
+			Open:            zmath.Trunc2(d.Open * ratio),
+			Close:           zmath.Trunc2(d.Close * ratio),
+			High:            zmath.Trunc2(d.High * ratio),
+			Low:             zmath.Trunc2(d.Low * ratio),
+			YesterdayClosed: zmath.Trunc2(d.YesterdayClosed * ratio),
+			Volume:          d.Volume,
+			Account:         d.Account,
+			Date:            d.Date,
+			NumOfYear:       d.NumOfYear,
+			Xd:              d.Xd,
+			Factor:          d.Factor,
+			CreateTimestamp: d.CreateTimestamp,
+			ModifyTimestamp: d.ModifyTimestamp,
+		})
+	}
+	return result
+}
+
 const (
 	FieldQuoteID              = "id"
 	FieldQuoteCode            = "code"
@@ -293,6 +506,7 @@ const (
 	FieldQuoteDate            = "date"
 	FieldQuoteNumOfYear       = "num_of_year"
 	FieldQuoteXd              = "xd"
+	FieldQuoteFactor          = "factor"
 	FieldQuoteCreateTimestamp = "create_timestamp"
 	FieldQuoteModifyTimestamp = "modify_timestamp"
 )
@@ -309,6 +523,7 @@ var quoteFeilds = []string{
 	FieldQuoteDate,
 	FieldQuoteNumOfYear,
 	FieldQuoteXd,
+	FieldQuoteFactor,
 	FieldQuoteCreateTimestamp,
 }
 
@@ -325,6 +540,7 @@ type Quote struct {
 	Date            time.Time    `json:"date"`
 	NumOfYear       int          `json:"num_of_year"`
 	Xd              float64      `json:"xd"`
+	Factor          float64      `json:"factor"`
 	CreateTimestamp time.Time    `json:"create_timestamp"`
 	ModifyTimestamp sql.NullTime `json:"modify_timestamp"`
 }
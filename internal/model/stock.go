@@ -11,9 +11,11 @@ import (
 	jsoniter "github.com/json-iterator/go"
 )
 
-func StockWithInsertOrUpdateMany(exec mysql.Exec, stocks []*Stock, timeout time.Duration) (int64, error) {
+// StockWithInsertOrUpdateMany 新增不存在的 stock，名称或停牌状态变化时更新已有 stock，返回新增及更新的数量
+// 需在事务内执行以保证数量准确，stocks 中不能有重复的 code
+func StockWithInsertOrUpdateMany(exec mysql.Exec, stocks []*Stock, timeout time.Duration) (int64, int64, error) {
 	if len(stocks) == 0 {
-		return 0, nil
+		return 0, 0, nil
 	}
 
 	var codes = make([]string, 0, len(stocks))
@@ -23,38 +25,46 @@ func StockWithInsertOrUpdateMany(exec mysql.Exec, stocks []*Stock, timeout time.
 
 	data, err := StockWithSelectMany(exec, codes, timeout)
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
 
-	var shouldInsertStocks = make([]*Stock, 0, len(stocks))
-	var shouldUpdateStocks = make([]*Stock, 0, len(stocks))
+	var (
+		changed           = make([]*Stock, 0, len(stocks))
+		inserted, updated int64
+	)
 	for _, stock := range stocks {
 		d, ok := data[stock.Code]
-		if !ok {
-			shouldInsertStocks = append(shouldInsertStocks, stock)
-		} else {
-			if d.Name != stock.Name {
-				shouldUpdateStocks = append(shouldUpdateStocks, stock)
-			}
+		switch {
+		case !ok:
+			inserted++
+		case d.Name != stock.Name || d.Suspend != stock.Suspend:
+			updated++
+		default:
+			continue
 		}
+		changed = append(changed, stock)
 	}
-
-	var count int64
-	for _, s := range shouldUpdateStocks {
-		affected, err := StockWithUpdateOne(exec, s.Code, s, timeout)
-		if err != nil {
-			return 0, err
-		}
-		count += affected
+	if len(changed) == 0 {
+		return 0, 0, nil
 	}
 
-	affected, err := StockWithInsertMany(exec, shouldInsertStocks, timeout)
-	if err != nil {
-		return 0, err
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var fields = make([]string, 0, len(changed))
+	var args = make([]interface{}, 0, 3*len(changed))
+	for _, record := range changed {
+		fields = append(fields, "(?, ?, ?, now(), null)")
+		args = append(args, record.Code)
+		args = append(args, record.Name)
+		args = append(args, record.Suspend)
 	}
-	count += affected
 
-	return count, nil
+	var _sql = fmt.Sprintf("insert into stock (%s) values %s on duplicate key update name = values(name), suspend = values(suspend), modify_timestamp = now()", strings.Join(stockFields, ","), strings.Join(fields, ","))
+	if _, err := exec.ExecContext(ctx, _sql, args...); err != nil {
+		return 0, 0, err
+	}
+	return inserted, updated, nil
 }
 
 func StockWithInsertMany(exec mysql.Exec, stocks []*Stock, timeout time.Duration) (int64, error) {
@@ -137,7 +147,7 @@ func StockWithSelectRange(exec mysql.Exec, offset, limit int64, timeout time.Dur
 	ctx, cannel := context.WithTimeout(context.Background(), timeout)
 	defer cannel()
 
-	var _sql = `select code, name, suspend, create_timestamp, modify_timestamp from stock limit ?, ?`
+	var _sql = `select code, name, suspend, create_timestamp, modify_timestamp from stock order by code limit ?, ?`
 	rows, err := exec.QueryContext(ctx, _sql, offset, limit)
 	if err != nil {
 		return nil, err
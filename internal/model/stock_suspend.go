@@ -0,0 +1,166 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eviltomorrow/robber-core/pkg/mysql"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// SuspendNormal 正常交易的停牌状态，其余状态均视为停牌
+const SuspendNormal = "正常"
+
+// IsSuspended 是否停牌
+func IsSuspended(suspend string) bool {
+	return suspend != "" && suspend != SuspendNormal
+}
+
+// StockSuspendWithInsertOrUpdateMany 按 code、date 新增或覆盖更新每日停牌状态
+func StockSuspendWithInsertOrUpdateMany(exec mysql.Exec, data []*StockSuspend, timeout time.Duration) (int64, error) {
+	if len(data) == 0 {
+		return 0, nil
+	}
+
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var fields = make([]string, 0, len(data))
+	var args = make([]interface{}, 0, 3*len(data))
+	for _, d := range data {
+		fields = append(fields, "(?, ?, ?, now(), null)")
+		args = append(args, d.Code)
+		args = append(args, d.Date.Format("2006-01-02"))
+		args = append(args, d.Suspend)
+	}
+
+	var _sql = fmt.Sprintf("insert into stock_suspend (%s) values %s on duplicate key update suspend = values(suspend), modify_timestamp = now()", strings.Join(stockSuspendFields, ","), strings.Join(fields, ","))
+	result, err := exec.ExecContext(ctx, _sql, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// StockSuspendWithSelectManyByDate 查询 date 当日停牌的 stock，按 code 升序
+func StockSuspendWithSelectManyByDate(exec mysql.Exec, date string, timeout time.Duration) ([]*StockSuspend, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var _sql = `select code, date, suspend, create_timestamp, modify_timestamp from stock_suspend where date = ? and suspend not in ('', ?) order by code asc`
+	rows, err := exec.QueryContext(ctx, _sql, date, SuspendNormal)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var data = make([]*StockSuspend, 0, 16)
+	for rows.Next() {
+		var d = &StockSuspend{}
+		if err := rows.Scan(
+			&d.Code,
+			&d.Date,
+			&d.Suspend,
+			&d.CreateTimestamp,
+			&d.ModifyTimestamp,
+		); err != nil {
+			return nil, err
+		}
+		data = append(data, d)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// StockSuspendWithSelectManyByCodesAndDate 查询 codes 在 date 当日的停牌状态，date 当日未写入的 code 不在结果中
+func StockSuspendWithSelectManyByCodesAndDate(exec mysql.Exec, codes []string, date string, timeout time.Duration) (map[string]*StockSuspend, error) {
+	if len(codes) == 0 {
+		return map[string]*StockSuspend{}, nil
+	}
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var fields = make([]string, 0, len(codes))
+	var args = make([]interface{}, 0, len(codes)+1)
+	args = append(args, date)
+	for _, code := range codes {
+		fields = append(fields, "?")
+		args = append(args, code)
+	}
+
+	var _sql = fmt.Sprintf(`select code, date, suspend, create_timestamp, modify_timestamp from stock_suspend where date = ? and code in (%s)`, strings.Join(fields, ","))
+	rows, err := exec.QueryContext(ctx, _sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var data = make(map[string]*StockSuspend, len(codes))
+	for rows.Next() {
+		var d = &StockSuspend{}
+		if err := rows.Scan(
+			&d.Code,
+			&d.Date,
+			&d.Suspend,
+			&d.CreateTimestamp,
+			&d.ModifyTimestamp,
+		); err != nil {
+			return nil, err
+		}
+		data[d.Code] = d
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// StockSuspendWithCountByDate 统计 date 当日写入的 stock 数量，含正常交易的 stock
+func StockSuspendWithCountByDate(exec mysql.Exec, date string, timeout time.Duration) (int64, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var (
+		_sql  = `select count(1) from stock_suspend where date = ?`
+		count int64
+	)
+	if err := exec.QueryRowContext(ctx, _sql, date).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+const (
+	FieldStockSuspendCode            = "code"
+	FieldStockSuspendDate            = "date"
+	FieldStockSuspendSuspend         = "suspend"
+	FieldStockSuspendCreateTimestamp = "create_timestamp"
+	FieldStockSuspendModifyTimestamp = "modify_timestamp"
+)
+
+var stockSuspendFields = []string{
+	FieldStockSuspendCode,
+	FieldStockSuspendDate,
+	FieldStockSuspendSuspend,
+	FieldStockSuspendCreateTimestamp,
+	FieldStockSuspendModifyTimestamp,
+}
+
+// StockSuspend stock 每日的停牌状态
+type StockSuspend struct {
+	Code            string       `json:"code"`
+	Date            time.Time    `json:"date"`
+	Suspend         string       `json:"suspend"`
+	CreateTimestamp time.Time    `json:"create_timestamp"`
+	ModifyTimestamp sql.NullTime `json:"modify_timestamp"`
+}
+
+func (s *StockSuspend) String() string {
+	buf, _ := jsoniter.ConfigCompatibleWithStandardLibrary.Marshal(s)
+	return string(buf)
+}
@@ -134,9 +134,10 @@ func TestStockWithInsertOrUpdateMany(t *testing.T) {
 	tx, err := mysql.DB.Begin()
 	_assert.Nil(err)
 
-	affected, err := StockWithInsertOrUpdateMany(tx, stocks, timeout)
+	inserted, updated, err := StockWithInsertOrUpdateMany(tx, stocks, timeout)
 	_assert.Nil(err)
-	_assert.Equal(int64(len(stocks)), affected)
+	_assert.Equal(int64(len(stocks)), inserted)
+	_assert.Equal(int64(0), updated)
 	err = tx.Commit()
 	_assert.Nil(err)
 
@@ -153,9 +154,9 @@ func TestStockWithInsertOrUpdateMany(t *testing.T) {
 		&stock1,
 		&stock2,
 	}
-	affected, err = StockWithInsertOrUpdateMany(mysql.DB, stocks, timeout)
+	inserted, updated, err = StockWithInsertOrUpdateMany(mysql.DB, stocks, timeout)
 	_assert.Nil(err)
-	_assert.Equal(int64(0), affected)
+	_assert.Equal(int64(0), inserted+updated)
 
 	// 3
 	stock4 := Stock{
@@ -168,9 +169,10 @@ func TestStockWithInsertOrUpdateMany(t *testing.T) {
 		&stock4,
 		&stock2,
 	}
-	affected, err = StockWithInsertOrUpdateMany(mysql.DB, stocks, timeout)
+	inserted, updated, err = StockWithInsertOrUpdateMany(mysql.DB, stocks, timeout)
 	_assert.Nil(err)
-	_assert.Equal(int64(1), affected)
+	_assert.Equal(int64(0), inserted)
+	_assert.Equal(int64(1), updated)
 }
 
 func equal(exepcted *Stock, actual *Stock) error {
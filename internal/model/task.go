@@ -3,6 +3,8 @@ package model
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/eviltomorrow/robber-core/pkg/mysql"
@@ -13,21 +15,114 @@ func TaskWithSelectOne(exec mysql.Exec, date string, timeout time.Duration) (*Ta
 	ctx, cannel := context.WithTimeout(context.Background(), timeout)
 	defer cannel()
 
-	var _sql = `select date, completed, metadata_count, stock_count, day_count, week_count, callback_url, create_timestamp, modify_timestamp from task where date = ?`
+	var _sql = fmt.Sprintf(`select %s from task where date = ?`, strings.Join(TaskFields, ", "))
 	row := exec.QueryRowContext(ctx, _sql, date)
 	if row.Err() != nil {
 		return nil, row.Err()
 	}
+	return scanTask(row)
+}
+
+// TaskWithSelectRange 按 date 降序分页查询 [from, to] 之间的 task，from、to、status 为空时不限制
+func TaskWithSelectRange(exec mysql.Exec, from, to string, status string, offset, limit int64, timeout time.Duration) ([]*Task, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var (
+		conditions = make([]string, 0, 3)
+		args       = make([]interface{}, 0, 5)
+	)
+	if from != "" {
+		conditions = append(conditions, "date >= ?")
+		args = append(args, from)
+	}
+	if to != "" {
+		conditions = append(conditions, "date <= ?")
+		args = append(args, to)
+	}
+	if status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, status)
+	}
+
+	var _sql = fmt.Sprintf(`select %s from task`, strings.Join(TaskFields, ", "))
+	if len(conditions) != 0 {
+		_sql += " where " + strings.Join(conditions, " and ")
+	}
+	_sql += ` order by date desc limit ?, ?`
+	args = append(args, offset, limit)
+
+	rows, err := exec.QueryContext(ctx, _sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks = make([]*Task, 0, limit)
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
 
+// TaskWithSelectDueCallback 查询已到投递时间的 callback_pending task，按下次投递时间升序，未设置投递时间的优先
+func TaskWithSelectDueCallback(exec mysql.Exec, now time.Time, limit int64, timeout time.Duration) ([]*Task, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var _sql = fmt.Sprintf(`select %s from task where status = ? and (next_callback_timestamp is null or next_callback_timestamp <= ?) order by next_callback_timestamp asc, date asc limit ?`, strings.Join(TaskFields, ", "))
+	rows, err := exec.QueryContext(ctx, _sql, TaskCallbackPending, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks = make([]*Task, 0, limit)
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func scanTask(scanner interface{ Scan(...interface{}) error }) (*Task, error) {
 	var task = &Task{}
-	if err := row.Scan(
+	if err := scanner.Scan(
 		&task.Date,
-		&task.Completed,
+		&task.Status,
+		&task.LastError,
 		&task.MetadataCount,
 		&task.StockCount,
 		&task.DayCount,
 		&task.WeekCount,
+		&task.ActualStockCount,
+		&task.ActualDayCount,
+		&task.ActualWeekCount,
+		&task.Inconsistent,
 		&task.CallbackURL,
+		&task.CallbackMode,
+		&task.CallbackSecret,
+		&task.CallbackAttempts,
+		&task.NextCallbackTimestamp,
+		&task.IngestingTimestamp,
+		&task.VerifyingTimestamp,
+		&task.CallbackPendingTimestamp,
+		&task.CompletedTimestamp,
+		&task.FailedTimestamp,
+		&task.FailedStatus,
 		&task.CreateTimestamp,
 		&task.ModifyTimestamp,
 	); err != nil {
@@ -44,23 +139,24 @@ func TaskWithInsertOne(exec mysql.Exec, task *Task, timeout time.Duration) (int6
 	ctx, cannel := context.WithTimeout(context.Background(), timeout)
 	defer cannel()
 
-	var _sql = `insert into task(date, completed, metadata_count, stock_count, day_count, week_count, callback_url, create_timestamp) values (?, ?, ?, ?, ?, ?, ?, now())`
-	result, err := exec.ExecContext(ctx, _sql, task.Date, task.Completed, task.MetadataCount, task.StockCount, task.DayCount, task.WeekCount, task.CallbackURL)
+	var _sql = `insert into task(date, status, last_error, metadata_count, stock_count, day_count, week_count, callback_url, callback_mode, callback_secret, create_timestamp) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, now())`
+	result, err := exec.ExecContext(ctx, _sql, task.Date, task.Status, task.LastError, task.MetadataCount, task.StockCount, task.DayCount, task.WeekCount, task.CallbackURL, task.CallbackMode, task.CallbackSecret)
 	if err != nil {
 		return 0, err
 	}
 	return result.RowsAffected()
 }
 
-func TaskWithUpdateOne(exec mysql.Exec, date string, task *Task, timeout time.Duration) (int64, error) {
+// TaskWithUpdateOne 仅当 task 当前状态为 status 时更新，状态已被其他请求修改时返回 0
+func TaskWithUpdateOne(exec mysql.Exec, date string, status string, task *Task, timeout time.Duration) (int64, error) {
 	if task == nil {
 		return 0, nil
 	}
 	ctx, cannel := context.WithTimeout(context.Background(), timeout)
 	defer cannel()
 
-	var _sql = `update task set completed = ?, metadata_count = ?, stock_count = ?, day_count = ?, week_count = ?, callback_url = ?, modify_timestamp = now() where date = ?`
-	result, err := exec.ExecContext(ctx, _sql, task.Completed, task.MetadataCount, task.StockCount, task.DayCount, task.WeekCount, task.CallbackURL, date)
+	var _sql = `update task set status = ?, last_error = ?, metadata_count = ?, stock_count = ?, day_count = ?, week_count = ?, actual_stock_count = ?, actual_day_count = ?, actual_week_count = ?, inconsistent = ?, callback_url = ?, callback_mode = ?, callback_secret = ?, callback_attempts = ?, next_callback_timestamp = ?, ingesting_timestamp = ?, verifying_timestamp = ?, callback_pending_timestamp = ?, completed_timestamp = ?, failed_timestamp = ?, failed_status = ?, modify_timestamp = now() where date = ? and status = ?`
+	result, err := exec.ExecContext(ctx, _sql, task.Status, task.LastError, task.MetadataCount, task.StockCount, task.DayCount, task.WeekCount, task.ActualStockCount, task.ActualDayCount, task.ActualWeekCount, task.Inconsistent, task.CallbackURL, task.CallbackMode, task.CallbackSecret, task.CallbackAttempts, task.NextCallbackTimestamp, task.IngestingTimestamp, task.VerifyingTimestamp, task.CallbackPendingTimestamp, task.CompletedTimestamp, task.FailedTimestamp, task.FailedStatus, date, status)
 	if err != nil {
 		return 0, err
 	}
@@ -68,40 +164,104 @@ func TaskWithUpdateOne(exec mysql.Exec, date string, task *Task, timeout time.Du
 }
 
 const (
-	FieldTaskDate            = "date"
-	FieldTaskCompleted       = "completed"
-	FieldTaskMetadataCount   = "metadata_count"
-	FieldTaskStockCount      = "stock_count"
-	FieldTaskDayCount        = "day_count"
-	FieldTaskWeekCount       = "week_count"
-	FieldTaskCallBackURL     = "callback_url"
-	FieldTaskCreateTimestamp = "create_timestamp"
-	FieldTaskModifyTimestamp = "modify_timestamp"
+	FieldTaskDate                     = "date"
+	FieldTaskStatus                   = "status"
+	FieldTaskLastError                = "last_error"
+	FieldTaskMetadataCount            = "metadata_count"
+	FieldTaskStockCount               = "stock_count"
+	FieldTaskDayCount                 = "day_count"
+	FieldTaskWeekCount                = "week_count"
+	FieldTaskActualStockCount         = "actual_stock_count"
+	FieldTaskActualDayCount           = "actual_day_count"
+	FieldTaskActualWeekCount          = "actual_week_count"
+	FieldTaskInconsistent             = "inconsistent"
+	FieldTaskCallBackURL              = "callback_url"
+	FieldTaskCallbackMode             = "callback_mode"
+	FieldTaskCallbackSecret           = "callback_secret"
+	FieldTaskCallbackAttempts         = "callback_attempts"
+	FieldTaskNextCallbackTimestamp    = "next_callback_timestamp"
+	FieldTaskIngestingTimestamp       = "ingesting_timestamp"
+	FieldTaskVerifyingTimestamp       = "verifying_timestamp"
+	FieldTaskCallbackPendingTimestamp = "callback_pending_timestamp"
+	FieldTaskCompletedTimestamp       = "completed_timestamp"
+	FieldTaskFailedTimestamp          = "failed_timestamp"
+	FieldTaskFailedStatus             = "failed_status"
+	FieldTaskCreateTimestamp          = "create_timestamp"
+	FieldTaskModifyTimestamp          = "modify_timestamp"
 )
 
 var TaskFields = []string{
 	FieldTaskDate,
-	FieldTaskCompleted,
+	FieldTaskStatus,
+	FieldTaskLastError,
 	FieldTaskMetadataCount,
 	FieldTaskStockCount,
 	FieldTaskDayCount,
 	FieldTaskWeekCount,
+	FieldTaskActualStockCount,
+	FieldTaskActualDayCount,
+	FieldTaskActualWeekCount,
+	FieldTaskInconsistent,
 	FieldTaskCallBackURL,
+	FieldTaskCallbackMode,
+	FieldTaskCallbackSecret,
+	FieldTaskCallbackAttempts,
+	FieldTaskNextCallbackTimestamp,
+	FieldTaskIngestingTimestamp,
+	FieldTaskVerifyingTimestamp,
+	FieldTaskCallbackPendingTimestamp,
+	FieldTaskCompletedTimestamp,
+	FieldTaskFailedTimestamp,
+	FieldTaskFailedStatus,
 	FieldTaskCreateTimestamp,
 	FieldTaskModifyTimestamp,
 }
 
-// Task
+// task 状态，流转规则见 service.TransitTask
+const (
+	TaskCreated         = "created"
+	TaskIngesting       = "ingesting"
+	TaskVerifying       = "verifying"
+	TaskCallbackPending = "callback_pending"
+	TaskCompleted       = "completed"
+	TaskFailed          = "failed"
+)
+
+// task 回调方式
+const (
+	// CallbackGet 无请求体的 GET 回调，兼容旧版
+	CallbackGet = "get"
+	// CallbackPost 以 JSON 推送 task 统计的 POST 回调，请求体使用 CallbackSecret 签名
+	CallbackPost = "post"
+)
+
+// Task 每日数据写入任务，created 状态的时间为 CreateTimestamp，其余状态的时间为最近一次进入该状态的时间
+// XxxCount 为 Complete 时上报的数量，ActualXxxCount 为服务端统计的数量，FailedStatus 为最近一次流转到 failed 前的状态
 type Task struct {
-	Date            string       `json:"date"`
-	Completed       int8         `json:"completed"`
-	MetadataCount   int64        `json:"metadata_count"`
-	StockCount      int64        `json:"stock_count"`
-	DayCount        int64        `json:"day_count"`
-	WeekCount       int64        `json:"week_count"`
-	CallbackURL     string       `json:"callback_url"`
-	CreateTimestamp time.Time    `json:"create_timestamp"`
-	ModifyTimestamp sql.NullTime `json:"modify_timestamp"`
+	Date                     string       `json:"date"`
+	Status                   string       `json:"status"`
+	LastError                string       `json:"last_error"`
+	MetadataCount            int64        `json:"metadata_count"`
+	StockCount               int64        `json:"stock_count"`
+	DayCount                 int64        `json:"day_count"`
+	WeekCount                int64        `json:"week_count"`
+	ActualStockCount         int64        `json:"actual_stock_count"`
+	ActualDayCount           int64        `json:"actual_day_count"`
+	ActualWeekCount          int64        `json:"actual_week_count"`
+	Inconsistent             bool         `json:"inconsistent"`
+	CallbackURL              string       `json:"callback_url"`
+	CallbackMode             string       `json:"callback_mode"`
+	CallbackSecret           string       `json:"-"`
+	CallbackAttempts         int64        `json:"callback_attempts"`
+	NextCallbackTimestamp    sql.NullTime `json:"next_callback_timestamp"`
+	IngestingTimestamp       sql.NullTime `json:"ingesting_timestamp"`
+	VerifyingTimestamp       sql.NullTime `json:"verifying_timestamp"`
+	CallbackPendingTimestamp sql.NullTime `json:"callback_pending_timestamp"`
+	CompletedTimestamp       sql.NullTime `json:"completed_timestamp"`
+	FailedTimestamp          sql.NullTime `json:"failed_timestamp"`
+	FailedStatus             string       `json:"failed_status"`
+	CreateTimestamp          time.Time    `json:"create_timestamp"`
+	ModifyTimestamp          sql.NullTime `json:"modify_timestamp"`
 }
 
 func (t *Task) String() string {
@@ -0,0 +1,106 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eviltomorrow/robber-core/pkg/mysql"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// TaskCallbackAttemptWithInsertOne 追加一条回调投递记录
+func TaskCallbackAttemptWithInsertOne(exec mysql.Exec, attempt *TaskCallbackAttempt, timeout time.Duration) (int64, error) {
+	if attempt == nil {
+		return 0, nil
+	}
+
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var _sql = `insert into task_callback_attempt (date, attempt, url, status_code, body, latency, error, create_timestamp) values (?, ?, ?, ?, ?, ?, ?, now())`
+	result, err := exec.ExecContext(ctx, _sql, attempt.Date, attempt.Attempt, attempt.URL, attempt.StatusCode, attempt.Body, attempt.Latency, attempt.Error)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// TaskCallbackAttemptWithSelectMany 查询 date 对应 task 的全部回调投递记录，按投递顺序升序
+func TaskCallbackAttemptWithSelectMany(exec mysql.Exec, date string, timeout time.Duration) ([]*TaskCallbackAttempt, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var _sql = fmt.Sprintf(`select %s from task_callback_attempt where date = ? order by id asc`, strings.Join(taskCallbackAttemptFields, ", "))
+	rows, err := exec.QueryContext(ctx, _sql, date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attempts = make([]*TaskCallbackAttempt, 0, 8)
+	for rows.Next() {
+		var attempt = &TaskCallbackAttempt{}
+		if err := rows.Scan(
+			&attempt.Id,
+			&attempt.Date,
+			&attempt.Attempt,
+			&attempt.URL,
+			&attempt.StatusCode,
+			&attempt.Body,
+			&attempt.Latency,
+			&attempt.Error,
+			&attempt.CreateTimestamp,
+		); err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, attempt)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
+
+const (
+	FieldTaskCallbackAttemptId              = "id"
+	FieldTaskCallbackAttemptDate            = "date"
+	FieldTaskCallbackAttemptAttempt         = "attempt"
+	FieldTaskCallbackAttemptURL             = "url"
+	FieldTaskCallbackAttemptStatusCode      = "status_code"
+	FieldTaskCallbackAttemptBody            = "body"
+	FieldTaskCallbackAttemptLatency         = "latency"
+	FieldTaskCallbackAttemptError           = "error"
+	FieldTaskCallbackAttemptCreateTimestamp = "create_timestamp"
+)
+
+var taskCallbackAttemptFields = []string{
+	FieldTaskCallbackAttemptId,
+	FieldTaskCallbackAttemptDate,
+	FieldTaskCallbackAttemptAttempt,
+	FieldTaskCallbackAttemptURL,
+	FieldTaskCallbackAttemptStatusCode,
+	FieldTaskCallbackAttemptBody,
+	FieldTaskCallbackAttemptLatency,
+	FieldTaskCallbackAttemptError,
+	FieldTaskCallbackAttemptCreateTimestamp,
+}
+
+// TaskCallbackAttempt task 的一次回调投递，StatusCode 为 0 表示请求未得到响应，Latency 单位为毫秒
+type TaskCallbackAttempt struct {
+	Id              int64     `json:"id"`
+	Date            string    `json:"date"`
+	Attempt         int64     `json:"attempt"`
+	URL             string    `json:"url"`
+	StatusCode      int64     `json:"status_code"`
+	Body            string    `json:"body"`
+	Latency         int64     `json:"latency"`
+	Error           string    `json:"error"`
+	CreateTimestamp time.Time `json:"create_timestamp"`
+}
+
+func (t *TaskCallbackAttempt) String() string {
+	buf, _ := jsoniter.ConfigCompatibleWithStandardLibrary.Marshal(t)
+	return string(buf)
+}
@@ -10,7 +10,7 @@ import (
 
 var t1 = &Task{
 	Date:          "2021-12-21",
-	Completed:     0,
+	Status:        TaskCreated,
 	MetadataCount: 4500,
 	StockCount:    15,
 	DayCount:      4500,
@@ -47,6 +47,7 @@ func TestTaskWithSelectOne(t *testing.T) {
 	task, err := TaskWithSelectOne(mysql.DB, t1.Date, timeout)
 	_assert.Nil(err)
 	_assert.Equal(t1.Date, task.Date)
+	_assert.Equal(t1.Status, task.Status)
 	_assert.Equal(t1.MetadataCount, task.MetadataCount)
 	_assert.Equal(t1.StockCount, task.StockCount)
 	_assert.Equal(t1.DayCount, task.DayCount)
@@ -68,9 +69,9 @@ func TestTaskWithUpdateOne(t *testing.T) {
 	// _assert.Nil(err)
 	// _assert.Equal(int64(1), affected)
 
-	t1.Completed = 1
+	t1.Status = TaskIngesting
 	t1.StockCount = 20
-	affected, err := TaskWithUpdateOne(tx, t1.Date, t1, timeout)
+	affected, err := TaskWithUpdateOne(tx, t1.Date, TaskCreated, t1, timeout)
 	_assert.Nil(err)
 	_assert.Equal(int64(1), affected)
 
@@ -82,6 +83,7 @@ func TestTaskWithUpdateOne(t *testing.T) {
 	task, err := TaskWithSelectOne(mysql.DB, t1.Date, timeout)
 	_assert.Nil(err)
 	_assert.Equal(t1.Date, task.Date)
+	_assert.Equal(t1.Status, task.Status)
 	_assert.Equal(t1.MetadataCount, task.MetadataCount)
 	_assert.Equal(t1.StockCount, task.StockCount)
 	_assert.Equal(t1.DayCount, task.DayCount)
@@ -0,0 +1,875 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/eviltomorrow/robber-repository/internal/model"
+)
+
+// Memory 基于内存的存储实现，用于测试
+type Memory struct {
+	mut sync.RWMutex
+
+	id      int64
+	stocks  map[string]*model.Stock
+	quotes  map[string]map[string][]*model.Quote
+	actions map[string]*model.CorporateAction
+	batches map[string]*model.Batch
+	tasks   map[string]*model.Task
+
+	attemptID int64
+	attempts  []*model.TaskCallbackAttempt
+
+	rejectedID int64
+	rejected   map[int64]*model.MetadataRejected
+
+	metadataID int64
+	metadata   []*model.Metadata
+
+	holidays map[string]*model.Holiday
+	suspends map[string]map[string]*model.StockSuspend
+}
+
+// NewMemory 创建内存存储
+func NewMemory() *Memory {
+	return &Memory{
+		stocks: map[string]*model.Stock{},
+		quotes: map[string]map[string][]*model.Quote{
+			model.Day:     {},
+			model.Week:    {},
+			model.Month:   {},
+			model.Quarter: {},
+			model.Year:    {},
+		},
+		actions: map[string]*model.CorporateAction{},
+		batches: map[string]*model.Batch{},
+		tasks:   map[string]*model.Task{},
+
+		rejected: map[int64]*model.MetadataRejected{},
+		holidays: map[string]*model.Holiday{},
+		suspends: map[string]map[string]*model.StockSuspend{},
+	}
+}
+
+func (m *Memory) StockWithInsertOrUpdateMany(stocks []*model.Stock, timeout time.Duration) (int64, int64, error) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	var inserted, updated int64
+	for _, stock := range uniqueStocks(stocks) {
+		d, ok := m.stocks[stock.Code]
+		if !ok {
+			var s = *stock
+			s.CreateTimestamp = time.Now()
+			s.ModifyTimestamp = sql.NullTime{}
+			m.stocks[stock.Code] = &s
+			inserted++
+			continue
+		}
+		if d.Name != stock.Name || d.Suspend != stock.Suspend {
+			d.Name = stock.Name
+			d.Suspend = stock.Suspend
+			d.ModifyTimestamp = sql.NullTime{Time: time.Now(), Valid: true}
+			updated++
+		}
+	}
+	return inserted, updated, nil
+}
+
+func (m *Memory) StockWithSelectMany(codes []string, timeout time.Duration) (map[string]*model.Stock, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	var stocks = make(map[string]*model.Stock, len(codes))
+	for _, code := range codes {
+		if stock, ok := m.stocks[code]; ok {
+			var s = *stock
+			stocks[code] = &s
+		}
+	}
+	return stocks, nil
+}
+
+func (m *Memory) StockWithSelectRange(offset, limit int64, timeout time.Duration) ([]*model.Stock, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	var codes = make([]string, 0, len(m.stocks))
+	for code := range m.stocks {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	var (
+		stocks     = make([]*model.Stock, 0, limit)
+		begin, end = bounds(len(codes), offset, limit)
+	)
+	for _, code := range codes[begin:end] {
+		var s = *m.stocks[code]
+		stocks = append(stocks, &s)
+	}
+	return stocks, nil
+}
+
+func (m *Memory) StockSuspendWithInsertOrUpdateMany(data []*model.StockSuspend, timeout time.Duration) (int64, error) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	for _, d := range data {
+		var (
+			c    = *d
+			date = d.Date.Format("2006-01-02")
+		)
+		c.Date = truncateDate(d.Date)
+		if _, ok := m.suspends[date]; !ok {
+			m.suspends[date] = map[string]*model.StockSuspend{}
+		}
+		if s, ok := m.suspends[date][d.Code]; ok {
+			c.CreateTimestamp = s.CreateTimestamp
+			c.ModifyTimestamp = sql.NullTime{Time: time.Now(), Valid: true}
+		} else {
+			c.CreateTimestamp = time.Now()
+			c.ModifyTimestamp = sql.NullTime{}
+		}
+		m.suspends[date][d.Code] = &c
+	}
+	return int64(len(data)), nil
+}
+
+func (m *Memory) StockSuspendWithSelectManyByDate(date string, timeout time.Duration) ([]*model.StockSuspend, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	var data = make([]*model.StockSuspend, 0, 16)
+	for _, d := range m.suspends[date] {
+		if model.IsSuspended(d.Suspend) {
+			var c = *d
+			data = append(data, &c)
+		}
+	}
+	sort.Slice(data, func(i, j int) bool {
+		return data[i].Code < data[j].Code
+	})
+	return data, nil
+}
+
+func (m *Memory) StockSuspendWithSelectManyByCodesAndDate(codes []string, date string, timeout time.Duration) (map[string]*model.StockSuspend, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	var data = make(map[string]*model.StockSuspend, len(codes))
+	for _, code := range codes {
+		if d, ok := m.suspends[date][code]; ok {
+			var c = *d
+			data[code] = &c
+		}
+	}
+	return data, nil
+}
+
+func (m *Memory) StockSuspendWithCountByDate(date string, timeout time.Duration) (int64, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	return int64(len(m.suspends[date])), nil
+}
+
+func (m *Memory) QuoteWithInsertOrUpdateMany(mode string, quotes []*model.Quote, timeout time.Duration) (int64, int64, error) {
+	return m.QuoteWithInsertOrUpdateManyAndFactor(mode, quotes, nil, "", nil, timeout)
+}
+
+func (m *Memory) QuoteWithInsertOrUpdateManyAndFactor(mode string, quotes []*model.Quote, ids []int64, date string, ratios map[string]float64, timeout time.Duration) (int64, int64, error) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	table, err := m.table(mode)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if len(ids) != 0 {
+		var removed = make(map[int64]struct{}, len(ids))
+		for _, id := range ids {
+			removed[id] = struct{}{}
+		}
+		for code, data := range table {
+			var kept = data[:0]
+			for _, q := range data {
+				if _, ok := removed[q.Id]; !ok {
+					kept = append(kept, q)
+				}
+			}
+			table[code] = kept
+		}
+	}
+
+	var inserted, updated int64
+	for _, quote := range uniqueQuotes(quotes) {
+		var q = *quote
+		q.Open = round2(q.Open)
+		q.Close = round2(q.Close)
+		q.High = round2(q.High)
+		q.Low = round2(q.Low)
+		q.YesterdayClosed = round2(q.YesterdayClosed)
+		q.Account = round2(q.Account)
+		q.Date = truncateDate(q.Date)
+
+		var (
+			data = table[q.Code]
+			i    = sort.Search(len(data), func(i int) bool { return !data[i].Date.Before(q.Date) })
+		)
+		if i < len(data) && data[i].Date.Equal(q.Date) {
+			q.Id = data[i].Id
+			q.CreateTimestamp = data[i].CreateTimestamp
+			q.ModifyTimestamp = sql.NullTime{Time: time.Now(), Valid: true}
+			data[i] = &q
+			updated++
+		} else {
+			m.id++
+			q.Id = m.id
+			q.CreateTimestamp = time.Now()
+			q.ModifyTimestamp = sql.NullTime{}
+			data = append(data, nil)
+			copy(data[i+1:], data[i:])
+			data[i] = &q
+			inserted++
+		}
+		table[q.Code] = data
+	}
+	for code, ratio := range ratios {
+		updateFactorAfterDate(table[code], date, ratio)
+	}
+	return inserted, updated, nil
+}
+
+func (m *Memory) QuoteWithSelectBetweenByCodeAndDate(mode string, code string, begin, end string, timeout time.Duration) ([]*model.Quote, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	table, err := m.table(mode)
+	if err != nil {
+		return nil, err
+	}
+
+	var data = make([]*model.Quote, 0, 8)
+	for _, q := range table[code] {
+		var date = q.Date.Format("2006-01-02")
+		if date >= begin && date <= end {
+			var n = *q
+			data = append(data, &n)
+		}
+	}
+	return data, nil
+}
+
+func (m *Memory) QuoteWithSelectManyLatest(mode string, code string, date string, limit int64, timeout time.Duration) ([]*model.Quote, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	table, err := m.table(mode)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		quotes = table[code]
+		data   = make([]*model.Quote, 0, limit)
+	)
+	for i := len(quotes) - 1; i >= 0 && int64(len(data)) < limit; i-- {
+		if quotes[i].Date.Format("2006-01-02") <= date {
+			var n = *quotes[i]
+			data = append(data, &n)
+		}
+	}
+	return data, nil
+}
+
+func (m *Memory) QuoteWithSelectManyLatestByCodes(mode string, codes []string, date string, limit int64, timeout time.Duration) (map[string][]*model.Quote, error) {
+	var data = make(map[string][]*model.Quote, len(codes))
+	for _, code := range codes {
+		quotes, err := m.QuoteWithSelectManyLatest(mode, code, date, limit, timeout)
+		if err != nil {
+			return nil, err
+		}
+		if len(quotes) != 0 {
+			data[code] = quotes
+		}
+	}
+	return data, nil
+}
+
+func (m *Memory) QuoteWithSelectManyFactorByCodes(mode string, codes []string, timeout time.Duration) (map[string]float64, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	table, err := m.table(mode)
+	if err != nil {
+		return nil, err
+	}
+
+	var data = make(map[string]float64, len(codes))
+	for _, code := range codes {
+		if quotes := table[code]; len(quotes) != 0 {
+			data[code] = quotes[len(quotes)-1].Factor
+		}
+	}
+	return data, nil
+}
+
+func (m *Memory) QuoteWithUpdateFactorAfterDate(mode string, code string, date string, ratio float64, timeout time.Duration) (int64, error) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	table, err := m.table(mode)
+	if err != nil {
+		return 0, err
+	}
+
+	return updateFactorAfterDate(table[code], date, ratio), nil
+}
+
+func updateFactorAfterDate(quotes []*model.Quote, date string, ratio float64) int64 {
+	var count int64
+	for _, q := range quotes {
+		if q.Date.Format("2006-01-02") > date {
+			q.Factor *= ratio
+			count++
+		}
+	}
+	return count
+}
+
+func (m *Memory) QuoteWithSelectRangeByDate(mode string, date string, offset, limit int64, timeout time.Duration) ([]*model.Quote, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	table, err := m.table(mode)
+	if err != nil {
+		return nil, err
+	}
+
+	var codes = make([]string, 0, len(table))
+	for code := range table {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	var data = make([]*model.Quote, 0, limit)
+	for _, code := range codes {
+		for _, q := range table[code] {
+			if q.Date.Format("2006-01-02") == date {
+				var n = *q
+				data = append(data, &n)
+			}
+		}
+	}
+	var begin, end = bounds(len(data), offset, limit)
+	return data[begin:end], nil
+}
+
+func (m *Memory) QuoteWithCountByDate(mode string, date string, timeout time.Duration) (int64, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	table, err := m.table(mode)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	for _, quotes := range table {
+		for _, q := range quotes {
+			if q.Date.Format("2006-01-02") == date {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
+
+func (m *Memory) QuoteWithSelectOneByCodeAndDate(mode string, code string, date string, timeout time.Duration) (*model.Quote, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	table, err := m.table(mode)
+	if err != nil {
+		return nil, err
+	}
+	for _, q := range table[code] {
+		if q.Date.Format("2006-01-02") == date {
+			var n = *q
+			return &n, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (m *Memory) CorporateActionWithInsertOrUpdateMany(actions []*model.CorporateAction, timeout time.Duration) (int64, error) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	for _, action := range actions {
+		var (
+			a   = *action
+			key = fmt.Sprintf("%s|%s|%s", action.Code, action.Date.Format("2006-01-02"), action.Kind)
+		)
+		a.Date = truncateDate(action.Date)
+		a.Price = round2(action.Price)
+		if d, ok := m.actions[key]; ok {
+			a.CreateTimestamp = d.CreateTimestamp
+			a.ModifyTimestamp = sql.NullTime{Time: time.Now(), Valid: true}
+		} else {
+			a.CreateTimestamp = time.Now()
+			a.ModifyTimestamp = sql.NullTime{}
+		}
+		m.actions[key] = &a
+	}
+	return int64(len(actions)), nil
+}
+
+func (m *Memory) CorporateActionWithSelectMany(code string, begin, end string, timeout time.Duration) ([]*model.CorporateAction, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	var actions = make([]*model.CorporateAction, 0, 4)
+	for _, action := range m.actions {
+		var date = action.Date.Format("2006-01-02")
+		if action.Code == code && date >= begin && date <= end {
+			var a = *action
+			actions = append(actions, &a)
+		}
+	}
+	sort.Slice(actions, func(i, j int) bool {
+		if !actions[i].Date.Equal(actions[j].Date) {
+			return actions[i].Date.Before(actions[j].Date)
+		}
+		return actions[i].Kind < actions[j].Kind
+	})
+	return actions, nil
+}
+
+func (m *Memory) CorporateActionWithSelectManyByCodes(codes []string, begin, end string, timeout time.Duration) (map[string][]*model.CorporateAction, error) {
+	var data = make(map[string][]*model.CorporateAction, len(codes))
+	for _, code := range codes {
+		actions, err := m.CorporateActionWithSelectMany(code, begin, end, timeout)
+		if err != nil {
+			return nil, err
+		}
+		if len(actions) != 0 {
+			data[code] = actions
+		}
+	}
+	return data, nil
+}
+
+func (m *Memory) BatchWithSelectOne(id string, timeout time.Duration) (*model.Batch, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	batch, ok := m.batches[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	var b = *batch
+	return &b, nil
+}
+
+func (m *Memory) BatchWithInsertOne(batch *model.Batch, timeout time.Duration) (int64, error) {
+	if batch == nil {
+		return 0, nil
+	}
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	if _, ok := m.batches[batch.Id]; ok {
+		return 0, nil
+	}
+	var b = *batch
+	b.CreateTimestamp = time.Now()
+	b.ModifyTimestamp = sql.NullTime{}
+	m.batches[batch.Id] = &b
+	return 1, nil
+}
+
+func (m *Memory) BatchWithUpdateOne(batch *model.Batch, timeout time.Duration) (int64, error) {
+	if batch == nil {
+		return 0, nil
+	}
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	stored, ok := m.batches[batch.Id]
+	if !ok || stored.Status != model.BatchPending {
+		return 0, nil
+	}
+	var b = *batch
+	b.CreateTimestamp = stored.CreateTimestamp
+	b.ModifyTimestamp = sql.NullTime{Time: time.Now(), Valid: true}
+	m.batches[batch.Id] = &b
+	return 1, nil
+}
+
+func (m *Memory) BatchWithDeleteOne(id string, before time.Time, timeout time.Duration) (int64, error) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	stored, ok := m.batches[id]
+	if !ok || stored.Status != model.BatchPending || (!before.IsZero() && !stored.CreateTimestamp.Before(before)) {
+		return 0, nil
+	}
+	delete(m.batches, id)
+	return 1, nil
+}
+
+func (m *Memory) HolidayWithInsertOrUpdateMany(holidays []*model.Holiday, timeout time.Duration) (int64, error) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	for _, holiday := range holidays {
+		var (
+			h    = *holiday
+			date = holiday.Date.Format("2006-01-02")
+		)
+		h.Date = truncateDate(holiday.Date)
+		if d, ok := m.holidays[date]; ok {
+			h.CreateTimestamp = d.CreateTimestamp
+			h.ModifyTimestamp = sql.NullTime{Time: time.Now(), Valid: true}
+		} else {
+			h.CreateTimestamp = time.Now()
+			h.ModifyTimestamp = sql.NullTime{}
+		}
+		m.holidays[date] = &h
+	}
+	return int64(len(holidays)), nil
+}
+
+func (m *Memory) HolidayWithSelectMany(begin, end string, timeout time.Duration) ([]*model.Holiday, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	var holidays = make([]*model.Holiday, 0, 16)
+	for date, holiday := range m.holidays {
+		if date >= begin && date <= end {
+			var h = *holiday
+			holidays = append(holidays, &h)
+		}
+	}
+	sort.Slice(holidays, func(i, j int) bool {
+		return holidays[i].Date.Before(holidays[j].Date)
+	})
+	return holidays, nil
+}
+
+func (m *Memory) MetadataWithInsertMany(data []*model.Metadata, timeout time.Duration) (int64, error) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	var exist = make(map[string]struct{}, len(m.metadata))
+	for _, d := range m.metadata {
+		exist[d.Code+d.Date.Format("2006-01-02")+d.Time] = struct{}{}
+	}
+
+	var affected int64
+	for _, d := range data {
+		var key = d.Code + d.Date.Format("2006-01-02") + d.Time
+		if _, ok := exist[key]; ok {
+			continue
+		}
+		exist[key] = struct{}{}
+
+		var c = *d
+		m.metadataID++
+		c.Id = m.metadataID
+		c.Date = truncateDate(d.Date)
+		c.CreateTimestamp = time.Now()
+		m.metadata = append(m.metadata, &c)
+		affected++
+	}
+	return affected, nil
+}
+
+func (m *Memory) MetadataWithSelectBetweenByCodeAndDate(code string, begin, end string, timeout time.Duration) ([]*model.Metadata, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	var data = make([]*model.Metadata, 0, 8)
+	for _, d := range m.metadata {
+		var date = d.Date.Format("2006-01-02")
+		if d.Code == code && date >= begin && date <= end {
+			var c = *d
+			data = append(data, &c)
+		}
+	}
+	sort.SliceStable(data, func(i, j int) bool {
+		return data[i].Date.Before(data[j].Date)
+	})
+	return data, nil
+}
+
+func (m *Memory) MetadataRejectedWithInsertOrUpdateMany(records []*model.MetadataRejected, timeout time.Duration) (int64, error) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	var count int64
+	for _, record := range records {
+		var exist *model.MetadataRejected
+		for _, r := range m.rejected {
+			if r.Code == record.Code && r.Date == record.Date {
+				exist = r
+				break
+			}
+		}
+		if exist != nil {
+			exist.Reason = record.Reason
+			exist.Metadata = record.Metadata
+			exist.ModifyTimestamp = sql.NullTime{Time: time.Now(), Valid: true}
+			count++
+			continue
+		}
+
+		var r = *record
+		m.rejectedID++
+		r.Id = m.rejectedID
+		r.CreateTimestamp = time.Now()
+		r.ModifyTimestamp = sql.NullTime{}
+		m.rejected[r.Id] = &r
+		count++
+	}
+	return count, nil
+}
+
+func (m *Memory) MetadataRejectedWithSelectRange(code string, offset, limit int64, timeout time.Duration) ([]*model.MetadataRejected, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	var records = make([]*model.MetadataRejected, 0, len(m.rejected))
+	for _, record := range m.rejected {
+		if code != "" && record.Code != code {
+			continue
+		}
+		var r = *record
+		records = append(records, &r)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Id < records[j].Id
+	})
+
+	begin, end := bounds(len(records), offset, limit)
+	return records[begin:end], nil
+}
+
+func (m *Memory) MetadataRejectedWithSelectOne(id int64, timeout time.Duration) (*model.MetadataRejected, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	record, ok := m.rejected[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	var r = *record
+	return &r, nil
+}
+
+func (m *Memory) MetadataRejectedWithDeleteMany(ids []int64, timeout time.Duration) (int64, error) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	var count int64
+	for _, id := range ids {
+		if _, ok := m.rejected[id]; ok {
+			delete(m.rejected, id)
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *Memory) TaskWithSelectOne(date string, timeout time.Duration) (*model.Task, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	task, ok := m.tasks[date]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	var t = *task
+	return &t, nil
+}
+
+func (m *Memory) TaskWithSelectRange(from, to string, status string, offset, limit int64, timeout time.Duration) ([]*model.Task, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	var dates = make([]string, 0, len(m.tasks))
+	for date, task := range m.tasks {
+		if (from != "" && date < from) || (to != "" && date > to) || (status != "" && task.Status != status) {
+			continue
+		}
+		dates = append(dates, date)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(dates)))
+
+	var (
+		tasks      = make([]*model.Task, 0, limit)
+		begin, end = bounds(len(dates), offset, limit)
+	)
+	for _, date := range dates[begin:end] {
+		var t = *m.tasks[date]
+		tasks = append(tasks, &t)
+	}
+	return tasks, nil
+}
+
+func (m *Memory) TaskWithSelectDueCallback(now time.Time, limit int64, timeout time.Duration) ([]*model.Task, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	var tasks = make([]*model.Task, 0, limit)
+	for _, task := range m.tasks {
+		if task.Status != model.TaskCallbackPending || (task.NextCallbackTimestamp.Valid && task.NextCallbackTimestamp.Time.After(now)) {
+			continue
+		}
+		var t = *task
+		tasks = append(tasks, &t)
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		var a, b = tasks[i].NextCallbackTimestamp, tasks[j].NextCallbackTimestamp
+		if a.Valid != b.Valid {
+			return !a.Valid
+		}
+		if !a.Time.Equal(b.Time) {
+			return a.Time.Before(b.Time)
+		}
+		return tasks[i].Date < tasks[j].Date
+	})
+	if int64(len(tasks)) > limit {
+		tasks = tasks[:limit]
+	}
+	return tasks, nil
+}
+
+func (m *Memory) TaskWithInsertOne(task *model.Task, timeout time.Duration) (int64, error) {
+	if task == nil {
+		return 0, nil
+	}
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	if _, ok := m.tasks[task.Date]; ok {
+		return 0, fmt.Errorf("duplicate entry '%s' for key 'task.PRIMARY'", task.Date)
+	}
+	var t = *task
+	t.CreateTimestamp = time.Now()
+	t.ModifyTimestamp = sql.NullTime{}
+	m.tasks[task.Date] = &t
+	return 1, nil
+}
+
+func (m *Memory) TaskWithUpdateOne(date string, status string, task *model.Task, timeout time.Duration) (int64, error) {
+	if task == nil {
+		return 0, nil
+	}
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	t, ok := m.tasks[date]
+	if !ok || t.Status != status {
+		return 0, nil
+	}
+	t.Status = task.Status
+	t.LastError = task.LastError
+	t.MetadataCount = task.MetadataCount
+	t.StockCount = task.StockCount
+	t.DayCount = task.DayCount
+	t.WeekCount = task.WeekCount
+	t.ActualStockCount = task.ActualStockCount
+	t.ActualDayCount = task.ActualDayCount
+	t.ActualWeekCount = task.ActualWeekCount
+	t.Inconsistent = task.Inconsistent
+	t.CallbackURL = task.CallbackURL
+	t.CallbackMode = task.CallbackMode
+	t.CallbackSecret = task.CallbackSecret
+	t.CallbackAttempts = task.CallbackAttempts
+	t.NextCallbackTimestamp = task.NextCallbackTimestamp
+	t.IngestingTimestamp = task.IngestingTimestamp
+	t.VerifyingTimestamp = task.VerifyingTimestamp
+	t.CallbackPendingTimestamp = task.CallbackPendingTimestamp
+	t.CompletedTimestamp = task.CompletedTimestamp
+	t.FailedTimestamp = task.FailedTimestamp
+	t.FailedStatus = task.FailedStatus
+	t.ModifyTimestamp = sql.NullTime{Time: time.Now(), Valid: true}
+	return 1, nil
+}
+
+func (m *Memory) TaskCallbackAttemptWithInsertOne(attempt *model.TaskCallbackAttempt, timeout time.Duration) (int64, error) {
+	if attempt == nil {
+		return 0, nil
+	}
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	m.attemptID++
+	var a = *attempt
+	a.Id = m.attemptID
+	a.CreateTimestamp = time.Now()
+	m.attempts = append(m.attempts, &a)
+	return 1, nil
+}
+
+func (m *Memory) TaskCallbackAttemptWithSelectMany(date string, timeout time.Duration) ([]*model.TaskCallbackAttempt, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	var attempts = make([]*model.TaskCallbackAttempt, 0, 8)
+	for _, attempt := range m.attempts {
+		if attempt.Date != date {
+			continue
+		}
+		var a = *attempt
+		attempts = append(attempts, &a)
+	}
+	return attempts, nil
+}
+
+func (m *Memory) Close() error {
+	return nil
+}
+
+func (m *Memory) table(mode string) (map[string][]*model.Quote, error) {
+	table, ok := m.quotes[mode]
+	if !ok {
+		return nil, fmt.Errorf("table 'quote_%s' doesn't exist", mode)
+	}
+	return table, nil
+}
+
+func truncateDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// bounds 计算 limit offset, limit 对应的区间
+func bounds(size int, offset, limit int64) (int, int) {
+	if offset >= int64(size) {
+		return size, size
+	}
+	var end = offset + limit
+	if end > int64(size) {
+		end = int64(size)
+	}
+	return int(offset), int(end)
+}
+
+// round2 与 DECIMAL(10,2) 保持一致
+func round2(f float64) float64 {
+	return math.Round(f*100) / 100
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/eviltomorrow/robber-repository/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryQuoteWithInsertOrUpdateMany(t *testing.T) {
+	_assert := assert.New(t)
+	repo := NewMemory()
+
+	var (
+		d1 = time.Date(2021, time.May, 10, 15, 0, 0, 0, time.Local)
+		d2 = d1.AddDate(0, 0, 1)
+	)
+	inserted, updated, err := repo.QuoteWithInsertOrUpdateMany(model.Day, []*model.Quote{
+		quoteOf("sz000001", d2, 10.005, 1.0),
+		quoteOf("sz000001", d1, 10.00, 1.0),
+		quoteOf("sh601012", d1, 20.00, 1.0),
+	}, timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(3), inserted)
+	_assert.Equal(int64(0), updated)
+
+	stored, err := repo.QuoteWithSelectOneByCodeAndDate(model.Day, "sz000001", d2.Format("2006-01-02"), timeout)
+	_assert.Nil(err)
+
+	// 同一批次内重复的 code、date 以最后一条为准
+	inserted, updated, err = repo.QuoteWithInsertOrUpdateMany(model.Day, []*model.Quote{quoteOf("sz000001", d2, 10.50, 1.0), quoteOf("sz000001", d2, 11.00, 1.0)}, timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(0), inserted)
+	_assert.Equal(int64(1), updated)
+
+	quote, err := repo.QuoteWithSelectOneByCodeAndDate(model.Day, "sz000001", d2.Format("2006-01-02"), timeout)
+	_assert.Nil(err)
+	_assert.Equal(stored.Id, quote.Id)
+	_assert.True(quote.ModifyTimestamp.Valid)
+
+	latest, err := repo.QuoteWithSelectManyLatest(model.Day, "sz000001", d2.Format("2006-01-02"), 10, timeout)
+	_assert.Nil(err)
+	_assert.Equal(2, len(latest))
+	_assert.Equal(11.00, latest[0].Close)
+	_assert.Equal(truncateDate(d1), latest[1].Date)
+
+	data, err := repo.QuoteWithSelectRangeByDate(model.Day, d1.Format("2006-01-02"), 1, 10, timeout)
+	_assert.Nil(err)
+	_assert.Equal(1, len(data))
+	_assert.Equal("sz000001", data[0].Code)
+
+	_, err = repo.QuoteWithSelectOneByCodeAndDate(model.Day, "sz000002", d1.Format("2006-01-02"), timeout)
+	_assert.Equal(sql.ErrNoRows, err)
+
+	_, _, err = repo.QuoteWithInsertOrUpdateMany("minute", []*model.Quote{quoteOf("sz000001", d1, 10.00, 1.0)}, timeout)
+	_assert.NotNil(err)
+}
+
+func TestMemoryStockWithSelectRange(t *testing.T) {
+	_assert := assert.New(t)
+	repo := NewMemory()
+
+	inserted, _, err := repo.StockWithInsertOrUpdateMany([]*model.Stock{
+		{Code: "sz000002", Name: "万科A", Suspend: "正常"},
+		{Code: "sz000001", Name: "平安银行", Suspend: "正常"},
+		{Code: "sh601012", Name: "隆基股份", Suspend: "正常"},
+	}, timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(3), inserted)
+
+	stocks, err := repo.StockWithSelectRange(1, 10, timeout)
+	_assert.Nil(err)
+	_assert.Equal(2, len(stocks))
+	_assert.Equal("sz000001", stocks[0].Code)
+
+	stocks, err = repo.StockWithSelectRange(3, 10, timeout)
+	_assert.Nil(err)
+	_assert.Equal(0, len(stocks))
+}
@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/eviltomorrow/robber-core/pkg/mysql"
+	"github.com/eviltomorrow/robber-repository/internal/model"
+)
+
+// MySQL 基于 MySQL 的存储实现
+type MySQL struct {
+	db *sql.DB
+}
+
+// NewMySQL 创建 MySQL 连接
+func NewMySQL(dsn string, minOpen, maxOpen int) (*MySQL, error) {
+	db, err := OpenMySQL(dsn, minOpen, maxOpen)
+	if err != nil {
+		return nil, err
+	}
+	return &MySQL{db: db}, nil
+}
+
+// OpenMySQL 打开 MySQL 连接池
+func OpenMySQL(dsn string, minOpen, maxOpen int) (*sql.DB, error) {
+	mysql.DSN = dsn
+	mysql.MinOpen = minOpen
+	mysql.MaxOpen = maxOpen
+	if err := mysql.Build(); err != nil {
+		return nil, err
+	}
+	return mysql.DB, nil
+}
+
+func (m *MySQL) StockWithInsertOrUpdateMany(stocks []*model.Stock, timeout time.Duration) (int64, int64, error) {
+	if len(stocks) == 0 {
+		return 0, 0, nil
+	}
+
+	tx, err := m.db.Begin()
+	if err != nil {
+		return 0, 0, err
+	}
+	inserted, updated, err := model.StockWithInsertOrUpdateMany(tx, uniqueStocks(stocks), timeout)
+	if err != nil {
+		tx.Rollback()
+		return 0, 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		tx.Rollback()
+		return 0, 0, err
+	}
+	return inserted, updated, nil
+}
+
+func (m *MySQL) StockWithSelectMany(codes []string, timeout time.Duration) (map[string]*model.Stock, error) {
+	return model.StockWithSelectMany(m.db, codes, timeout)
+}
+
+func (m *MySQL) StockWithSelectRange(offset, limit int64, timeout time.Duration) ([]*model.Stock, error) {
+	return model.StockWithSelectRange(m.db, offset, limit, timeout)
+}
+
+func (m *MySQL) StockSuspendWithInsertOrUpdateMany(data []*model.StockSuspend, timeout time.Duration) (int64, error) {
+	return model.StockSuspendWithInsertOrUpdateMany(m.db, data, timeout)
+}
+
+func (m *MySQL) StockSuspendWithSelectManyByDate(date string, timeout time.Duration) ([]*model.StockSuspend, error) {
+	return model.StockSuspendWithSelectManyByDate(m.db, date, timeout)
+}
+
+func (m *MySQL) StockSuspendWithSelectManyByCodesAndDate(codes []string, date string, timeout time.Duration) (map[string]*model.StockSuspend, error) {
+	return model.StockSuspendWithSelectManyByCodesAndDate(m.db, codes, date, timeout)
+}
+
+func (m *MySQL) StockSuspendWithCountByDate(date string, timeout time.Duration) (int64, error) {
+	return model.StockSuspendWithCountByDate(m.db, date, timeout)
+}
+
+func (m *MySQL) QuoteWithInsertOrUpdateMany(mode string, quotes []*model.Quote, timeout time.Duration) (int64, int64, error) {
+	return m.QuoteWithInsertOrUpdateManyAndFactor(mode, quotes, nil, "", nil, timeout)
+}
+
+func (m *MySQL) QuoteWithInsertOrUpdateManyAndFactor(mode string, quotes []*model.Quote, ids []int64, date string, ratios map[string]float64, timeout time.Duration) (int64, int64, error) {
+	if len(quotes) == 0 && len(ids) == 0 && len(ratios) == 0 {
+		return 0, 0, nil
+	}
+
+	tx, err := m.db.Begin()
+	if err != nil {
+		return 0, 0, err
+	}
+	if _, err := model.QuoteWithDeleteManyByIds(tx, mode, ids, timeout); err != nil {
+		tx.Rollback()
+		return 0, 0, err
+	}
+	inserted, updated, err := model.QuoteWithInsertOrUpdateMany(tx, mode, uniqueQuotes(quotes), timeout)
+	if err != nil {
+		tx.Rollback()
+		return 0, 0, err
+	}
+	for code, ratio := range ratios {
+		if _, err := model.QuoteWithUpdateFactorAfterDate(tx, mode, code, date, ratio, timeout); err != nil {
+			tx.Rollback()
+			return 0, 0, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		tx.Rollback()
+		return 0, 0, err
+	}
+	return inserted, updated, nil
+}
+
+func (m *MySQL) QuoteWithSelectBetweenByCodeAndDate(mode string, code string, begin, end string, timeout time.Duration) ([]*model.Quote, error) {
+	return model.QuoteWithSelectBetweenByCodeAndDate(m.db, mode, code, begin, end, timeout)
+}
+
+func (m *MySQL) QuoteWithSelectManyLatest(mode string, code string, date string, limit int64, timeout time.Duration) ([]*model.Quote, error) {
+	return model.QuoteWithSelectManyLatest(m.db, mode, code, date, limit, timeout)
+}
+
+func (m *MySQL) QuoteWithSelectManyLatestByCodes(mode string, codes []string, date string, limit int64, timeout time.Duration) (map[string][]*model.Quote, error) {
+	return model.QuoteWithSelectManyLatestByCodes(m.db, mode, codes, date, limit, timeout)
+}
+
+func (m *MySQL) QuoteWithSelectManyFactorByCodes(mode string, codes []string, timeout time.Duration) (map[string]float64, error) {
+	return model.QuoteWithSelectManyFactorByCodes(m.db, mode, codes, timeout)
+}
+
+func (m *MySQL) QuoteWithUpdateFactorAfterDate(mode string, code string, date string, ratio float64, timeout time.Duration) (int64, error) {
+	return model.QuoteWithUpdateFactorAfterDate(m.db, mode, code, date, ratio, timeout)
+}
+
+func (m *MySQL) QuoteWithSelectRangeByDate(mode string, date string, offset, limit int64, timeout time.Duration) ([]*model.Quote, error) {
+	return model.QuoteWithSelectRangeByDate(m.db, mode, date, offset, limit, timeout)
+}
+
+func (m *MySQL) QuoteWithSelectOneByCodeAndDate(mode string, code string, date string, timeout time.Duration) (*model.Quote, error) {
+	return model.QuoteWithSelectOneByCodeAndDate(m.db, mode, code, date, timeout)
+}
+
+func (m *MySQL) QuoteWithCountByDate(mode string, date string, timeout time.Duration) (int64, error) {
+	return model.QuoteWithCountByDate(m.db, mode, date, timeout)
+}
+
+func (m *MySQL) CorporateActionWithInsertOrUpdateMany(actions []*model.CorporateAction, timeout time.Duration) (int64, error) {
+	return model.CorporateActionWithInsertOrUpdateMany(m.db, actions, timeout)
+}
+
+func (m *MySQL) CorporateActionWithSelectMany(code string, begin, end string, timeout time.Duration) ([]*model.CorporateAction, error) {
+	return model.CorporateActionWithSelectMany(m.db, code, begin, end, timeout)
+}
+
+func (m *MySQL) CorporateActionWithSelectManyByCodes(codes []string, begin, end string, timeout time.Duration) (map[string][]*model.CorporateAction, error) {
+	return model.CorporateActionWithSelectManyByCodes(m.db, codes, begin, end, timeout)
+}
+
+func (m *MySQL) BatchWithSelectOne(id string, timeout time.Duration) (*model.Batch, error) {
+	return model.BatchWithSelectOne(m.db, id, timeout)
+}
+
+func (m *MySQL) BatchWithInsertOne(batch *model.Batch, timeout time.Duration) (int64, error) {
+	return model.BatchWithInsertOne(m.db, batch, timeout)
+}
+
+func (m *MySQL) BatchWithUpdateOne(batch *model.Batch, timeout time.Duration) (int64, error) {
+	return model.BatchWithUpdateOne(m.db, batch, timeout)
+}
+
+func (m *MySQL) BatchWithDeleteOne(id string, before time.Time, timeout time.Duration) (int64, error) {
+	return model.BatchWithDeleteOne(m.db, id, before, timeout)
+}
+
+func (m *MySQL) HolidayWithInsertOrUpdateMany(holidays []*model.Holiday, timeout time.Duration) (int64, error) {
+	return model.HolidayWithInsertOrUpdateMany(m.db, holidays, timeout)
+}
+
+func (m *MySQL) HolidayWithSelectMany(begin, end string, timeout time.Duration) ([]*model.Holiday, error) {
+	return model.HolidayWithSelectMany(m.db, begin, end, timeout)
+}
+
+func (m *MySQL) MetadataWithInsertMany(data []*model.Metadata, timeout time.Duration) (int64, error) {
+	return model.MetadataWithInsertMany(m.db, data, timeout)
+}
+
+func (m *MySQL) MetadataWithSelectBetweenByCodeAndDate(code string, begin, end string, timeout time.Duration) ([]*model.Metadata, error) {
+	return model.MetadataWithSelectBetweenByCodeAndDate(m.db, code, begin, end, timeout)
+}
+
+func (m *MySQL) MetadataRejectedWithInsertOrUpdateMany(records []*model.MetadataRejected, timeout time.Duration) (int64, error) {
+	return model.MetadataRejectedWithInsertOrUpdateMany(m.db, records, timeout)
+}
+
+func (m *MySQL) MetadataRejectedWithSelectRange(code string, offset, limit int64, timeout time.Duration) ([]*model.MetadataRejected, error) {
+	return model.MetadataRejectedWithSelectRange(m.db, code, offset, limit, timeout)
+}
+
+func (m *MySQL) MetadataRejectedWithSelectOne(id int64, timeout time.Duration) (*model.MetadataRejected, error) {
+	return model.MetadataRejectedWithSelectOne(m.db, id, timeout)
+}
+
+func (m *MySQL) MetadataRejectedWithDeleteMany(ids []int64, timeout time.Duration) (int64, error) {
+	return model.MetadataRejectedWithDeleteMany(m.db, ids, timeout)
+}
+
+func (m *MySQL) TaskWithSelectOne(date string, timeout time.Duration) (*model.Task, error) {
+	return model.TaskWithSelectOne(m.db, date, timeout)
+}
+
+func (m *MySQL) TaskWithSelectRange(from, to string, status string, offset, limit int64, timeout time.Duration) ([]*model.Task, error) {
+	return model.TaskWithSelectRange(m.db, from, to, status, offset, limit, timeout)
+}
+
+func (m *MySQL) TaskWithSelectDueCallback(now time.Time, limit int64, timeout time.Duration) ([]*model.Task, error) {
+	return model.TaskWithSelectDueCallback(m.db, now, limit, timeout)
+}
+
+func (m *MySQL) TaskWithInsertOne(task *model.Task, timeout time.Duration) (int64, error) {
+	return model.TaskWithInsertOne(m.db, task, timeout)
+}
+
+func (m *MySQL) TaskWithUpdateOne(date string, status string, task *model.Task, timeout time.Duration) (int64, error) {
+	return model.TaskWithUpdateOne(m.db, date, status, task, timeout)
+}
+
+func (m *MySQL) TaskCallbackAttemptWithInsertOne(attempt *model.TaskCallbackAttempt, timeout time.Duration) (int64, error) {
+	return model.TaskCallbackAttemptWithInsertOne(m.db, attempt, timeout)
+}
+
+func (m *MySQL) TaskCallbackAttemptWithSelectMany(date string, timeout time.Duration) ([]*model.TaskCallbackAttempt, error) {
+	return model.TaskCallbackAttemptWithSelectMany(m.db, date, timeout)
+}
+
+func (m *MySQL) Close() error {
+	return m.db.Close()
+}
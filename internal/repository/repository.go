@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/eviltomorrow/robber-repository/internal/config"
+	"github.com/eviltomorrow/robber-repository/internal/migration"
+	"github.com/eviltomorrow/robber-repository/internal/model"
+)
+
+const (
+	DriverMySQL  = "mysql"
+	DriverSQLite = "sqlite"
+	DriverMemory = "memory"
+)
+
+// Repository 存储接口，屏蔽具体的存储实现
+type Repository interface {
+	// StockWithInsertOrUpdateMany 新增不存在的 stock，名称或停牌状态变化时更新已有 stock，返回新增及更新的数量
+	StockWithInsertOrUpdateMany(stocks []*model.Stock, timeout time.Duration) (int64, int64, error)
+	StockWithSelectMany(codes []string, timeout time.Duration) (map[string]*model.Stock, error)
+	StockWithSelectRange(offset, limit int64, timeout time.Duration) ([]*model.Stock, error)
+
+	// StockSuspendWithInsertOrUpdateMany 按 code、date 记录每日停牌状态
+	StockSuspendWithInsertOrUpdateMany(data []*model.StockSuspend, timeout time.Duration) (int64, error)
+	// StockSuspendWithSelectManyByDate 查询 date 当日停牌的 stock，按 code 升序
+	StockSuspendWithSelectManyByDate(date string, timeout time.Duration) ([]*model.StockSuspend, error)
+	// StockSuspendWithSelectManyByCodesAndDate 查询 codes 在 date 当日的停牌状态，date 当日未写入的 code 不在结果中
+	StockSuspendWithSelectManyByCodesAndDate(codes []string, date string, timeout time.Duration) (map[string]*model.StockSuspend, error)
+	// StockSuspendWithCountByDate 统计 date 当日写入的 stock 数量，含正常交易的 stock
+	StockSuspendWithCountByDate(date string, timeout time.Duration) (int64, error)
+
+	// QuoteWithInsertOrUpdateMany 在同一事务内按 code、date 新增或覆盖更新 quotes，返回新增及更新的数量
+	// quotes 中重复的 code、date 以最后一条为准
+	QuoteWithInsertOrUpdateMany(mode string, quotes []*model.Quote, timeout time.Duration) (int64, int64, error)
+	// QuoteWithInsertOrUpdateManyAndFactor 在同一事务内删除 ids 对应的数据，新增或覆盖更新 quotes，并将 ratios 中各 code 在 date 之后数据的累计复权因子乘以对应比例
+	QuoteWithInsertOrUpdateManyAndFactor(mode string, quotes []*model.Quote, ids []int64, date string, ratios map[string]float64, timeout time.Duration) (int64, int64, error)
+	// 以下查询均返回未复权的原始价格，复权见 model.AdjustQuotes
+	QuoteWithSelectBetweenByCodeAndDate(mode string, code string, begin, end string, timeout time.Duration) ([]*model.Quote, error)
+	QuoteWithSelectManyLatest(mode string, code string, date string, limit int64, timeout time.Duration) ([]*model.Quote, error)
+	QuoteWithSelectManyLatestByCodes(mode string, codes []string, date string, limit int64, timeout time.Duration) (map[string][]*model.Quote, error)
+	QuoteWithSelectManyFactorByCodes(mode string, codes []string, timeout time.Duration) (map[string]float64, error)
+	QuoteWithUpdateFactorAfterDate(mode string, code string, date string, ratio float64, timeout time.Duration) (int64, error)
+	QuoteWithSelectRangeByDate(mode string, date string, offset, limit int64, timeout time.Duration) ([]*model.Quote, error)
+	QuoteWithSelectOneByCodeAndDate(mode string, code string, date string, timeout time.Duration) (*model.Quote, error)
+	QuoteWithCountByDate(mode string, date string, timeout time.Duration) (int64, error)
+
+	CorporateActionWithInsertOrUpdateMany(actions []*model.CorporateAction, timeout time.Duration) (int64, error)
+	CorporateActionWithSelectMany(code string, begin, end string, timeout time.Duration) ([]*model.CorporateAction, error)
+	CorporateActionWithSelectManyByCodes(codes []string, begin, end string, timeout time.Duration) (map[string][]*model.CorporateAction, error)
+
+	BatchWithSelectOne(id string, timeout time.Duration) (*model.Batch, error)
+	// BatchWithInsertOne 新增批次，id 已存在时不写入并返回 0
+	BatchWithInsertOne(batch *model.Batch, timeout time.Duration) (int64, error)
+	// BatchWithUpdateOne 记录 pending 批次的写入数量并置为 batch.Status，批次不是 pending 时返回 0
+	BatchWithUpdateOne(batch *model.Batch, timeout time.Duration) (int64, error)
+	// BatchWithDeleteOne 删除 pending 批次，before 不为零值时仅删除 before 之前创建的批次
+	BatchWithDeleteOne(id string, before time.Time, timeout time.Duration) (int64, error)
+
+	HolidayWithInsertOrUpdateMany(holidays []*model.Holiday, timeout time.Duration) (int64, error)
+	HolidayWithSelectMany(begin, end string, timeout time.Duration) ([]*model.Holiday, error)
+
+	// MetadataWithInsertMany 追加写入原始数据，同一 code、date、time 已存在时忽略，返回实际写入的数量
+	MetadataWithInsertMany(data []*model.Metadata, timeout time.Duration) (int64, error)
+	MetadataWithSelectBetweenByCodeAndDate(code string, begin, end string, timeout time.Duration) ([]*model.Metadata, error)
+
+	MetadataRejectedWithInsertOrUpdateMany(records []*model.MetadataRejected, timeout time.Duration) (int64, error)
+	MetadataRejectedWithSelectRange(code string, offset, limit int64, timeout time.Duration) ([]*model.MetadataRejected, error)
+	MetadataRejectedWithSelectOne(id int64, timeout time.Duration) (*model.MetadataRejected, error)
+	MetadataRejectedWithDeleteMany(ids []int64, timeout time.Duration) (int64, error)
+
+	TaskWithSelectOne(date string, timeout time.Duration) (*model.Task, error)
+	// TaskWithSelectRange 按 date 降序分页查询 [from, to] 之间的 task，from、to、status 为空时不限制
+	TaskWithSelectRange(from, to string, status string, offset, limit int64, timeout time.Duration) ([]*model.Task, error)
+	// TaskWithSelectDueCallback 查询 next_callback_timestamp 为空或不晚于 now 的 callback_pending task，按 next_callback_timestamp 升序，最多 limit 条
+	TaskWithSelectDueCallback(now time.Time, limit int64, timeout time.Duration) ([]*model.Task, error)
+	TaskWithInsertOne(task *model.Task, timeout time.Duration) (int64, error)
+	// TaskWithUpdateOne 仅当 task 当前状态为 status 时更新，状态已被其他请求修改时返回 0
+	TaskWithUpdateOne(date string, status string, task *model.Task, timeout time.Duration) (int64, error)
+
+	TaskCallbackAttemptWithInsertOne(attempt *model.TaskCallbackAttempt, timeout time.Duration) (int64, error)
+	// TaskCallbackAttemptWithSelectMany 查询 date 对应 task 的全部回调投递记录，按投递顺序升序
+	TaskCallbackAttemptWithSelectMany(date string, timeout time.Duration) ([]*model.TaskCallbackAttempt, error)
+
+	Close() error
+}
+
+// Build 根据配置创建存储实现，数据库表结构落后于程序时返回错误
+func Build(cfg *config.Config) (Repository, error) {
+	switch cfg.Storage.Driver {
+	case DriverMySQL, "":
+		repo, err := NewMySQL(cfg.MySQL.DSN, cfg.MySQL.MinOpen, cfg.MySQL.MaxOpen)
+		if err != nil {
+			return nil, err
+		}
+		migrator, err := migration.New(repo.db, migration.DriverMySQL)
+		if err != nil {
+			repo.Close()
+			return nil, err
+		}
+		if err := migrator.Check(); err != nil {
+			repo.Close()
+			return nil, err
+		}
+		return repo, nil
+	case DriverSQLite:
+		return NewSQLite(cfg.SQLite.Path)
+	case DriverMemory:
+		return NewMemory(), nil
+	default:
+		return nil, fmt.Errorf("not support storage driver[%s]", cfg.Storage.Driver)
+	}
+}
+
+// OpenDB 打开配置对应的数据库连接，返回连接及其 driver
+func OpenDB(cfg *config.Config) (*sql.DB, string, error) {
+	switch cfg.Storage.Driver {
+	case DriverMySQL, "":
+		db, err := OpenMySQL(cfg.MySQL.DSN, cfg.MySQL.MinOpen, cfg.MySQL.MaxOpen)
+		return db, DriverMySQL, err
+	case DriverSQLite:
+		db, err := OpenSQLite(cfg.SQLite.Path)
+		return db, DriverSQLite, err
+	default:
+		return nil, "", fmt.Errorf("storage driver[%s] not support database connection", cfg.Storage.Driver)
+	}
+}
+
+// uniqueQuotes 按 code、date 去重，保留最后一条
+func uniqueQuotes(quotes []*model.Quote) []*model.Quote {
+	var (
+		data  = make([]*model.Quote, 0, len(quotes))
+		index = make(map[string]int, len(quotes))
+	)
+	for _, quote := range quotes {
+		var key = quote.Code + quote.Date.Format("2006-01-02")
+		if i, ok := index[key]; ok {
+			data[i] = quote
+			continue
+		}
+		index[key] = len(data)
+		data = append(data, quote)
+	}
+	return data
+}
+
+// uniqueStocks 按 code 去重，保留最后一条
+func uniqueStocks(stocks []*model.Stock) []*model.Stock {
+	var (
+		data  = make([]*model.Stock, 0, len(stocks))
+		index = make(map[string]int, len(stocks))
+	)
+	for _, stock := range stocks {
+		if i, ok := index[stock.Code]; ok {
+			data[i] = stock
+			continue
+		}
+		index[stock.Code] = len(data)
+		data = append(data, stock)
+	}
+	return data
+}
@@ -0,0 +1,1326 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/eviltomorrow/robber-core/pkg/mysql"
+	"github.com/eviltomorrow/robber-repository/internal/migration"
+	"github.com/eviltomorrow/robber-repository/internal/model"
+
+	_ "modernc.org/sqlite"
+)
+
+const (
+	sqliteDateLayout      = "2006-01-02"
+	sqliteTimestampLayout = "2006-01-02 15:04:05"
+)
+
+// SQLite 基于 SQLite 的存储实现，适用于单机及离线部署
+type SQLite struct {
+	db *sql.DB
+}
+
+// NewSQLite 打开 path 对应的数据库文件，启动时自动执行表结构迁移
+func NewSQLite(path string) (*SQLite, error) {
+	db, err := OpenSQLite(path)
+	if err != nil {
+		return nil, err
+	}
+
+	migrator, err := migration.New(db, migration.DriverSQLite)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	if _, err := migrator.Up(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &SQLite{db: db}, nil
+}
+
+// OpenSQLite 打开 path 对应的数据库文件
+func OpenSQLite(path string) (*sql.DB, error) {
+	if path == "" {
+		return nil, fmt.Errorf("invalid sqlite path, path is nil")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite 同一时刻仅允许一个写连接
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), mysql.DefaultTimeout)
+	defer cancel()
+
+	for _, _sql := range []string{"pragma journal_mode = WAL", "pragma busy_timeout = 5000"} {
+		if _, err := db.ExecContext(ctx, _sql); err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+	return db, nil
+}
+
+func (s *SQLite) StockWithInsertOrUpdateMany(stocks []*model.Stock, timeout time.Duration) (int64, int64, error) {
+	if len(stocks) == 0 {
+		return 0, 0, nil
+	}
+	stocks = uniqueStocks(stocks)
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var codes = make([]string, 0, len(stocks))
+	for _, stock := range stocks {
+		codes = append(codes, stock.Code)
+	}
+	data, err := sqliteStockWithSelectMany(tx, codes, timeout)
+	if err != nil {
+		tx.Rollback()
+		return 0, 0, err
+	}
+
+	var (
+		fields            = make([]string, 0, len(stocks))
+		args              = make([]interface{}, 0, 4*len(stocks))
+		now               = time.Now().Format(sqliteTimestampLayout)
+		inserted, updated int64
+	)
+	for _, stock := range stocks {
+		d, ok := data[stock.Code]
+		switch {
+		case !ok:
+			inserted++
+		case d.Name != stock.Name || d.Suspend != stock.Suspend:
+			updated++
+		default:
+			continue
+		}
+		fields = append(fields, "(?, ?, ?, ?, null)")
+		args = append(args, stock.Code, stock.Name, stock.Suspend, now)
+	}
+	if len(fields) != 0 {
+		ctx, cannel := context.WithTimeout(context.Background(), timeout)
+		defer cannel()
+
+		var _sql = fmt.Sprintf("insert into stock (code, name, suspend, create_timestamp, modify_timestamp) values %s on conflict(code) do update set name = excluded.name, suspend = excluded.suspend, modify_timestamp = excluded.create_timestamp", strings.Join(fields, ","))
+		if _, err := tx.ExecContext(ctx, _sql, args...); err != nil {
+			tx.Rollback()
+			return 0, 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		tx.Rollback()
+		return 0, 0, err
+	}
+	return inserted, updated, nil
+}
+
+func (s *SQLite) StockWithSelectMany(codes []string, timeout time.Duration) (map[string]*model.Stock, error) {
+	return sqliteStockWithSelectMany(s.db, codes, timeout)
+}
+
+func (s *SQLite) StockWithSelectRange(offset, limit int64, timeout time.Duration) ([]*model.Stock, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	rows, err := s.db.QueryContext(ctx, `select code, name, suspend, create_timestamp, modify_timestamp from stock order by code limit ?, ?`, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stocks = make([]*model.Stock, 0, limit)
+	for rows.Next() {
+		stock, err := sqliteScanStock(rows)
+		if err != nil {
+			return nil, err
+		}
+		stocks = append(stocks, stock)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return stocks, nil
+}
+
+func (s *SQLite) StockSuspendWithInsertOrUpdateMany(data []*model.StockSuspend, timeout time.Duration) (int64, error) {
+	if len(data) == 0 {
+		return 0, nil
+	}
+
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var (
+		now    = time.Now().Format(sqliteTimestampLayout)
+		fields = make([]string, 0, len(data))
+		args   = make([]interface{}, 0, 4*len(data)+1)
+	)
+	for _, d := range data {
+		fields = append(fields, "(?, ?, ?, ?, null)")
+		args = append(args, d.Code, d.Date.Format(sqliteDateLayout), d.Suspend, now)
+	}
+	args = append(args, now)
+
+	var _sql = fmt.Sprintf("insert into stock_suspend (code, date, suspend, create_timestamp, modify_timestamp) values %s on conflict(code, date) do update set suspend = excluded.suspend, modify_timestamp = ?", strings.Join(fields, ","))
+	result, err := s.db.ExecContext(ctx, _sql, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (s *SQLite) StockSuspendWithSelectManyByDate(date string, timeout time.Duration) ([]*model.StockSuspend, error) {
+	var _sql = `select code, date, suspend, create_timestamp, modify_timestamp from stock_suspend where date = ? and suspend not in ('', ?) order by code asc`
+	return sqliteStockSuspendWithSelect(s.db, _sql, timeout, date, model.SuspendNormal)
+}
+
+func (s *SQLite) StockSuspendWithSelectManyByCodesAndDate(codes []string, date string, timeout time.Duration) (map[string]*model.StockSuspend, error) {
+	var data = make(map[string]*model.StockSuspend, len(codes))
+	if len(codes) == 0 {
+		return data, nil
+	}
+
+	var fields = make([]string, 0, len(codes))
+	var args = make([]interface{}, 0, len(codes)+1)
+	args = append(args, date)
+	for _, code := range codes {
+		fields = append(fields, "?")
+		args = append(args, code)
+	}
+	var _sql = fmt.Sprintf(`select code, date, suspend, create_timestamp, modify_timestamp from stock_suspend where date = ? and code in (%s)`, strings.Join(fields, ","))
+	suspends, err := sqliteStockSuspendWithSelect(s.db, _sql, timeout, args...)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range suspends {
+		data[d.Code] = d
+	}
+	return data, nil
+}
+
+func sqliteStockSuspendWithSelect(exec mysql.Exec, _sql string, timeout time.Duration, args ...interface{}) ([]*model.StockSuspend, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	rows, err := exec.QueryContext(ctx, _sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var data = make([]*model.StockSuspend, 0, 16)
+	for rows.Next() {
+		var (
+			d               = &model.StockSuspend{}
+			date            string
+			createTimestamp string
+			modifyTimestamp sql.NullString
+		)
+		if err := rows.Scan(&d.Code, &date, &d.Suspend, &createTimestamp, &modifyTimestamp); err != nil {
+			return nil, err
+		}
+		if d.Date, err = time.ParseInLocation(sqliteDateLayout, date, time.Local); err != nil {
+			return nil, err
+		}
+		if d.CreateTimestamp, d.ModifyTimestamp, err = sqliteParseTimestamp(createTimestamp, modifyTimestamp); err != nil {
+			return nil, err
+		}
+		data = append(data, d)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (s *SQLite) StockSuspendWithCountByDate(date string, timeout time.Duration) (int64, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var count int64
+	if err := s.db.QueryRowContext(ctx, `select count(1) from stock_suspend where date = ?`, date).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (s *SQLite) QuoteWithInsertOrUpdateMany(mode string, quotes []*model.Quote, timeout time.Duration) (int64, int64, error) {
+	return s.QuoteWithInsertOrUpdateManyAndFactor(mode, quotes, nil, "", nil, timeout)
+}
+
+func (s *SQLite) QuoteWithInsertOrUpdateManyAndFactor(mode string, quotes []*model.Quote, ids []int64, date string, ratios map[string]float64, timeout time.Duration) (int64, int64, error) {
+	if len(quotes) == 0 && len(ids) == 0 && len(ratios) == 0 {
+		return 0, 0, nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	if len(ids) != 0 {
+		var (
+			fields = make([]string, 0, len(ids))
+			args   = make([]interface{}, 0, len(ids))
+		)
+		for _, id := range ids {
+			fields = append(fields, "?")
+			args = append(args, id)
+		}
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("delete from quote_%s where id in (%s)", mode, strings.Join(fields, ",")), args...); err != nil {
+			tx.Rollback()
+			return 0, 0, err
+		}
+	}
+	inserted, updated, err := sqliteQuoteWithInsertOrUpdateMany(ctx, tx, mode, uniqueQuotes(quotes))
+	if err != nil {
+		tx.Rollback()
+		return 0, 0, err
+	}
+	for code, ratio := range ratios {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("update quote_%s set factor = factor * ? where code = ? and date > ?", mode), ratio, code, date); err != nil {
+			tx.Rollback()
+			return 0, 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		tx.Rollback()
+		return 0, 0, err
+	}
+	return inserted, updated, nil
+}
+
+func sqliteQuoteWithInsertOrUpdateMany(ctx context.Context, tx *sql.Tx, mode string, quotes []*model.Quote) (int64, int64, error) {
+	if len(quotes) == 0 {
+		return 0, 0, nil
+	}
+
+	var (
+		keys = make([]string, 0, len(quotes))
+		args = make([]interface{}, 0, 13*len(quotes))
+	)
+	for _, quote := range quotes {
+		keys = append(keys, "(?, ?)")
+		args = append(args, quote.Code, quote.Date.Format(sqliteDateLayout))
+	}
+
+	var exist int64
+	if err := tx.QueryRowContext(ctx, fmt.Sprintf("select count(*) from quote_%s where (code, date) in (%s)", mode, strings.Join(keys, ",")), args...).Scan(&exist); err != nil {
+		return 0, 0, err
+	}
+
+	var (
+		fields = make([]string, 0, len(quotes))
+		now    = time.Now().Format(sqliteTimestampLayout)
+	)
+	args = args[:0]
+	for _, quote := range quotes {
+		fields = append(fields, "(?, round(?, 2), round(?, 2), round(?, 2), round(?, 2), round(?, 2), ?, round(?, 2), ?, ?, ?, ?, ?)")
+		args = append(args,
+			quote.Code,
+			quote.Open,
+			quote.Close,
+			quote.High,
+			quote.Low,
+			quote.YesterdayClosed,
+			quote.Volume,
+			quote.Account,
+			quote.Date.Format(sqliteDateLayout),
+			quote.NumOfYear,
+			quote.Xd,
+			quote.Factor,
+			now,
+		)
+	}
+
+	var _sql = fmt.Sprintf("insert into quote_%s (code, open, close, high, low, yesterday_closed, volume, account, date, num_of_year, xd, factor, create_timestamp) values %s on conflict(code, date) do update set open = excluded.open, close = excluded.close, high = excluded.high, low = excluded.low, yesterday_closed = excluded.yesterday_closed, volume = excluded.volume, account = excluded.account, num_of_year = excluded.num_of_year, xd = excluded.xd, factor = excluded.factor, modify_timestamp = excluded.create_timestamp", mode, strings.Join(fields, ","))
+	if _, err := tx.ExecContext(ctx, _sql, args...); err != nil {
+		return 0, 0, err
+	}
+	return int64(len(quotes)) - exist, exist, nil
+}
+
+func (s *SQLite) QuoteWithSelectBetweenByCodeAndDate(mode string, code string, begin, end string, timeout time.Duration) ([]*model.Quote, error) {
+	var _sql = fmt.Sprintf("select %s from quote_%s where code = ? and date between ? and ? order by date asc", sqliteQuoteColumns, mode)
+	return sqliteQuoteWithSelect(s.db, _sql, timeout, code, begin, end)
+}
+
+func (s *SQLite) QuoteWithSelectManyLatest(mode string, code string, date string, limit int64, timeout time.Duration) ([]*model.Quote, error) {
+	var _sql = fmt.Sprintf("select %s from quote_%s where code = ? and date <= ? order by date desc limit ?", sqliteQuoteColumns, mode)
+	return sqliteQuoteWithSelect(s.db, _sql, timeout, code, date, limit)
+}
+
+func (s *SQLite) QuoteWithSelectManyLatestByCodes(mode string, codes []string, date string, limit int64, timeout time.Duration) (map[string][]*model.Quote, error) {
+	if len(codes) == 0 {
+		return map[string][]*model.Quote{}, nil
+	}
+
+	var fields = make([]string, 0, len(codes))
+	var args = make([]interface{}, 0, len(codes)+2)
+	for _, code := range codes {
+		fields = append(fields, "?")
+		args = append(args, code)
+	}
+	args = append(args, date, limit)
+
+	var _sql = fmt.Sprintf("select %s from (select %s, row_number() over (partition by code order by date desc) as rn from quote_%s where code in (%s) and date <= ?) t where rn <= ? order by code asc, date desc", sqliteQuoteColumns, sqliteQuoteColumns, mode, strings.Join(fields, ","))
+	quotes, err := sqliteQuoteWithSelect(s.db, _sql, timeout, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var data = make(map[string][]*model.Quote, len(codes))
+	for _, quote := range quotes {
+		data[quote.Code] = append(data[quote.Code], quote)
+	}
+	return data, nil
+}
+
+func (s *SQLite) QuoteWithSelectManyFactorByCodes(mode string, codes []string, timeout time.Duration) (map[string]float64, error) {
+	if len(codes) == 0 {
+		return map[string]float64{}, nil
+	}
+
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var fields = make([]string, 0, len(codes))
+	var args = make([]interface{}, 0, len(codes))
+	for _, code := range codes {
+		fields = append(fields, "?")
+		args = append(args, code)
+	}
+
+	var _sql = fmt.Sprintf("select code, factor from quote_%s q where code in (%s) and date = (select max(date) from quote_%s where code = q.code)", mode, strings.Join(fields, ","), mode)
+	rows, err := s.db.QueryContext(ctx, _sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var data = make(map[string]float64, len(codes))
+	for rows.Next() {
+		var (
+			code   string
+			factor float64
+		)
+		if err := rows.Scan(&code, &factor); err != nil {
+			return nil, err
+		}
+		data[code] = factor
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (s *SQLite) QuoteWithUpdateFactorAfterDate(mode string, code string, date string, ratio float64, timeout time.Duration) (int64, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	result, err := s.db.ExecContext(ctx, fmt.Sprintf("update quote_%s set factor = factor * ? where code = ? and date > ?", mode), ratio, code, date)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (s *SQLite) QuoteWithSelectRangeByDate(mode string, date string, offset, limit int64, timeout time.Duration) ([]*model.Quote, error) {
+	var _sql = fmt.Sprintf("select %s from quote_%s where date = ? order by code limit ?, ?", sqliteQuoteColumns, mode)
+	return sqliteQuoteWithSelect(s.db, _sql, timeout, date, offset, limit)
+}
+
+func (s *SQLite) QuoteWithCountByDate(mode string, date string, timeout time.Duration) (int64, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var count int64
+	if err := s.db.QueryRowContext(ctx, fmt.Sprintf("select count(1) from quote_%s where date = ?", mode), date).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (s *SQLite) QuoteWithSelectOneByCodeAndDate(mode string, code string, date string, timeout time.Duration) (*model.Quote, error) {
+	var _sql = fmt.Sprintf("select %s from quote_%s where code = ? and date = ?", sqliteQuoteColumns, mode)
+	data, err := sqliteQuoteWithSelect(s.db, _sql, timeout, code, date)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return data[0], nil
+}
+
+func (s *SQLite) CorporateActionWithInsertOrUpdateMany(actions []*model.CorporateAction, timeout time.Duration) (int64, error) {
+	if len(actions) == 0 {
+		return 0, nil
+	}
+
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var (
+		now    = time.Now().Format(sqliteTimestampLayout)
+		fields = make([]string, 0, len(actions))
+		args   = make([]interface{}, 0, 6*len(actions)+1)
+	)
+	for _, action := range actions {
+		fields = append(fields, "(?, ?, ?, ?, round(?, 2), ?, null)")
+		args = append(args, action.Code, action.Date.Format(sqliteDateLayout), action.Kind, action.Value, action.Price, now)
+	}
+	args = append(args, now)
+
+	var _sql = fmt.Sprintf("insert into corporate_action (code, date, kind, value, price, create_timestamp, modify_timestamp) values %s on conflict(code, date, kind) do update set value = excluded.value, price = excluded.price, modify_timestamp = ?", strings.Join(fields, ","))
+	result, err := s.db.ExecContext(ctx, _sql, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (s *SQLite) CorporateActionWithSelectMany(code string, begin, end string, timeout time.Duration) ([]*model.CorporateAction, error) {
+	var _sql = `select code, date, kind, value, price, create_timestamp, modify_timestamp from corporate_action where code = ? and date between ? and ? order by date asc, kind asc`
+	return sqliteCorporateActionWithSelect(s.db, _sql, timeout, code, begin, end)
+}
+
+func (s *SQLite) CorporateActionWithSelectManyByCodes(codes []string, begin, end string, timeout time.Duration) (map[string][]*model.CorporateAction, error) {
+	if len(codes) == 0 {
+		return map[string][]*model.CorporateAction{}, nil
+	}
+
+	var fields = make([]string, 0, len(codes))
+	var args = make([]interface{}, 0, len(codes)+2)
+	for _, code := range codes {
+		fields = append(fields, "?")
+		args = append(args, code)
+	}
+	args = append(args, begin, end)
+
+	var _sql = fmt.Sprintf("select code, date, kind, value, price, create_timestamp, modify_timestamp from corporate_action where code in (%s) and date between ? and ? order by code asc, date asc, kind asc", strings.Join(fields, ","))
+	actions, err := sqliteCorporateActionWithSelect(s.db, _sql, timeout, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var data = make(map[string][]*model.CorporateAction, len(codes))
+	for _, action := range actions {
+		data[action.Code] = append(data[action.Code], action)
+	}
+	return data, nil
+}
+
+func (s *SQLite) BatchWithSelectOne(id string, timeout time.Duration) (*model.Batch, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var _sql = `select id, status, stock_count, day_count, week_count, month_count, quarter_count, year_count, stock_updated, day_updated, week_updated, month_updated, quarter_updated, year_updated, create_timestamp, modify_timestamp from push_batch where id = ?`
+	row := s.db.QueryRowContext(ctx, _sql, id)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	var (
+		batch           = &model.Batch{}
+		createTimestamp string
+		modifyTimestamp sql.NullString
+	)
+	if err := row.Scan(
+		&batch.Id,
+		&batch.Status,
+		&batch.StockCount,
+		&batch.DayCount,
+		&batch.WeekCount,
+		&batch.MonthCount,
+		&batch.QuarterCount,
+		&batch.YearCount,
+		&batch.StockUpdated,
+		&batch.DayUpdated,
+		&batch.WeekUpdated,
+		&batch.MonthUpdated,
+		&batch.QuarterUpdated,
+		&batch.YearUpdated,
+		&createTimestamp,
+		&modifyTimestamp,
+	); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if batch.CreateTimestamp, batch.ModifyTimestamp, err = sqliteParseTimestamp(createTimestamp, modifyTimestamp); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+func (s *SQLite) BatchWithInsertOne(batch *model.Batch, timeout time.Duration) (int64, error) {
+	if batch == nil {
+		return 0, nil
+	}
+
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var _sql = `insert or ignore into push_batch(id, status, stock_count, day_count, week_count, month_count, quarter_count, year_count, stock_updated, day_updated, week_updated, month_updated, quarter_updated, year_updated, create_timestamp) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	result, err := s.db.ExecContext(ctx, _sql, batch.Id, batch.Status, batch.StockCount, batch.DayCount, batch.WeekCount, batch.MonthCount, batch.QuarterCount, batch.YearCount, batch.StockUpdated, batch.DayUpdated, batch.WeekUpdated, batch.MonthUpdated, batch.QuarterUpdated, batch.YearUpdated, time.Now().Format(sqliteTimestampLayout))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (s *SQLite) BatchWithUpdateOne(batch *model.Batch, timeout time.Duration) (int64, error) {
+	if batch == nil {
+		return 0, nil
+	}
+
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var _sql = `update push_batch set status = ?, stock_count = ?, day_count = ?, week_count = ?, month_count = ?, quarter_count = ?, year_count = ?, stock_updated = ?, day_updated = ?, week_updated = ?, month_updated = ?, quarter_updated = ?, year_updated = ?, modify_timestamp = ? where id = ? and status = ?`
+	result, err := s.db.ExecContext(ctx, _sql, batch.Status, batch.StockCount, batch.DayCount, batch.WeekCount, batch.MonthCount, batch.QuarterCount, batch.YearCount, batch.StockUpdated, batch.DayUpdated, batch.WeekUpdated, batch.MonthUpdated, batch.QuarterUpdated, batch.YearUpdated, time.Now().Format(sqliteTimestampLayout), batch.Id, model.BatchPending)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (s *SQLite) BatchWithDeleteOne(id string, before time.Time, timeout time.Duration) (int64, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var (
+		_sql = `delete from push_batch where id = ? and status = ?`
+		args = []interface{}{id, model.BatchPending}
+	)
+	if !before.IsZero() {
+		_sql += ` and create_timestamp < ?`
+		args = append(args, before.Format(sqliteTimestampLayout))
+	}
+	result, err := s.db.ExecContext(ctx, _sql, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (s *SQLite) HolidayWithInsertOrUpdateMany(holidays []*model.Holiday, timeout time.Duration) (int64, error) {
+	if len(holidays) == 0 {
+		return 0, nil
+	}
+
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var (
+		now    = time.Now().Format(sqliteTimestampLayout)
+		fields = make([]string, 0, len(holidays))
+		args   = make([]interface{}, 0, 3*len(holidays)+1)
+	)
+	for _, holiday := range holidays {
+		fields = append(fields, "(?, ?, ?, null)")
+		args = append(args, holiday.Date.Format(sqliteDateLayout), holiday.Name, now)
+	}
+	args = append(args, now)
+
+	var _sql = fmt.Sprintf("insert into trading_holiday (date, name, create_timestamp, modify_timestamp) values %s on conflict(date) do update set name = excluded.name, modify_timestamp = ?", strings.Join(fields, ","))
+	result, err := s.db.ExecContext(ctx, _sql, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (s *SQLite) HolidayWithSelectMany(begin, end string, timeout time.Duration) ([]*model.Holiday, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var _sql = `select date, name, create_timestamp, modify_timestamp from trading_holiday where date between ? and ? order by date asc`
+	rows, err := s.db.QueryContext(ctx, _sql, begin, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var holidays = make([]*model.Holiday, 0, 16)
+	for rows.Next() {
+		var (
+			holiday         = &model.Holiday{}
+			date            string
+			createTimestamp string
+			modifyTimestamp sql.NullString
+		)
+		if err := rows.Scan(&date, &holiday.Name, &createTimestamp, &modifyTimestamp); err != nil {
+			return nil, err
+		}
+		if holiday.Date, err = time.ParseInLocation(sqliteDateLayout, date, time.Local); err != nil {
+			return nil, err
+		}
+		if holiday.CreateTimestamp, holiday.ModifyTimestamp, err = sqliteParseTimestamp(createTimestamp, modifyTimestamp); err != nil {
+			return nil, err
+		}
+		holidays = append(holidays, holiday)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return holidays, nil
+}
+
+func (s *SQLite) MetadataWithInsertMany(data []*model.Metadata, timeout time.Duration) (int64, error) {
+	if len(data) == 0 {
+		return 0, nil
+	}
+
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var (
+		now    = time.Now().Format(sqliteTimestampLayout)
+		fields = make([]string, 0, len(data))
+		args   = make([]interface{}, 0, 13*len(data))
+	)
+	for _, d := range data {
+		fields = append(fields, "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+		args = append(args, d.Code, d.Name, d.Open, d.YesterdayClosed, d.Latest, d.High, d.Low, d.Volume, d.Account, d.Date.Format(sqliteDateLayout), d.Time, d.Suspend, now)
+	}
+
+	var _sql = fmt.Sprintf("insert or ignore into metadata (code, name, open, yesterday_closed, latest, high, low, volume, account, date, time, suspend, create_timestamp) values %s", strings.Join(fields, ","))
+	result, err := s.db.ExecContext(ctx, _sql, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (s *SQLite) MetadataWithSelectBetweenByCodeAndDate(code string, begin, end string, timeout time.Duration) ([]*model.Metadata, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var _sql = `select id, code, name, open, yesterday_closed, latest, high, low, volume, account, date, time, suspend, create_timestamp from metadata where code = ? and date between ? and ? order by date asc, id asc`
+	rows, err := s.db.QueryContext(ctx, _sql, code, begin, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var data = make([]*model.Metadata, 0, 8)
+	for rows.Next() {
+		var (
+			d               = &model.Metadata{}
+			date            string
+			createTimestamp string
+		)
+		if err := rows.Scan(
+			&d.Id,
+			&d.Code,
+			&d.Name,
+			&d.Open,
+			&d.YesterdayClosed,
+			&d.Latest,
+			&d.High,
+			&d.Low,
+			&d.Volume,
+			&d.Account,
+			&date,
+			&d.Time,
+			&d.Suspend,
+			&createTimestamp,
+		); err != nil {
+			return nil, err
+		}
+		if d.Date, err = time.ParseInLocation(sqliteDateLayout, date, time.Local); err != nil {
+			return nil, err
+		}
+		if d.CreateTimestamp, _, err = sqliteParseTimestamp(createTimestamp, sql.NullString{}); err != nil {
+			return nil, err
+		}
+		data = append(data, d)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (s *SQLite) MetadataRejectedWithInsertOrUpdateMany(records []*model.MetadataRejected, timeout time.Duration) (int64, error) {
+	if len(records) == 0 {
+		return 0, nil
+	}
+
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var (
+		now    = time.Now().Format(sqliteTimestampLayout)
+		fields = make([]string, 0, len(records))
+		args   = make([]interface{}, 0, 5*len(records)+1)
+	)
+	for _, record := range records {
+		fields = append(fields, "(?, ?, ?, ?, ?, null)")
+		args = append(args, record.Code, record.Date, record.Reason, record.Metadata, now)
+	}
+	args = append(args, now)
+
+	var _sql = fmt.Sprintf("insert into metadata_rejected (code, date, reason, metadata, create_timestamp, modify_timestamp) values %s on conflict(code, date) do update set reason = excluded.reason, metadata = excluded.metadata, modify_timestamp = ?", strings.Join(fields, ","))
+	result, err := s.db.ExecContext(ctx, _sql, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (s *SQLite) MetadataRejectedWithSelectRange(code string, offset, limit int64, timeout time.Duration) ([]*model.MetadataRejected, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var (
+		_sql = `select id, code, date, reason, metadata, create_timestamp, modify_timestamp from metadata_rejected`
+		args = make([]interface{}, 0, 3)
+	)
+	if code != "" {
+		_sql += ` where code = ?`
+		args = append(args, code)
+	}
+	_sql += ` order by id asc limit ?, ?`
+	args = append(args, offset, limit)
+
+	rows, err := s.db.QueryContext(ctx, _sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records = make([]*model.MetadataRejected, 0, limit)
+	for rows.Next() {
+		record, err := sqliteScanMetadataRejected(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func (s *SQLite) MetadataRejectedWithSelectOne(id int64, timeout time.Duration) (*model.MetadataRejected, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var _sql = `select id, code, date, reason, metadata, create_timestamp, modify_timestamp from metadata_rejected where id = ?`
+	row := s.db.QueryRowContext(ctx, _sql, id)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+	return sqliteScanMetadataRejected(row)
+}
+
+func (s *SQLite) MetadataRejectedWithDeleteMany(ids []int64, timeout time.Duration) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var (
+		fields = make([]string, 0, len(ids))
+		args   = make([]interface{}, 0, len(ids))
+	)
+	for _, id := range ids {
+		fields = append(fields, "?")
+		args = append(args, id)
+	}
+
+	var _sql = fmt.Sprintf("delete from metadata_rejected where id in (%s)", strings.Join(fields, ","))
+	result, err := s.db.ExecContext(ctx, _sql, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func sqliteScanMetadataRejected(scanner interface{ Scan(...interface{}) error }) (*model.MetadataRejected, error) {
+	var (
+		record          = &model.MetadataRejected{}
+		createTimestamp string
+		modifyTimestamp sql.NullString
+	)
+	if err := scanner.Scan(
+		&record.Id,
+		&record.Code,
+		&record.Date,
+		&record.Reason,
+		&record.Metadata,
+		&createTimestamp,
+		&modifyTimestamp,
+	); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if record.CreateTimestamp, record.ModifyTimestamp, err = sqliteParseTimestamp(createTimestamp, modifyTimestamp); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+const sqliteTaskColumns = "date, status, last_error, metadata_count, stock_count, day_count, week_count, actual_stock_count, actual_day_count, actual_week_count, inconsistent, callback_url, callback_mode, callback_secret, callback_attempts, next_callback_timestamp, ingesting_timestamp, verifying_timestamp, callback_pending_timestamp, completed_timestamp, failed_timestamp, failed_status, create_timestamp, modify_timestamp"
+
+func (s *SQLite) TaskWithSelectOne(date string, timeout time.Duration) (*model.Task, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	row := s.db.QueryRowContext(ctx, fmt.Sprintf(`select %s from task where date = ?`, sqliteTaskColumns), date)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+	return sqliteScanTask(row)
+}
+
+func (s *SQLite) TaskWithSelectRange(from, to string, status string, offset, limit int64, timeout time.Duration) ([]*model.Task, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var (
+		conditions = make([]string, 0, 3)
+		args       = make([]interface{}, 0, 5)
+	)
+	if from != "" {
+		conditions = append(conditions, "date >= ?")
+		args = append(args, from)
+	}
+	if to != "" {
+		conditions = append(conditions, "date <= ?")
+		args = append(args, to)
+	}
+	if status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, status)
+	}
+
+	var _sql = fmt.Sprintf(`select %s from task`, sqliteTaskColumns)
+	if len(conditions) != 0 {
+		_sql += " where " + strings.Join(conditions, " and ")
+	}
+	_sql += ` order by date desc limit ?, ?`
+	args = append(args, offset, limit)
+
+	rows, err := s.db.QueryContext(ctx, _sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks = make([]*model.Task, 0, limit)
+	for rows.Next() {
+		task, err := sqliteScanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (s *SQLite) TaskWithSelectDueCallback(now time.Time, limit int64, timeout time.Duration) ([]*model.Task, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var _sql = fmt.Sprintf(`select %s from task where status = ? and (next_callback_timestamp is null or next_callback_timestamp <= ?) order by next_callback_timestamp asc, date asc limit ?`, sqliteTaskColumns)
+	rows, err := s.db.QueryContext(ctx, _sql, model.TaskCallbackPending, now.Format(sqliteTimestampLayout), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks = make([]*model.Task, 0, limit)
+	for rows.Next() {
+		task, err := sqliteScanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func sqliteScanTask(scanner interface{ Scan(...interface{}) error }) (*model.Task, error) {
+	var (
+		task                                                           = &model.Task{}
+		next, ingesting, verifying, callbackPending, completed, failed sql.NullString
+		createTimestamp                                                string
+		modifyTimestamp                                                sql.NullString
+	)
+	if err := scanner.Scan(
+		&task.Date,
+		&task.Status,
+		&task.LastError,
+		&task.MetadataCount,
+		&task.StockCount,
+		&task.DayCount,
+		&task.WeekCount,
+		&task.ActualStockCount,
+		&task.ActualDayCount,
+		&task.ActualWeekCount,
+		&task.Inconsistent,
+		&task.CallbackURL,
+		&task.CallbackMode,
+		&task.CallbackSecret,
+		&task.CallbackAttempts,
+		&next,
+		&ingesting,
+		&verifying,
+		&callbackPending,
+		&completed,
+		&failed,
+		&task.FailedStatus,
+		&createTimestamp,
+		&modifyTimestamp,
+	); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if task.CreateTimestamp, task.ModifyTimestamp, err = sqliteParseTimestamp(createTimestamp, modifyTimestamp); err != nil {
+		return nil, err
+	}
+	for _, t := range []struct {
+		dst *sql.NullTime
+		src sql.NullString
+	}{
+		{&task.NextCallbackTimestamp, next},
+		{&task.IngestingTimestamp, ingesting},
+		{&task.VerifyingTimestamp, verifying},
+		{&task.CallbackPendingTimestamp, callbackPending},
+		{&task.CompletedTimestamp, completed},
+		{&task.FailedTimestamp, failed},
+	} {
+		if *t.dst, err = sqliteParseNullTime(t.src); err != nil {
+			return nil, err
+		}
+	}
+	return task, nil
+}
+
+func (s *SQLite) TaskWithInsertOne(task *model.Task, timeout time.Duration) (int64, error) {
+	if task == nil {
+		return 0, nil
+	}
+
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var _sql = `insert into task(date, status, last_error, metadata_count, stock_count, day_count, week_count, callback_url, callback_mode, callback_secret, create_timestamp) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	result, err := s.db.ExecContext(ctx, _sql, task.Date, task.Status, task.LastError, task.MetadataCount, task.StockCount, task.DayCount, task.WeekCount, task.CallbackURL, task.CallbackMode, task.CallbackSecret, time.Now().Format(sqliteTimestampLayout))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (s *SQLite) TaskWithUpdateOne(date string, status string, task *model.Task, timeout time.Duration) (int64, error) {
+	if task == nil {
+		return 0, nil
+	}
+
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var _sql = `update task set status = ?, last_error = ?, metadata_count = ?, stock_count = ?, day_count = ?, week_count = ?, actual_stock_count = ?, actual_day_count = ?, actual_week_count = ?, inconsistent = ?, callback_url = ?, callback_mode = ?, callback_secret = ?, callback_attempts = ?, next_callback_timestamp = ?, ingesting_timestamp = ?, verifying_timestamp = ?, callback_pending_timestamp = ?, completed_timestamp = ?, failed_timestamp = ?, failed_status = ?, modify_timestamp = ? where date = ? and status = ?`
+	result, err := s.db.ExecContext(ctx, _sql,
+		task.Status,
+		task.LastError,
+		task.MetadataCount,
+		task.StockCount,
+		task.DayCount,
+		task.WeekCount,
+		task.ActualStockCount,
+		task.ActualDayCount,
+		task.ActualWeekCount,
+		task.Inconsistent,
+		task.CallbackURL,
+		task.CallbackMode,
+		task.CallbackSecret,
+		task.CallbackAttempts,
+		sqliteFormatNullTime(task.NextCallbackTimestamp),
+		sqliteFormatNullTime(task.IngestingTimestamp),
+		sqliteFormatNullTime(task.VerifyingTimestamp),
+		sqliteFormatNullTime(task.CallbackPendingTimestamp),
+		sqliteFormatNullTime(task.CompletedTimestamp),
+		sqliteFormatNullTime(task.FailedTimestamp),
+		task.FailedStatus,
+		time.Now().Format(sqliteTimestampLayout),
+		date,
+		status,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (s *SQLite) TaskCallbackAttemptWithInsertOne(attempt *model.TaskCallbackAttempt, timeout time.Duration) (int64, error) {
+	if attempt == nil {
+		return 0, nil
+	}
+
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var _sql = `insert into task_callback_attempt (date, attempt, url, status_code, body, latency, error, create_timestamp) values (?, ?, ?, ?, ?, ?, ?, ?)`
+	result, err := s.db.ExecContext(ctx, _sql, attempt.Date, attempt.Attempt, attempt.URL, attempt.StatusCode, attempt.Body, attempt.Latency, attempt.Error, time.Now().Format(sqliteTimestampLayout))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (s *SQLite) TaskCallbackAttemptWithSelectMany(date string, timeout time.Duration) ([]*model.TaskCallbackAttempt, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var _sql = `select id, date, attempt, url, status_code, body, latency, error, create_timestamp from task_callback_attempt where date = ? order by id asc`
+	rows, err := s.db.QueryContext(ctx, _sql, date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attempts = make([]*model.TaskCallbackAttempt, 0, 8)
+	for rows.Next() {
+		var (
+			attempt         = &model.TaskCallbackAttempt{}
+			createTimestamp string
+		)
+		if err := rows.Scan(
+			&attempt.Id,
+			&attempt.Date,
+			&attempt.Attempt,
+			&attempt.URL,
+			&attempt.StatusCode,
+			&attempt.Body,
+			&attempt.Latency,
+			&attempt.Error,
+			&createTimestamp,
+		); err != nil {
+			return nil, err
+		}
+		if attempt.CreateTimestamp, err = time.ParseInLocation(sqliteTimestampLayout, createTimestamp, time.Local); err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, attempt)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
+
+func (s *SQLite) Close() error {
+	return s.db.Close()
+}
+
+const sqliteQuoteColumns = "id, code, open, close, high, low, yesterday_closed, volume, account, date, num_of_year, xd, factor, create_timestamp, modify_timestamp"
+
+func sqliteQuoteWithSelect(exec mysql.Exec, _sql string, timeout time.Duration, args ...interface{}) ([]*model.Quote, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	rows, err := exec.QueryContext(ctx, _sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var data = make([]*model.Quote, 0, 8)
+	for rows.Next() {
+		var (
+			m               = &model.Quote{}
+			date            string
+			createTimestamp string
+			modifyTimestamp sql.NullString
+		)
+		if err := rows.Scan(
+			&m.Id,
+			&m.Code,
+			&m.Open,
+			&m.Close,
+			&m.High,
+			&m.Low,
+			&m.YesterdayClosed,
+			&m.Volume,
+			&m.Account,
+			&date,
+			&m.NumOfYear,
+			&m.Xd,
+			&m.Factor,
+			&createTimestamp,
+			&modifyTimestamp,
+		); err != nil {
+			return nil, err
+		}
+		if m.Date, err = time.ParseInLocation(sqliteDateLayout, date, time.Local); err != nil {
+			return nil, err
+		}
+		if m.CreateTimestamp, m.ModifyTimestamp, err = sqliteParseTimestamp(createTimestamp, modifyTimestamp); err != nil {
+			return nil, err
+		}
+		data = append(data, m)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func sqliteCorporateActionWithSelect(exec mysql.Exec, _sql string, timeout time.Duration, args ...interface{}) ([]*model.CorporateAction, error) {
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	rows, err := exec.QueryContext(ctx, _sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var actions = make([]*model.CorporateAction, 0, 4)
+	for rows.Next() {
+		var (
+			action          = &model.CorporateAction{}
+			date            string
+			createTimestamp string
+			modifyTimestamp sql.NullString
+		)
+		if err := rows.Scan(&action.Code, &date, &action.Kind, &action.Value, &action.Price, &createTimestamp, &modifyTimestamp); err != nil {
+			return nil, err
+		}
+		if action.Date, err = time.ParseInLocation(sqliteDateLayout, date, time.Local); err != nil {
+			return nil, err
+		}
+		if action.CreateTimestamp, action.ModifyTimestamp, err = sqliteParseTimestamp(createTimestamp, modifyTimestamp); err != nil {
+			return nil, err
+		}
+		actions = append(actions, action)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return actions, nil
+}
+
+func sqliteStockWithSelectMany(exec mysql.Exec, codes []string, timeout time.Duration) (map[string]*model.Stock, error) {
+	if len(codes) == 0 {
+		return map[string]*model.Stock{}, nil
+	}
+	ctx, cannel := context.WithTimeout(context.Background(), timeout)
+	defer cannel()
+
+	var fields = make([]string, 0, len(codes))
+	var args = make([]interface{}, 0, len(codes))
+	for _, code := range codes {
+		fields = append(fields, "?")
+		args = append(args, code)
+	}
+
+	var _sql = fmt.Sprintf(`select code, name, suspend, create_timestamp, modify_timestamp from stock where code in (%s)`, strings.Join(fields, ","))
+	rows, err := exec.QueryContext(ctx, _sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stocks = make(map[string]*model.Stock, len(codes))
+	for rows.Next() {
+		stock, err := sqliteScanStock(rows)
+		if err != nil {
+			return nil, err
+		}
+		stocks[stock.Code] = stock
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return stocks, nil
+}
+
+func sqliteScanStock(rows *sql.Rows) (*model.Stock, error) {
+	var (
+		stock           = &model.Stock{}
+		createTimestamp string
+		modifyTimestamp sql.NullString
+		err             error
+	)
+	if err = rows.Scan(&stock.Code, &stock.Name, &stock.Suspend, &createTimestamp, &modifyTimestamp); err != nil {
+		return nil, err
+	}
+	if stock.CreateTimestamp, stock.ModifyTimestamp, err = sqliteParseTimestamp(createTimestamp, modifyTimestamp); err != nil {
+		return nil, err
+	}
+	return stock, nil
+}
+
+func sqliteParseTimestamp(create string, modify sql.NullString) (time.Time, sql.NullTime, error) {
+	createTimestamp, err := time.ParseInLocation(sqliteTimestampLayout, create, time.Local)
+	if err != nil {
+		return time.Time{}, sql.NullTime{}, err
+	}
+	if !modify.Valid {
+		return createTimestamp, sql.NullTime{}, nil
+	}
+	modifyTimestamp, err := time.ParseInLocation(sqliteTimestampLayout, modify.String, time.Local)
+	if err != nil {
+		return time.Time{}, sql.NullTime{}, err
+	}
+	return createTimestamp, sql.NullTime{Time: modifyTimestamp, Valid: true}, nil
+}
+
+func sqliteParseNullTime(value sql.NullString) (sql.NullTime, error) {
+	if !value.Valid {
+		return sql.NullTime{}, nil
+	}
+	t, err := time.ParseInLocation(sqliteTimestampLayout, value.String, time.Local)
+	if err != nil {
+		return sql.NullTime{}, err
+	}
+	return sql.NullTime{Time: t, Valid: true}, nil
+}
+
+func sqliteFormatNullTime(value sql.NullTime) interface{} {
+	if !value.Valid {
+		return nil
+	}
+	return value.Time.Format(sqliteTimestampLayout)
+}
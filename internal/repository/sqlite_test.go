@@ -0,0 +1,726 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eviltomorrow/robber-repository/internal/migration"
+	"github.com/eviltomorrow/robber-repository/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+var timeout = 10 * time.Second
+
+func newSQLite(t *testing.T) *SQLite {
+	repo, err := NewSQLite(filepath.Join(t.TempDir(), "robber.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	return repo
+}
+
+func quoteOf(code string, date time.Time, close, xd float64) *model.Quote {
+	return &model.Quote{
+		Code:            code,
+		Open:            close,
+		Close:           close,
+		High:            close,
+		Low:             close,
+		YesterdayClosed: close,
+		Volume:          100,
+		Account:         close * 100,
+		Date:            date,
+		NumOfYear:       date.YearDay(),
+		Xd:              xd,
+		Factor:          xd,
+		CreateTimestamp: time.Now(),
+	}
+}
+
+func TestSQLiteTimeout(t *testing.T) {
+	_assert := assert.New(t)
+
+	// 多个连接并发执行，语句结束后立即 cancel 超时 context，不能中断同一连接上的后续语句
+	var wg sync.WaitGroup
+	for n := 0; n < 4; n++ {
+		repo := newSQLite(t)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 300; i++ {
+				var code = fmt.Sprintf("sz%06d", i)
+				if _, _, err := repo.StockWithInsertOrUpdateMany([]*model.Stock{{Code: code, Name: code, Suspend: model.SuspendNormal}}, timeout); !_assert.Nil(err, "insert %d", i) {
+					return
+				}
+				if _, err := repo.StockWithSelectMany([]string{code}, timeout); !_assert.Nil(err, "select %d", i) {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// 执行超时的语句仍会被中断
+	repo := newSQLite(t)
+	ctx, cannel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cannel()
+	_, err := repo.db.ExecContext(ctx, "with recursive r(n) as (select 1 union all select n + 1 from r) select count(1) from r")
+	_assert.NotNil(err)
+	_assert.Equal(context.DeadlineExceeded, ctx.Err())
+}
+
+func TestSQLiteStockWithInsertOrUpdateMany(t *testing.T) {
+	_assert := assert.New(t)
+	repo := newSQLite(t)
+
+	var stocks = []*model.Stock{
+		{Code: "sz000001", Name: "平安银行", Suspend: "正常"},
+		{Code: "sh601012", Name: "隆基股份", Suspend: "正常"},
+	}
+	inserted, updated, err := repo.StockWithInsertOrUpdateMany(stocks, timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(2), inserted)
+	_assert.Equal(int64(0), updated)
+
+	inserted, updated, err = repo.StockWithInsertOrUpdateMany(stocks, timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(0), inserted+updated)
+
+	// 同一批次内重复的 code 以最后一条为准
+	inserted, updated, err = repo.StockWithInsertOrUpdateMany([]*model.Stock{
+		{Code: "sz000002", Name: "万科", Suspend: "正常"},
+		{Code: "sz000001", Name: "平安银行XD", Suspend: "正常"},
+		{Code: "sz000002", Name: "万科A", Suspend: "正常"},
+	}, timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(1), inserted)
+	_assert.Equal(int64(1), updated)
+
+	data, err := repo.StockWithSelectMany([]string{"sz000001"}, timeout)
+	_assert.Nil(err)
+	_assert.Equal("平安银行XD", data["sz000001"].Name)
+	_assert.True(data["sz000001"].ModifyTimestamp.Valid)
+
+	all, err := repo.StockWithSelectRange(0, 10, timeout)
+	_assert.Nil(err)
+	_assert.Equal(3, len(all))
+
+	// 按 code 排序分页，与写入顺序无关
+	var codes = make([]string, 0, 3)
+	for offset := int64(0); offset < 3; offset += 2 {
+		page, err := repo.StockWithSelectRange(offset, 2, timeout)
+		_assert.Nil(err)
+		for _, stock := range page {
+			codes = append(codes, stock.Code)
+		}
+	}
+	_assert.Equal([]string{"sh601012", "sz000001", "sz000002"}, codes)
+}
+
+func TestSQLiteQuoteWithSelect(t *testing.T) {
+	_assert := assert.New(t)
+	repo := newSQLite(t)
+
+	var (
+		d1 = time.Date(2021, time.May, 10, 0, 0, 0, 0, time.Local)
+		d2 = d1.AddDate(0, 0, 1)
+		d3 = d1.AddDate(0, 0, 2)
+	)
+	inserted, updated, err := repo.QuoteWithInsertOrUpdateMany(model.Day, []*model.Quote{
+		quoteOf("sz000001", d1, 10.00, 1.0),
+		quoteOf("sz000001", d2, 10.00, 1.0),
+		quoteOf("sz000001", d3, 5.00, 0.5),
+	}, timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(3), inserted)
+	_assert.Equal(int64(0), updated)
+
+	stored, err := repo.QuoteWithSelectOneByCodeAndDate(model.Day, "sz000001", d3.Format("2006-01-02"), timeout)
+	_assert.Nil(err)
+
+	// 已存在的 code、date 原地更新，同一批次内重复的以最后一条为准
+	inserted, updated, err = repo.QuoteWithInsertOrUpdateMany(model.Day, []*model.Quote{
+		quoteOf("sz000001", d3, 4.00, 0.5),
+		quoteOf("sh601012", d3, 5.10, 1.0),
+		quoteOf("sz000001", d3, 5.00, 0.5),
+	}, timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(1), inserted)
+	_assert.Equal(int64(1), updated)
+
+	quote, err := repo.QuoteWithSelectOneByCodeAndDate(model.Day, "sz000001", d3.Format("2006-01-02"), timeout)
+	_assert.Nil(err)
+	_assert.Equal(stored.Id, quote.Id)
+	_assert.Equal(stored.CreateTimestamp, quote.CreateTimestamp)
+	_assert.True(quote.ModifyTimestamp.Valid)
+
+	latest, err := repo.QuoteWithSelectManyLatest(model.Day, "sz000001", d3.Format("2006-01-02"), 10, timeout)
+	_assert.Nil(err)
+	_assert.Equal(3, len(latest))
+	_assert.Equal(d3, latest[0].Date)
+	_assert.Equal(5.00, latest[0].Close)
+	_assert.Equal(10.00, latest[1].Close)
+
+	factors, err := repo.QuoteWithSelectManyFactorByCodes(model.Day, []string{"sz000001", "sz000002"}, timeout)
+	_assert.Nil(err)
+	_assert.Equal(1, len(factors))
+	_assert.Equal(0.5, factors["sz000001"])
+	adjusted := model.AdjustQuotes(latest, factors["sz000001"], model.AdjustForward)
+	_assert.Equal(5.00, adjusted[1].Close)
+	adjusted = model.AdjustQuotes(latest, factors["sz000001"], model.AdjustBackward)
+	_assert.Equal(10.00, adjusted[0].Close)
+	_assert.Equal(10.00, adjusted[1].Close)
+
+	affected, err := repo.QuoteWithUpdateFactorAfterDate(model.Day, "sz000001", d1.Format("2006-01-02"), 0.5, timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(2), affected)
+	factors, err = repo.QuoteWithSelectManyFactorByCodes(model.Day, []string{"sz000001"}, timeout)
+	_assert.Nil(err)
+	_assert.Equal(0.25, factors["sz000001"])
+
+	between, err := repo.QuoteWithSelectBetweenByCodeAndDate(model.Day, "sz000001", d1.Format("2006-01-02"), d3.Format("2006-01-02"), timeout)
+	_assert.Nil(err)
+	_assert.Equal(3, len(between))
+	_assert.Equal(d1, between[0].Date)
+	_assert.Equal(10.00, between[0].Close)
+
+	one, err := repo.QuoteWithSelectOneByCodeAndDate(model.Day, "sz000001", d2.Format("2006-01-02"), timeout)
+	_assert.Nil(err)
+	_assert.Equal(10.00, one.Close)
+
+	_, err = repo.QuoteWithSelectOneByCodeAndDate(model.Week, "sz000001", d2.Format("2006-01-02"), timeout)
+	_assert.Equal(sql.ErrNoRows, err)
+
+	data, err := repo.QuoteWithSelectRangeByDate(model.Day, d1.Format("2006-01-02"), 0, 10, timeout)
+	_assert.Nil(err)
+	_assert.Equal(1, len(data))
+}
+
+func TestSQLiteQuoteWithInsertOrUpdateManyAndFactor(t *testing.T) {
+	_assert := assert.New(t)
+	repo := newSQLite(t)
+
+	var (
+		d1 = time.Date(2021, time.May, 10, 0, 0, 0, 0, time.Local)
+		d2 = d1.AddDate(0, 0, 1)
+		d3 = d1.AddDate(0, 0, 2)
+	)
+	_, _, err := repo.QuoteWithInsertOrUpdateMany(model.Day, []*model.Quote{
+		quoteOf("sz000001", d2, 10.00, 1.0),
+		quoteOf("sz000001", d3, 10.00, 1.0),
+	}, timeout)
+	_assert.Nil(err)
+	stale, err := repo.QuoteWithSelectOneByCodeAndDate(model.Day, "sz000001", d3.Format("2006-01-02"), timeout)
+	_assert.Nil(err)
+
+	inserted, updated, err := repo.QuoteWithInsertOrUpdateManyAndFactor(model.Day, []*model.Quote{
+		quoteOf("sz000001", d1, 10.00, 0.5),
+	}, []int64{stale.Id}, d1.Format("2006-01-02"), map[string]float64{"sz000001": 0.5}, timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(1), inserted)
+	_assert.Equal(int64(0), updated)
+	quote, err := repo.QuoteWithSelectOneByCodeAndDate(model.Day, "sz000001", d2.Format("2006-01-02"), timeout)
+	_assert.Nil(err)
+	_assert.Equal(0.5, quote.Factor)
+	_, err = repo.QuoteWithSelectOneByCodeAndDate(model.Day, "sz000001", d3.Format("2006-01-02"), timeout)
+	_assert.Equal(sql.ErrNoRows, err)
+
+	// 修正累计复权因子失败时，删除及覆盖写入的数据一并回滚
+	_, err = repo.db.Exec("create trigger quote_day_factor before update of factor on quote_day begin select raise(abort, 'factor locked'); end")
+	_assert.Nil(err)
+	_, _, err = repo.QuoteWithInsertOrUpdateManyAndFactor(model.Day, []*model.Quote{
+		quoteOf("sz000001", d1, 8.00, 0.5),
+	}, []int64{quote.Id}, d1.Format("2006-01-02"), map[string]float64{"sz000001": 0.5}, timeout)
+	_assert.NotNil(err)
+	_, err = repo.QuoteWithSelectOneByCodeAndDate(model.Day, "sz000001", d2.Format("2006-01-02"), timeout)
+	_assert.Nil(err)
+	quote, err = repo.QuoteWithSelectOneByCodeAndDate(model.Day, "sz000001", d1.Format("2006-01-02"), timeout)
+	_assert.Nil(err)
+	_assert.Equal(10.00, quote.Close)
+	_assert.False(quote.ModifyTimestamp.Valid)
+}
+
+func TestSQLiteBatch(t *testing.T) {
+	_assert := assert.New(t)
+	repo := newSQLite(t)
+
+	affected, err := repo.BatchWithInsertOne(&model.Batch{Id: "20211217-0001", Status: model.BatchPending}, timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(1), affected)
+	affected, err = repo.BatchWithInsertOne(&model.Batch{Id: "20211217-0001", Status: model.BatchPending}, timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(0), affected)
+
+	// 仅删除 before 之前创建的 pending 批次
+	affected, err = repo.BatchWithDeleteOne("20211217-0001", time.Now().Add(-time.Hour), timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(0), affected)
+
+	affected, err = repo.BatchWithUpdateOne(&model.Batch{Id: "20211217-0001", Status: model.BatchDone, DayCount: 5, DayUpdated: 1}, timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(1), affected)
+	batch, err := repo.BatchWithSelectOne("20211217-0001", timeout)
+	_assert.Nil(err)
+	_assert.Equal(model.BatchDone, batch.Status)
+	_assert.Equal(int64(5), batch.DayCount)
+	_assert.Equal(int64(1), batch.DayUpdated)
+	_assert.True(batch.ModifyTimestamp.Valid)
+
+	// 已处理完成的批次不再更新或删除
+	affected, err = repo.BatchWithUpdateOne(&model.Batch{Id: "20211217-0001", Status: model.BatchDone}, timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(0), affected)
+	affected, err = repo.BatchWithDeleteOne("20211217-0001", time.Time{}, timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(0), affected)
+
+	_, err = repo.BatchWithInsertOne(&model.Batch{Id: "20211217-0002", Status: model.BatchPending}, timeout)
+	_assert.Nil(err)
+	affected, err = repo.BatchWithDeleteOne("20211217-0002", time.Time{}, timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(1), affected)
+	_, err = repo.BatchWithSelectOne("20211217-0002", timeout)
+	_assert.Equal(sql.ErrNoRows, err)
+}
+
+func TestSQLiteTask(t *testing.T) {
+	_assert := assert.New(t)
+	repo := newSQLite(t)
+
+	_, err := repo.TaskWithSelectOne("2021-12-21", timeout)
+	_assert.Equal(sql.ErrNoRows, err)
+
+	affected, err := repo.TaskWithInsertOne(&model.Task{Date: "2021-12-21", Status: model.TaskCreated, CallbackURL: "http://127.0.0.1"}, timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(1), affected)
+
+	_, err = repo.TaskWithInsertOne(&model.Task{Date: "2021-12-21"}, timeout)
+	_assert.NotNil(err)
+
+	var failed = time.Date(2021, time.December, 21, 15, 30, 0, 0, time.Local)
+	affected, err = repo.TaskWithUpdateOne("2021-12-21", model.TaskCreated, &model.Task{Status: model.TaskFailed, LastError: "callback failure", DayCount: 4500, ActualDayCount: 4499, Inconsistent: true, CallbackURL: "http://127.0.0.1", FailedTimestamp: sql.NullTime{Time: failed, Valid: true}, FailedStatus: model.TaskCallbackPending}, timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(1), affected)
+
+	// 状态已被修改
+	affected, err = repo.TaskWithUpdateOne("2021-12-21", model.TaskCreated, &model.Task{Status: model.TaskIngesting}, timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(0), affected)
+
+	task, err := repo.TaskWithSelectOne("2021-12-21", timeout)
+	_assert.Nil(err)
+	_assert.Equal(model.TaskFailed, task.Status)
+	_assert.Equal("callback failure", task.LastError)
+	_assert.Equal(int64(4500), task.DayCount)
+	_assert.Equal(int64(4499), task.ActualDayCount)
+	_assert.True(task.Inconsistent)
+	_assert.Equal(failed, task.FailedTimestamp.Time)
+	_assert.Equal(model.TaskCallbackPending, task.FailedStatus)
+	_assert.False(task.IngestingTimestamp.Valid)
+	_assert.True(task.ModifyTimestamp.Valid)
+
+	for _, date := range []string{"2021-12-20", "2021-12-22"} {
+		_, err = repo.TaskWithInsertOne(&model.Task{Date: date, Status: model.TaskCreated}, timeout)
+		_assert.Nil(err)
+	}
+	tasks, err := repo.TaskWithSelectRange("", "", "", 0, 10, timeout)
+	_assert.Nil(err)
+	if _assert.Equal(3, len(tasks)) {
+		_assert.Equal("2021-12-22", tasks[0].Date)
+		_assert.Equal("2021-12-20", tasks[2].Date)
+	}
+	tasks, err = repo.TaskWithSelectRange("2021-12-21", "2021-12-31", model.TaskFailed, 0, 10, timeout)
+	_assert.Nil(err)
+	if _assert.Equal(1, len(tasks)) {
+		_assert.Equal("2021-12-21", tasks[0].Date)
+		_assert.Equal(failed, tasks[0].FailedTimestamp.Time)
+	}
+	tasks, err = repo.TaskWithSelectRange("2021-12-20", "", model.TaskCreated, 1, 10, timeout)
+	_assert.Nil(err)
+	if _assert.Equal(1, len(tasks)) {
+		_assert.Equal("2021-12-20", tasks[0].Date)
+	}
+}
+
+func TestSQLiteTaskWithSelectDueCallback(t *testing.T) {
+	_assert := assert.New(t)
+	repo := newSQLite(t)
+
+	var now = time.Date(2021, time.December, 21, 15, 30, 0, 0, time.Local)
+	for date, next := range map[string]sql.NullTime{
+		"2021-12-17": {Time: now.Add(time.Hour), Valid: true},
+		"2021-12-18": {Time: now.Add(-time.Minute), Valid: true},
+		"2021-12-19": {},
+		"2021-12-20": {Time: now, Valid: true},
+	} {
+		_, err := repo.TaskWithInsertOne(&model.Task{Date: date, Status: model.TaskCreated}, timeout)
+		_assert.Nil(err)
+		_, err = repo.TaskWithUpdateOne(date, model.TaskCreated, &model.Task{Status: model.TaskCallbackPending, NextCallbackTimestamp: next}, timeout)
+		_assert.Nil(err)
+	}
+	_, err := repo.TaskWithInsertOne(&model.Task{Date: "2021-12-21", Status: model.TaskFailed}, timeout)
+	_assert.Nil(err)
+
+	tasks, err := repo.TaskWithSelectDueCallback(now, 10, timeout)
+	_assert.Nil(err)
+	var dates = make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		dates = append(dates, task.Date)
+	}
+	_assert.Equal([]string{"2021-12-19", "2021-12-18", "2021-12-20"}, dates)
+
+	tasks, err = repo.TaskWithSelectDueCallback(now, 1, timeout)
+	_assert.Nil(err)
+	if _assert.Equal(1, len(tasks)) {
+		_assert.Equal("2021-12-19", tasks[0].Date)
+	}
+}
+
+func TestSQLiteTaskCallbackAttempt(t *testing.T) {
+	_assert := assert.New(t)
+	repo := newSQLite(t)
+
+	_, err := repo.TaskWithInsertOne(&model.Task{Date: "2021-12-21", Status: model.TaskCreated, CallbackURL: "http://127.0.0.1", CallbackMode: model.CallbackPost, CallbackSecret: "secret"}, timeout)
+	_assert.Nil(err)
+
+	task, err := repo.TaskWithSelectOne("2021-12-21", timeout)
+	_assert.Nil(err)
+	_assert.Equal(model.CallbackPost, task.CallbackMode)
+	_assert.Equal("secret", task.CallbackSecret)
+
+	var next = time.Date(2021, time.December, 21, 15, 30, 0, 0, time.Local)
+	affected, err := repo.TaskWithUpdateOne("2021-12-21", model.TaskCreated, &model.Task{Status: model.TaskCallbackPending, CallbackURL: "http://127.0.0.1", CallbackMode: model.CallbackPost, CallbackSecret: "secret", CallbackAttempts: 2, NextCallbackTimestamp: sql.NullTime{Time: next, Valid: true}}, timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(1), affected)
+
+	task, err = repo.TaskWithSelectOne("2021-12-21", timeout)
+	_assert.Nil(err)
+	_assert.Equal(model.CallbackPost, task.CallbackMode)
+	_assert.Equal(int64(2), task.CallbackAttempts)
+	_assert.Equal(next, task.NextCallbackTimestamp.Time)
+
+	for i, code := range []int64{503, 200} {
+		affected, err = repo.TaskCallbackAttemptWithInsertOne(&model.TaskCallbackAttempt{Date: "2021-12-21", Attempt: int64(i + 1), URL: "http://127.0.0.1", StatusCode: code, Body: "ok", Latency: 12}, timeout)
+		_assert.Nil(err)
+		_assert.Equal(int64(1), affected)
+	}
+	_, err = repo.TaskCallbackAttemptWithInsertOne(&model.TaskCallbackAttempt{Date: "2021-12-22", Attempt: 1, URL: "http://127.0.0.1", Error: "connection refused"}, timeout)
+	_assert.Nil(err)
+
+	attempts, err := repo.TaskCallbackAttemptWithSelectMany("2021-12-21", timeout)
+	_assert.Nil(err)
+	if _assert.Equal(2, len(attempts)) {
+		_assert.Equal(int64(1), attempts[0].Attempt)
+		_assert.Equal(int64(503), attempts[0].StatusCode)
+		_assert.Equal(int64(200), attempts[1].StatusCode)
+		_assert.Equal(int64(12), attempts[1].Latency)
+		_assert.False(attempts[1].CreateTimestamp.IsZero())
+	}
+}
+
+func TestSQLiteQuoteWithSelectManyLatestByCodes(t *testing.T) {
+	_assert := assert.New(t)
+	repo := newSQLite(t)
+
+	var d1 = time.Date(2021, time.May, 10, 0, 0, 0, 0, time.Local)
+	var quotes []*model.Quote
+	for i := 0; i < 5; i++ {
+		quotes = append(quotes, quoteOf("sz000001", d1.AddDate(0, 0, i), 10.00+float64(i), 1.0))
+		quotes = append(quotes, quoteOf("sh601012", d1.AddDate(0, 0, i), 20.00+float64(i), 1.0))
+	}
+	_, _, err := repo.QuoteWithInsertOrUpdateMany(model.Day, quotes, timeout)
+	_assert.Nil(err)
+
+	data, err := repo.QuoteWithSelectManyLatestByCodes(model.Day, []string{"sz000001", "sh601012", "sz000002"}, d1.AddDate(0, 0, 3).Format("2006-01-02"), 2, timeout)
+	_assert.Nil(err)
+	_assert.Equal(2, len(data))
+	_assert.Equal(2, len(data["sz000001"]))
+	_assert.Equal(13.00, data["sz000001"][0].Close)
+	_assert.Equal(12.00, data["sz000001"][1].Close)
+	_assert.Equal(23.00, data["sh601012"][0].Close)
+	_assert.Equal(0, len(data["sz000002"]))
+
+	count, err := repo.QuoteWithCountByDate(model.Day, d1.Format("2006-01-02"), timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(2), count)
+	count, err = repo.QuoteWithCountByDate(model.Week, d1.Format("2006-01-02"), timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(0), count)
+}
+
+func TestSQLiteCorporateAction(t *testing.T) {
+	_assert := assert.New(t)
+	repo := newSQLite(t)
+
+	var date = time.Date(2021, time.December, 14, 0, 0, 0, 0, time.Local)
+	affected, err := repo.CorporateActionWithInsertOrUpdateMany([]*model.CorporateAction{
+		{Code: "sz000001", Date: date, Kind: model.ActionDividend, Value: 0.10},
+		{Code: "sz000001", Date: date, Kind: model.ActionRights, Value: 0.3, Price: 5.00},
+	}, timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(2), affected)
+
+	_, err = repo.CorporateActionWithInsertOrUpdateMany([]*model.CorporateAction{{Code: "sz000001", Date: date, Kind: model.ActionDividend, Value: 0.20}}, timeout)
+	_assert.Nil(err)
+
+	actions, err := repo.CorporateActionWithSelectMany("sz000001", "2021-12-01", "2021-12-31", timeout)
+	_assert.Nil(err)
+	_assert.Equal(2, len(actions))
+	_assert.Equal(model.ActionDividend, actions[0].Kind)
+	_assert.Equal(0.20, actions[0].Value)
+	_assert.True(actions[0].ModifyTimestamp.Valid)
+	_assert.Equal(date, actions[1].Date)
+	_assert.Equal(5.00, actions[1].Price)
+
+	_, err = repo.CorporateActionWithInsertOrUpdateMany([]*model.CorporateAction{{Code: "sz000002", Date: date.AddDate(0, 0, 1), Kind: model.ActionSplit, Value: 2}}, timeout)
+	_assert.Nil(err)
+	groups, err := repo.CorporateActionWithSelectManyByCodes([]string{"sz000001", "sz000002", "sz000003"}, "2021-12-01", "2021-12-31", timeout)
+	_assert.Nil(err)
+	_assert.Equal(2, len(groups))
+	_assert.Equal(2, len(groups["sz000001"]))
+	if _assert.Equal(1, len(groups["sz000002"])) {
+		_assert.Equal(model.ActionSplit, groups["sz000002"][0].Kind)
+	}
+}
+
+func TestSQLiteMetadataRejected(t *testing.T) {
+	_assert := assert.New(t)
+	repo := newSQLite(t)
+
+	_, err := repo.MetadataRejectedWithInsertOrUpdateMany([]*model.MetadataRejected{
+		{Code: "sz000001", Date: "2021-12-13", Reason: "save day failure", Metadata: `{"code":"sz000001"}`},
+		{Code: "sz000002", Date: "2021/12/13", Reason: "invalid date", Metadata: `{"code":"sz000002"}`},
+	}, timeout)
+	_assert.Nil(err)
+
+	_, err = repo.MetadataRejectedWithInsertOrUpdateMany([]*model.MetadataRejected{
+		{Code: "sz000001", Date: "2021-12-13", Reason: "build week failure", Metadata: `{"code":"sz000001"}`},
+	}, timeout)
+	_assert.Nil(err)
+
+	records, err := repo.MetadataRejectedWithSelectRange("", 0, 10, timeout)
+	_assert.Nil(err)
+	_assert.Equal(2, len(records))
+	_assert.Equal("build week failure", records[0].Reason)
+	_assert.True(records[0].ModifyTimestamp.Valid)
+
+	records, err = repo.MetadataRejectedWithSelectRange("sz000002", 0, 10, timeout)
+	_assert.Nil(err)
+	_assert.Equal(1, len(records))
+
+	record, err := repo.MetadataRejectedWithSelectOne(records[0].Id, timeout)
+	_assert.Nil(err)
+	_assert.Equal("2021/12/13", record.Date)
+
+	affected, err := repo.MetadataRejectedWithDeleteMany([]int64{record.Id}, timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(1), affected)
+	_, err = repo.MetadataRejectedWithSelectOne(record.Id, timeout)
+	_assert.Equal(sql.ErrNoRows, err)
+}
+
+func TestSQLiteMetadata(t *testing.T) {
+	_assert := assert.New(t)
+	repo := newSQLite(t)
+
+	var (
+		d1 = time.Date(2021, time.December, 13, 0, 0, 0, 0, time.Local)
+		d2 = time.Date(2021, time.December, 14, 0, 0, 0, 0, time.Local)
+	)
+	affected, err := repo.MetadataWithInsertMany([]*model.Metadata{
+		{Code: "sz000001", Name: "平安银行", Open: 10.001, Latest: 10.10, Volume: 1000, Date: d2, Time: "15:00:00", Suspend: "正常"},
+		{Code: "sz000001", Name: "平安银行", Open: 10.00, Latest: 10.05, Volume: 900, Date: d1, Time: "15:00:00", Suspend: "正常"},
+		{Code: "sz000002", Name: "万科A", Open: 20.00, Latest: 20.10, Volume: 500, Date: d1, Time: "15:00:00", Suspend: "正常"},
+	}, timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(3), affected)
+
+	// 重复提交同一 code、date、time 的原始数据时不追加
+	affected, err = repo.MetadataWithInsertMany([]*model.Metadata{
+		{Code: "sz000001", Name: "平安银行", Open: 10.001, Latest: 10.10, Volume: 1000, Date: d2, Time: "15:00:00", Suspend: "正常"},
+		{Code: "sz000001", Name: "平安银行", Open: 10.00, Latest: 10.08, Volume: 800, Date: d2, Time: "14:00:00", Suspend: "正常"},
+	}, timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(1), affected)
+
+	data, err := repo.MetadataWithSelectBetweenByCodeAndDate("sz000001", "2021-12-01", "2021-12-31", timeout)
+	_assert.Nil(err)
+	if _assert.Equal(3, len(data)) {
+		_assert.Equal(d1, data[0].Date)
+		_assert.Equal(d2, data[1].Date)
+		_assert.Equal(10.001, data[1].Open)
+		_assert.Equal("15:00:00", data[1].Time)
+		_assert.Equal("14:00:00", data[2].Time)
+	}
+}
+
+func TestSQLiteHoliday(t *testing.T) {
+	_assert := assert.New(t)
+	repo := newSQLite(t)
+
+	var (
+		d1 = time.Date(2022, time.January, 3, 0, 0, 0, 0, time.Local)
+		d2 = time.Date(2022, time.January, 31, 0, 0, 0, 0, time.Local)
+	)
+	_, err := repo.HolidayWithInsertOrUpdateMany([]*model.Holiday{{Date: d2, Name: "除夕"}, {Date: d1, Name: "元旦"}}, timeout)
+	_assert.Nil(err)
+	_, err = repo.HolidayWithInsertOrUpdateMany([]*model.Holiday{{Date: d2, Name: "春节"}}, timeout)
+	_assert.Nil(err)
+
+	holidays, err := repo.HolidayWithSelectMany("2022-01-01", "2022-12-31", timeout)
+	_assert.Nil(err)
+	if _assert.Equal(2, len(holidays)) {
+		_assert.Equal(d1, holidays[0].Date)
+		_assert.Equal(d2, holidays[1].Date)
+		_assert.Equal("春节", holidays[1].Name)
+	}
+
+	holidays, err = repo.HolidayWithSelectMany("2022-01-04", "2022-01-30", timeout)
+	_assert.Nil(err)
+	_assert.Equal(0, len(holidays))
+}
+
+func TestSQLiteStockSuspend(t *testing.T) {
+	_assert := assert.New(t)
+	repo := newSQLite(t)
+
+	var (
+		d1 = time.Date(2021, time.December, 13, 0, 0, 0, 0, time.Local)
+		d2 = time.Date(2021, time.December, 14, 0, 0, 0, 0, time.Local)
+	)
+	_, err := repo.StockSuspendWithInsertOrUpdateMany([]*model.StockSuspend{
+		{Code: "sz000002", Date: d1, Suspend: "停牌"},
+		{Code: "sz000001", Date: d1, Suspend: "停牌"},
+		{Code: "sh601012", Date: d1, Suspend: model.SuspendNormal},
+		{Code: "sz000001", Date: d2, Suspend: "停牌"},
+	}, timeout)
+	_assert.Nil(err)
+	_, err = repo.StockSuspendWithInsertOrUpdateMany([]*model.StockSuspend{{Code: "sz000001", Date: d2, Suspend: model.SuspendNormal}}, timeout)
+	_assert.Nil(err)
+
+	data, err := repo.StockSuspendWithSelectManyByDate("2021-12-13", timeout)
+	_assert.Nil(err)
+	if _assert.Equal(2, len(data)) {
+		_assert.Equal("sz000001", data[0].Code)
+		_assert.Equal(d1, data[0].Date)
+		_assert.Equal("sz000002", data[1].Code)
+	}
+
+	data, err = repo.StockSuspendWithSelectManyByDate("2021-12-14", timeout)
+	_assert.Nil(err)
+	_assert.Equal(0, len(data))
+
+	suspends, err := repo.StockSuspendWithSelectManyByCodesAndDate([]string{"sz000001", "sh601012", "sz000003"}, "2021-12-13", timeout)
+	_assert.Nil(err)
+	_assert.Equal(2, len(suspends))
+	_assert.Equal("停牌", suspends["sz000001"].Suspend)
+	_assert.Equal(model.SuspendNormal, suspends["sh601012"].Suspend)
+	suspends, err = repo.StockSuspendWithSelectManyByCodesAndDate([]string{"sz000001"}, "2021-12-14", timeout)
+	_assert.Nil(err)
+	_assert.Equal(model.SuspendNormal, suspends["sz000001"].Suspend)
+
+	count, err := repo.StockSuspendWithCountByDate("2021-12-13", timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(3), count)
+	count, err = repo.StockSuspendWithCountByDate("2021-12-15", timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(0), count)
+}
+
+func TestSQLiteStockSuspendMigration(t *testing.T) {
+	_assert := assert.New(t)
+	repo := newSQLite(t)
+
+	var (
+		d1 = time.Date(2021, time.December, 13, 0, 0, 0, 0, time.Local)
+		d2 = time.Date(2021, time.December, 14, 0, 0, 0, 0, time.Local)
+	)
+	_, _, err := repo.StockWithInsertOrUpdateMany([]*model.Stock{{Code: "sz000001", Name: "平安银行", Suspend: model.SuspendNormal}}, timeout)
+	_assert.Nil(err)
+	_, err = repo.MetadataWithInsertMany([]*model.Metadata{
+		{Code: "sz000001", Name: "平安银行", Date: d1, Time: "15:00:00", Suspend: model.SuspendNormal},
+		{Code: "sz000001", Name: "平安银行", Date: d2, Time: "10:00:00", Suspend: model.SuspendNormal},
+		{Code: "sz000001", Name: "平安银行", Date: d2, Time: "15:00:00", Suspend: "停牌"},
+	}, timeout)
+	_assert.Nil(err)
+
+	// 回退至 0017 后重新执行，0019 回填每日停牌状态及 stock 当前停牌状态
+	migrator, err := migration.New(repo.db, migration.DriverSQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = migrator.Down(int(migrator.Latest() - 17))
+	_assert.Nil(err)
+	_, err = migrator.Up()
+	_assert.Nil(err)
+
+	data, err := repo.StockSuspendWithSelectManyByDate("2021-12-14", timeout)
+	_assert.Nil(err)
+	_assert.Equal(1, len(data))
+	data, err = repo.StockSuspendWithSelectManyByDate("2021-12-13", timeout)
+	_assert.Nil(err)
+	_assert.Equal(0, len(data))
+
+	stocks, err := repo.StockWithSelectMany([]string{"sz000001"}, timeout)
+	_assert.Nil(err)
+	_assert.Equal("停牌", stocks["sz000001"].Suspend)
+}
+
+func TestSQLiteTaskStatusMigration(t *testing.T) {
+	_assert := assert.New(t)
+	repo := newSQLite(t)
+
+	migrator, err := migration.New(repo.db, migration.DriverSQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = migrator.Down(int(migrator.Latest() - 19))
+	_assert.Nil(err)
+	_, err = repo.db.Exec(`insert into task(date, completed, metadata_count, stock_count, day_count, week_count, callback_url, create_timestamp, modify_timestamp) values ('2021-12-17', 1, 5, 1, 5, 1, '', '2021-12-17 15:00:00', '2021-12-17 16:00:00'), ('2021-12-20', 0, 0, 0, 0, 0, '', '2021-12-20 15:00:00', null)`)
+	_assert.Nil(err)
+	_, err = migrator.Up()
+	_assert.Nil(err)
+
+	task, err := repo.TaskWithSelectOne("2021-12-17", timeout)
+	_assert.Nil(err)
+	_assert.Equal(model.TaskCompleted, task.Status)
+	_assert.Equal(time.Date(2021, time.December, 17, 16, 0, 0, 0, time.Local), task.CompletedTimestamp.Time)
+
+	task, err = repo.TaskWithSelectOne("2021-12-20", timeout)
+	_assert.Nil(err)
+	_assert.Equal(model.TaskCreated, task.Status)
+	_assert.False(task.CompletedTimestamp.Valid)
+}
+
+func TestSQLiteTaskFailedStatusMigration(t *testing.T) {
+	_assert := assert.New(t)
+	repo := newSQLite(t)
+
+	migrator, err := migration.New(repo.db, migration.DriverSQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = migrator.Down(2)
+	_assert.Nil(err)
+	// 2021-12-17 回调投递失败，2021-12-20 回调投递失败后重新校验失败，2021-12-21 校验失败
+	_, err = repo.db.Exec(`insert into task(date, status, metadata_count, stock_count, day_count, week_count, verifying_timestamp, callback_pending_timestamp, failed_timestamp, callback_url, create_timestamp) values
+		('2021-12-17', 'failed', 0, 0, 0, 0, '2021-12-17 15:00:00', '2021-12-17 15:01:00', '2021-12-17 16:00:00', '', '2021-12-17 14:00:00'),
+		('2021-12-20', 'failed', 0, 0, 0, 0, '2021-12-20 17:00:00', '2021-12-20 15:01:00', '2021-12-20 17:01:00', '', '2021-12-20 14:00:00'),
+		('2021-12-21', 'failed', 0, 0, 0, 0, '2021-12-21 15:00:00', null, '2021-12-21 15:01:00', '', '2021-12-21 14:00:00')`)
+	_assert.Nil(err)
+	_, err = migrator.Up()
+	_assert.Nil(err)
+
+	for date, status := range map[string]string{"2021-12-17": model.TaskCallbackPending, "2021-12-20": "", "2021-12-21": ""} {
+		task, err := repo.TaskWithSelectOne(date, timeout)
+		_assert.Nil(err)
+		_assert.Equal(status, task.FailedStatus, date)
+	}
+}
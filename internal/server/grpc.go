@@ -7,42 +7,78 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"strings"
 	"time"
 
 	"github.com/eviltomorrow/robber-core/pkg/grpclb"
-	"github.com/eviltomorrow/robber-core/pkg/httpclient"
-	"github.com/eviltomorrow/robber-core/pkg/mysql"
 	"github.com/eviltomorrow/robber-core/pkg/system"
 	"github.com/eviltomorrow/robber-core/pkg/zlog"
 	"github.com/eviltomorrow/robber-core/pkg/znet"
+	"github.com/eviltomorrow/robber-repository/internal/calendar"
 	"github.com/eviltomorrow/robber-repository/internal/middleware"
 	"github.com/eviltomorrow/robber-repository/internal/model"
+	"github.com/eviltomorrow/robber-repository/internal/repository"
 	"github.com/eviltomorrow/robber-repository/internal/service"
 	"github.com/eviltomorrow/robber-repository/pkg/pb"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
+// kindStock 写入数量统计中 stock 对应的类型
+const kindStock = "stock"
+
+// BatchIDKey PushData 批次 ID 对应的 gRPC metadata key，重复提交同一批次时直接返回首次处理结果
+const BatchIDKey = "x-batch-id"
+
+// batchExpire 超过该时间仍为 pending 的批次视为处理中断，重复提交时重新处理
+const batchExpire = 30 * time.Minute
+
+// maxQuoteRangeYears GetQuoteRange 查询区间的最大年数
+const maxQuoteRangeYears = 100
+
 var (
 	Host           = "0.0.0.0"
 	Port           = 27321
 	Endpoints      = []string{}
 	RevokeEtcdConn func() error
 	Key            = "grpclb/service/repository"
-	timeout        = 10 * time.Second
+	Repository     repository.Repository
+	// BatchSize PushData 每批写入的数据量，Parallelism 并发生成周期线的 goroutine 数量
+	BatchSize   = 500
+	Parallelism = 8
+	// CountTolerance Complete 上报数量与实际数量允许的相对误差
+	CountTolerance = 0.0
+	// CallbackMaxAttempts 每轮回调最多投递次数，CallbackBackoff 首次重试间隔，此后每次翻倍直至 CallbackMaxBackoff
+	CallbackMaxAttempts int64 = 5
+	CallbackBackoff           = 30 * time.Second
+	CallbackMaxBackoff        = 30 * time.Minute
+	CallbackTimeout           = 10 * time.Second
+	timeout                   = 10 * time.Second
 
-	server *grpc.Server
+	periods = []string{model.Week, model.Month, model.Quarter, model.Year}
+
+	server     *grpc.Server
+	dispatcher *service.CallbackDispatcher
 )
 
 type GRPC struct {
 	pb.UnimplementedServiceServer
+
+	Repository     repository.Repository
+	BatchSize      int
+	Parallelism    int
+	CountTolerance float64
+	// Dispatcher 后台投递 task 回调，为 nil 时 task 停留在 callback_pending
+	Dispatcher *service.CallbackDispatcher
 }
 
 // PushData(Service_PushDataServer) error
-// GetStockFull(*emptypb.Empty, Service_GetStockFullServer) error
+// GetStockFull(*StockRequest, Service_GetStockFullServer) error
 // GetQuoteLatest(*QuoteRequest, Service_GetQuoteLatestServer) error
 
 func (g *GRPC) Version(ctx context.Context, _ *emptypb.Empty) (*wrapperspb.StringValue, error) {
@@ -63,45 +99,49 @@ func (g *GRPC) Version(ctx context.Context, _ *emptypb.Empty) (*wrapperspb.Strin
 
 // CreateTask(context.Context, *Task) (*emptypb.Empty, error)
 // Complete(context.Context, *Task) (*emptypb.Empty, error)
+// RedeliverCallback(context.Context, *wrapperspb.StringValue) (*emptypb.Empty, error)
+// ListCallbackAttempts(*wrapperspb.StringValue, Service_ListCallbackAttemptsServer) error
+// GetTask(context.Context, *wrapperspb.StringValue) (*TaskInfo, error)
+// ListTasks(*TaskListRequest, Service_ListTasksServer) error
 // PushData(Service_PushDataServer) error
-// GetStockFull(*emptypb.Empty, Service_GetStockFullServer) error
+// GetStockFull(*StockRequest, Service_GetStockFullServer) error
+// GetSuspensions(*SuspensionRequest, Service_GetSuspensionsServer) error
 // GetQuoteLatest(*QuoteRequest, Service_GetQuoteLatestServer) error
+// GetQuoteRange(*QuoteRangeRequest, Service_GetQuoteRangeServer) error
+// GetQuoteLatestBatch(*QuoteBatchRequest, Service_GetQuoteLatestBatchServer) error
+// GetMarketSnapshot(*SnapshotRequest, Service_GetMarketSnapshotServer) error
+// PushCorporateAction(Service_PushCorporateActionServer) error
+// GetCorporateAction(*CorporateActionRequest, Service_GetCorporateActionServer) error
+// GetMetadataRange(*MetadataRangeRequest, Service_GetMetadataRangeServer) error
+// ListRejected(*RejectedRequest, Service_ListRejectedServer) error
+// GetRejected(context.Context, *wrapperspb.Int64Value) (*RejectedMetadata, error)
+// ReplayRejected(context.Context, *ReplayRequest) (*Count, error)
+// PushHoliday(Service_PushHolidayServer) error
+// GetHoliday(*HolidayRequest, Service_GetHolidayServer) error
 
 func (g *GRPC) CreateTask(ctx context.Context, req *pb.Task) (*emptypb.Empty, error) {
 	if req == nil {
 		return nil, fmt.Errorf("invalid parameter, task is nil")
 	}
 
-	tx, err := mysql.DB.Begin()
-	if err != nil {
-		return nil, nil
-	}
-	_, err = model.TaskWithSelectOne(tx, req.Date, timeout)
-	if err == nil {
-		tx.Rollback()
-		return nil, fmt.Errorf("exist same date[%v] task", req.Date)
-	}
-	if err != sql.ErrNoRows {
-		tx.Rollback()
-		return nil, err
-	}
-
-	if _, err := model.TaskWithInsertOne(tx, &model.Task{Date: req.Date, CallbackURL: req.CallbackUrl}, timeout); err != nil {
-		tx.Rollback()
-		return nil, err
+	var mode = model.CallbackGet
+	if req.CallbackMode == pb.Task_POST {
+		mode = model.CallbackPost
 	}
-	if err := tx.Commit(); err != nil {
+	if err := service.CreateTask(g.Repository, &model.Task{Date: req.Date, CallbackURL: req.CallbackUrl, CallbackMode: mode, CallbackSecret: req.CallbackSecret}, timeout); err != nil {
 		return nil, err
 	}
 	return &emptypb.Empty{}, nil
 }
 
+// Complete 依次将 task 流转到 verifying、callback_pending，回调由 Dispatcher 在后台投递
+// verifying 时统计 date 的实际数量，与上报数量不一致时仍继续回调，并在回调中标记不一致
 func (g *GRPC) Complete(ctx context.Context, req *pb.Task) (*emptypb.Empty, error) {
 	if req == nil {
 		return nil, fmt.Errorf("invalid parameter, tak is nil")
 	}
 
-	task, err := model.TaskWithSelectOne(mysql.DB, req.Date, timeout)
+	task, err := g.Repository.TaskWithSelectOne(req.Date, timeout)
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("not found task with date[%s]", req.Date)
 	}
@@ -112,174 +152,528 @@ func (g *GRPC) Complete(ctx context.Context, req *pb.Task) (*emptypb.Empty, erro
 		return nil, fmt.Errorf("panic: invalid task is nil")
 	}
 
-	resp, err := httpclient.GetHTTP(task.CallbackURL, timeout, nil)
-	if err != nil {
+	task.MetadataCount = req.MetadataCount
+	task.StockCount = req.StockCount
+	task.DayCount = req.DayCount
+	task.WeekCount = req.WeekCount
+	if err := service.TransitTask(g.Repository, task, model.TaskVerifying, timeout); err != nil {
 		return nil, err
 	}
-	zlog.Info("Callback success", zap.String("url", task.CallbackURL), zap.String("result", resp))
-
-	tx, err := mysql.DB.Begin()
-	if err != nil {
+	if err := service.VerifyTask(g.Repository, task, g.CountTolerance, timeout); err != nil {
+		zlog.Error("VerifyTask failure", zap.String("date", task.Date), zap.Error(err))
+		if e := service.FailTask(g.Repository, task, err, timeout); e != nil {
+			zlog.Error("FailTask failure", zap.String("date", task.Date), zap.Error(e))
+		}
 		return nil, err
 	}
-	_, err = model.TaskWithUpdateOne(tx, req.Date, &model.Task{
-		Completed:     1,
-		MetadataCount: req.MetadataCount,
-		StockCount:    req.StockCount,
-		DayCount:      req.DayCount,
-		WeekCount:     req.WeekCount,
-	}, timeout)
-	if err != nil {
-		tx.Rollback()
+	if task.Inconsistent {
+		zlog.Warn("Task count is inconsistent", zap.String("task", task.String()))
+	}
+	if err := service.TransitTask(g.Repository, task, model.TaskCallbackPending, timeout); err != nil {
 		return nil, err
 	}
-	if err := tx.Commit(); err != nil {
+	g.notifyDispatcher()
+	return &emptypb.Empty{}, nil
+}
+
+func (g *GRPC) RedeliverCallback(ctx context.Context, req *wrapperspb.StringValue) (*emptypb.Empty, error) {
+	if req == nil {
+		return nil, fmt.Errorf("invalid parameter, req is nil")
+	}
+
+	if _, err := service.RedeliverCallback(g.Repository, req.Value, timeout); err != nil {
 		return nil, err
 	}
+	g.notifyDispatcher()
 	return &emptypb.Empty{}, nil
 }
 
-func (g *GRPC) PushData(req pb.Service_PushDataServer) error {
-	var (
-		timeout = 20 * time.Second
-		size    = 50
-		stocks  = make([]*model.Stock, 0, size)
-		days    = make([]*model.Quote, 0, size)
-		weeks   = make([]*model.Quote, 0, size)
+func (g *GRPC) ListCallbackAttempts(req *wrapperspb.StringValue, resp pb.Service_ListCallbackAttemptsServer) error {
+	if req == nil {
+		return fmt.Errorf("invalid parameter, req is nil")
+	}
+
+	if _, err := g.Repository.TaskWithSelectOne(req.Value, timeout); err == sql.ErrNoRows {
+		return fmt.Errorf("not found task with date[%s]", req.Value)
+	} else if err != nil {
+		return err
+	}
+
+	attempts, err := g.Repository.TaskCallbackAttemptWithSelectMany(req.Value, timeout)
+	if err != nil {
+		return err
+	}
+	for _, attempt := range attempts {
+		if err := resp.Send(&pb.CallbackAttempt{
+			Id:              attempt.Id,
+			Date:            attempt.Date,
+			Attempt:         attempt.Attempt,
+			Url:             attempt.URL,
+			StatusCode:      attempt.StatusCode,
+			Body:            attempt.Body,
+			Latency:         attempt.Latency,
+			Error:           attempt.Error,
+			CreateTimestamp: attempt.CreateTimestamp.Format("2006-01-02 15:04:05"),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *GRPC) notifyDispatcher() {
+	if g.Dispatcher != nil {
+		g.Dispatcher.Notify()
+	}
+}
+
+func (g *GRPC) GetTask(ctx context.Context, req *wrapperspb.StringValue) (*pb.TaskInfo, error) {
+	if req == nil {
+		return nil, fmt.Errorf("invalid parameter, req is nil")
+	}
+
+	task, err := g.Repository.TaskWithSelectOne(req.Value, timeout)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("not found task with date[%s]", req.Value)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toTaskInfo(task), nil
+}
+
+func (g *GRPC) ListTasks(req *pb.TaskListRequest, resp pb.Service_ListTasksServer) error {
+	if req == nil {
+		return fmt.Errorf("invalid parameter, req is nil")
+	}
+	for _, date := range []string{req.From, req.To} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.ParseInLocation("2006-01-02", date, time.Local); err != nil {
+			return fmt.Errorf("invalid parameter, date[%s] must be formatted as 2006-01-02", date)
+		}
+	}
+	if req.State != "" && !service.IsTaskStatus(req.State) {
+		return fmt.Errorf("invalid parameter, state[%s] is not supported", req.State)
+	}
 
-		stockCount, dayCount, weekCount int64
-		cache                           = make([]*pb.Metadata, 0, size)
+	var (
+		offset = req.Offset
+		size   = int64(100)
 	)
 	for {
-		data, err := req.Recv()
-		if err == io.EOF {
+		var limit = size
+		if req.Limit > 0 {
+			if remain := req.Offset + req.Limit - offset; remain < limit {
+				limit = remain
+			}
+		}
+		if limit <= 0 {
 			break
 		}
+
+		tasks, err := g.Repository.TaskWithSelectRange(req.From, req.To, req.State, offset, limit, timeout)
 		if err != nil {
 			return err
 		}
+		for _, task := range tasks {
+			if err := resp.Send(toTaskInfo(task)); err != nil {
+				return err
+			}
+		}
 
-		stocks = append(stocks, &model.Stock{
-			Code:            data.Code,
-			Name:            data.Name,
-			Suspend:         data.Suspend,
-			CreateTimestamp: time.Now(),
-		})
-
-		cache = append(cache, data)
+		if int64(len(tasks)) < limit {
+			break
+		}
+		offset += int64(len(tasks))
+	}
+	return nil
+}
 
-		if len(cache) >= size {
-			for _, c := range cache {
-				t, err := time.ParseInLocation("2006-01-02", c.Date, time.Local)
-				if err != nil {
-					zlog.Error("ParseInLocation date failure", zap.String("data", c.String()), zap.Error(err))
-					continue
-				}
-				day, err := service.BuildQuoteDay(c, t)
-				if err != nil {
-					zlog.Error("BuildQuoteDay failure", zap.String("data", c.String()), zap.Error(err))
-				} else {
-					days = append(days, day)
+func (g *GRPC) PushData(req pb.Service_PushDataServer) error {
+	var batchID = batchIDOf(req.Context())
+	if batchID != "" {
+		batch, err := g.reserveBatch(batchID)
+		if err != nil {
+			return err
+		}
+		if batch != nil {
+			zlog.Info("Replay batch, skip data", zap.String("batch", batch.String()))
+			for {
+				if _, err := req.Recv(); err == io.EOF {
+					break
+				} else if err != nil {
+					return err
 				}
 			}
+			return req.SendAndClose(toCount(batch))
+		}
+	}
 
-			affected, err := service.SaveStocks(stocks, timeout)
-			if err != nil {
-				zlog.Error("SaveStocks failure", zap.Any("stocks", stocks), zap.Error(err))
-			}
-			stocks = stocks[:0]
-			stockCount += affected
+	// 交易日历在整个 PushData 内仅加载一次
+	cal, err := calendar.Load(g.Repository, timeout)
+	if err != nil {
+		g.releaseBatch(batchID)
+		return fmt.Errorf("load calendar failure, nest error: %v", err)
+	}
 
-			affected, err = service.SaveQuotes(days, model.Day, timeout)
-			if err != nil {
-				zlog.Error("SaveQuotes day failure", zap.Any("days", days), zap.Error(err))
-			}
-			days = days[:0]
-			dayCount += affected
-
-			for _, c := range cache {
-				t, err := time.ParseInLocation("2006-01-02", c.Date, time.Local)
-				if err != nil {
-					zlog.Error("ParseInLocation date failure", zap.String("data", c.String()), zap.Error(err))
-					continue
-				}
+	var (
+		timeout = 20 * time.Second
+		count   = &pb.Count{}
+		batches = make(chan []*pb.Metadata, 1)
+		recvErr error
+	)
+	// 接收与写入流水线执行，写入当前批次的同时接收下一批次
+	go func() {
+		defer close(batches)
 
-				if t.Weekday() == time.Friday {
-					week, err := service.BuildQuoteWeek(c.Code, t)
-					if err != nil {
-						zlog.Error("BuildQuoteWeek failure", zap.String("data", c.String()), zap.Error(err))
-					} else {
-						weeks = append(weeks, week)
-					}
-				}
+		var cache = make([]*pb.Metadata, 0, g.batchSize())
+		for {
+			data, err := req.Recv()
+			if err == io.EOF {
+				break
 			}
-
-			affected, err = service.SaveQuotes(weeks, model.Week, timeout)
 			if err != nil {
-				zlog.Error("SaveQuotes week failure", zap.Any("weeks", weeks), zap.Error(err))
+				recvErr = err
+				return
 			}
-			weeks = weeks[:0]
-			weekCount += affected
 
-			cache = cache[:0]
+			cache = append(cache, data)
+			if len(cache) >= g.batchSize() {
+				select {
+				case batches <- cache:
+				case <-req.Context().Done():
+					recvErr = req.Context().Err()
+					return
+				}
+				cache = make([]*pb.Metadata, 0, g.batchSize())
+			}
 		}
-	}
-
-	if len(cache) != 0 {
+		if len(cache) != 0 {
+			batches <- cache
+		}
+	}()
+	var ingesting = make(map[string]bool, 1)
+	for cache := range batches {
 		for _, c := range cache {
-			t, err := time.ParseInLocation("2006-01-02", c.Date, time.Local)
-			if err != nil {
-				zlog.Error("ParseInLocation date failure", zap.String("data", c.String()), zap.Error(err))
+			if ingesting[c.Date] {
 				continue
 			}
-			day, err := service.BuildQuoteDay(c, t)
-			if err != nil {
-				zlog.Error("BuildQuoteDay failure", zap.String("data", c.String()), zap.Error(err))
-			} else {
-				days = append(days, day)
+			ingesting[c.Date] = true
+			if err := service.IngestTask(g.Repository, c.Date, timeout); err != nil {
+				zlog.Error("IngestTask failure", zap.String("date", c.Date), zap.Error(err))
 			}
 		}
+		g.saveMetadata(cal, cache, count, timeout)
+	}
+	if recvErr != nil {
+		g.releaseBatch(batchID)
+		return recvErr
+	}
 
-		affected, err := service.SaveStocks(stocks, timeout)
+	// 存在未写入的数据时释放批次，重复提交同一批次会重新处理
+	if len(count.Rejected) != 0 {
+		g.releaseBatch(batchID)
+	} else if batchID != "" {
+		var batch = &model.Batch{
+			Id:           batchID,
+			Status:       model.BatchDone,
+			StockCount:   count.Stock,
+			DayCount:     count.Day,
+			WeekCount:    count.Week,
+			MonthCount:   count.Month,
+			QuarterCount: count.Quarter,
+			YearCount:    count.Year,
+		}
+		if updated := count.Updated; updated != nil {
+			batch.StockUpdated = updated.Stock
+			batch.DayUpdated = updated.Day
+			batch.WeekUpdated = updated.Week
+			batch.MonthUpdated = updated.Month
+			batch.QuarterUpdated = updated.Quarter
+			batch.YearUpdated = updated.Year
+		}
+		affected, err := g.Repository.BatchWithUpdateOne(batch, timeout)
 		if err != nil {
-			zlog.Error("SaveStocks failure", zap.Any("stocks", stocks), zap.Error(err))
+			return fmt.Errorf("save batch[%s] failure, nest error: %v", batchID, err)
 		}
-		stockCount += affected
+		if affected == 0 {
+			return fmt.Errorf("save batch[%s] failure, batch is no longer pending", batchID)
+		}
+	}
+	return req.SendAndClose(count)
+}
+
+// reserveBatch 以 pending 状态预占批次 ID，批次已处理完成时返回首次处理的结果，其他请求正在处理同一批次时返回错误
+func (g *GRPC) reserveBatch(id string) (*model.Batch, error) {
+	if _, err := g.Repository.BatchWithDeleteOne(id, time.Now().Add(-batchExpire), timeout); err != nil {
+		return nil, err
+	}
+	affected, err := g.Repository.BatchWithInsertOne(&model.Batch{Id: id, Status: model.BatchPending}, timeout)
+	if err != nil {
+		return nil, err
+	}
+	if affected != 0 {
+		return nil, nil
+	}
+
+	batch, err := g.Repository.BatchWithSelectOne(id, timeout)
+	if err != nil {
+		return nil, err
+	}
+	if batch.Status != model.BatchDone {
+		return nil, fmt.Errorf("batch[%s] is being processed by another request", id)
+	}
+	return batch, nil
+}
+
+// releaseBatch 释放预占的批次 ID，重复提交同一批次时重新处理
+func (g *GRPC) releaseBatch(id string) {
+	if id == "" {
+		return
+	}
+	if _, err := g.Repository.BatchWithDeleteOne(id, time.Time{}, timeout); err != nil {
+		zlog.Error("BatchWithDeleteOne failure", zap.String("batch", id), zap.Error(err))
+	}
+}
+
+// rejection 未能写入的 metadata 及原因
+type rejection struct {
+	data   *pb.Metadata
+	reason string
+}
 
-		affected, err = service.SaveQuotes(days, model.Day, timeout)
+// saveMetadata 归档一组 metadata 并写入对应的 stock、日线及周/月/季/年线，累加写入数量，cal 用于判断周期最后一个交易日
+// 未能写入的数据追加到 count.Rejected 并保存到 metadata_rejected 以便修复后重放，同时返回本次未能写入的数据
+func (g *GRPC) saveMetadata(cal *calendar.Calendar, cache []*pb.Metadata, count *pb.Count, timeout time.Duration) []*pb.Rejected {
+	var (
+		stocks   = make(map[string]*model.Stock, len(cache))
+		codes    = make([]string, 0, len(cache))
+		latest   = make(map[string]time.Time, len(cache))
+		suspends = make([]*model.StockSuspend, 0, len(cache))
+		days     = make([]*model.Quote, 0, len(cache))
+		valid    = make([]*pb.Metadata, 0, len(cache))
+		archive  = make([]*model.Metadata, 0, len(cache))
+		archived = make([]*pb.Metadata, 0, len(cache))
+		dates    = make([]time.Time, 0, len(cache))
+		rejected = make([]*rejection, 0, 4)
+	)
+	for _, c := range cache {
+		t, err := time.ParseInLocation("2006-01-02", c.Date, time.Local)
 		if err != nil {
-			zlog.Error("SaveQuotes day failure", zap.Any("days", days), zap.Error(err))
+			zlog.Error("ParseInLocation date failure", zap.String("data", c.String()), zap.Error(err))
+			rejected = append(rejected, &rejection{data: c, reason: fmt.Sprintf("invalid date: %v", err)})
+			continue
 		}
-		dayCount += affected
 
-		for _, c := range cache {
-			t, err := time.ParseInLocation("2006-01-02", c.Date, time.Local)
-			if err != nil {
-				zlog.Error("ParseInLocation date failure", zap.String("data", c.String()), zap.Error(err))
-				continue
+		// stock 的名称及当前停牌状态以批次内 date 最新的数据为准
+		if date, ok := latest[c.Code]; !ok || !t.Before(date) {
+			if !ok {
+				codes = append(codes, c.Code)
 			}
-
-			if t.Weekday() == time.Friday {
-				week, err := service.BuildQuoteWeek(c.Code, t)
-				if err != nil {
-					zlog.Error("BuildQuoteWeek failure", zap.String("data", c.String()), zap.Error(err))
-				} else {
-					weeks = append(weeks, week)
-				}
+			latest[c.Code] = t
+			stocks[c.Code] = &model.Stock{
+				Code:            c.Code,
+				Name:            c.Name,
+				Suspend:         c.Suspend,
+				CreateTimestamp: time.Now(),
 			}
 		}
-		affected, err = service.SaveQuotes(weeks, model.Week, timeout)
-		if err != nil {
-			zlog.Error("SaveQuotes week failure", zap.Any("weeks", weeks), zap.Error(err))
+		suspends = append(suspends, &model.StockSuspend{Code: c.Code, Date: t, Suspend: c.Suspend})
+		archive = append(archive, toMetadata(c, t))
+		archived = append(archived, c)
+		dates = append(dates, t)
+	}
+
+	quotes, errs := service.BuildQuoteDays(g.Repository, archived, dates)
+	for i, c := range archived {
+		if errs[i] != nil {
+			zlog.Error("BuildQuoteDay failure", zap.String("data", c.String()), zap.Error(errs[i]))
+			rejected = append(rejected, &rejection{data: c, reason: fmt.Sprintf("build day failure: %v", errs[i])})
+			continue
+		}
+		days = append(days, quotes[i])
+		valid = append(valid, c)
+	}
+
+	if _, err := g.Repository.MetadataWithInsertMany(archive, timeout); err != nil {
+		zlog.Error("MetadataWithInsertMany failure", zap.Any("metadata", archive), zap.Error(err))
+		for _, c := range archived {
+			rejected = append(rejected, &rejection{data: c, reason: fmt.Sprintf("archive metadata failure: %v", err)})
+		}
+	}
+
+	var data = make([]*model.Stock, 0, len(codes))
+	for _, code := range codes {
+		data = append(data, stocks[code])
+	}
+	inserted, updated, err := service.SaveStocks(g.Repository, data, timeout)
+	if err != nil {
+		zlog.Error("SaveStocks failure", zap.Any("stocks", data), zap.Error(err))
+		for _, c := range archived {
+			rejected = append(rejected, &rejection{data: c, reason: fmt.Sprintf("save stock failure: %v", err)})
+		}
+	}
+	addCount(count, kindStock, inserted, updated)
+
+	if _, err := g.Repository.StockSuspendWithInsertOrUpdateMany(suspends, timeout); err != nil {
+		zlog.Error("StockSuspendWithInsertOrUpdateMany failure", zap.Any("suspends", suspends), zap.Error(err))
+		for _, c := range archived {
+			rejected = append(rejected, &rejection{data: c, reason: fmt.Sprintf("save suspend failure: %v", err)})
+		}
+	}
+
+	inserted, updated, err = service.SaveQuotes(g.Repository, days, model.Day, timeout)
+	if err != nil {
+		zlog.Error("SaveQuotes day failure", zap.Any("days", days), zap.Error(err))
+		for _, c := range valid {
+			rejected = append(rejected, &rejection{data: c, reason: fmt.Sprintf("save day failure: %v", err)})
+		}
+	}
+	addCount(count, model.Day, inserted, updated)
+
+	for _, mode := range periods {
+		inserted, updated, r := g.savePeriod(cal, mode, valid, timeout)
+		rejected = append(rejected, r...)
+		addCount(count, mode, inserted, updated)
+	}
+
+	var result = g.saveRejected(rejected, timeout)
+	count.Rejected = append(count.Rejected, result...)
+	return result
+}
+
+// savePeriod 为周期最后一个交易日的数据并发生成并保存 mode 对应的周期线
+func (g *GRPC) savePeriod(cal *calendar.Calendar, mode string, cache []*pb.Metadata, timeout time.Duration) (int64, int64, []*rejection) {
+	var (
+		candidates = make([]*pb.Metadata, 0, len(cache))
+		codes      = make([]string, 0, len(cache))
+		dates      = make([]time.Time, 0, len(cache))
+	)
+	for _, c := range cache {
+		t, err := time.ParseInLocation("2006-01-02", c.Date, time.Local)
+		if err != nil || !cal.IsPeriodEnd(mode, t) {
+			continue
+		}
+		candidates = append(candidates, c)
+		codes = append(codes, c.Code)
+		dates = append(dates, t)
+	}
+
+	var (
+		quotes   = make([]*model.Quote, 0, len(candidates))
+		ends     = make([]*pb.Metadata, 0, len(candidates))
+		rejected = make([]*rejection, 0, 1)
+	)
+	built, errs := service.BuildQuotePeriods(g.Repository, mode, codes, dates, g.Parallelism)
+	for i, c := range candidates {
+		if errs[i] != nil {
+			zlog.Error("BuildQuotePeriod failure", zap.String("mode", mode), zap.String("data", c.String()), zap.Error(errs[i]))
+			rejected = append(rejected, &rejection{data: c, reason: fmt.Sprintf("build %s failure: %v", mode, errs[i])})
+			continue
+		}
+		quotes = append(quotes, built[i])
+		ends = append(ends, c)
+	}
+
+	inserted, updated, err := service.SaveQuotes(g.Repository, quotes, mode, timeout)
+	if err != nil {
+		zlog.Error("SaveQuotes period failure", zap.String("mode", mode), zap.Any("quotes", quotes), zap.Error(err))
+		for _, c := range ends {
+			rejected = append(rejected, &rejection{data: c, reason: fmt.Sprintf("save %s failure: %v", mode, err)})
+		}
+	}
+	return inserted, updated, rejected
+}
+
+// addCount 累加 kind 对应的新增及更新数量
+func addCount(count *pb.Count, kind string, inserted, updated int64) {
+	if count.Inserted == nil {
+		count.Inserted = &pb.Affected{}
+	}
+	if count.Updated == nil {
+		count.Updated = &pb.Affected{}
+	}
+
+	var total = inserted + updated
+	switch kind {
+	case kindStock:
+		count.Stock += total
+		count.Inserted.Stock += inserted
+		count.Updated.Stock += updated
+	case model.Day:
+		count.Day += total
+		count.Inserted.Day += inserted
+		count.Updated.Day += updated
+	case model.Week:
+		count.Week += total
+		count.Inserted.Week += inserted
+		count.Updated.Week += updated
+	case model.Month:
+		count.Month += total
+		count.Inserted.Month += inserted
+		count.Updated.Month += updated
+	case model.Quarter:
+		count.Quarter += total
+		count.Inserted.Quarter += inserted
+		count.Updated.Quarter += updated
+	case model.Year:
+		count.Year += total
+		count.Inserted.Year += inserted
+		count.Updated.Year += updated
+	}
+}
+
+// saveRejected 按 metadata 合并失败原因并保存到 metadata_rejected
+func (g *GRPC) saveRejected(rejected []*rejection, timeout time.Duration) []*pb.Rejected {
+	if len(rejected) == 0 {
+		return nil
+	}
+
+	var (
+		data    = make([]*pb.Metadata, 0, len(rejected))
+		reasons = make(map[*pb.Metadata][]string, len(rejected))
+	)
+	for _, r := range rejected {
+		if _, ok := reasons[r.data]; !ok {
+			data = append(data, r.data)
 		}
+		reasons[r.data] = append(reasons[r.data], r.reason)
+	}
 
-		weekCount += affected
+	var (
+		result  = make([]*pb.Rejected, 0, len(data))
+		records = make([]*model.MetadataRejected, 0, len(data))
+	)
+	for _, d := range data {
+		var reason = strings.Join(reasons[d], "; ")
+		result = append(result, &pb.Rejected{Code: d.Code, Date: d.Date, Reason: reason})
+
+		buf, err := protojson.Marshal(d)
+		if err != nil {
+			zlog.Error("Marshal metadata failure", zap.String("data", d.String()), zap.Error(err))
+			continue
+		}
+		records = append(records, &model.MetadataRejected{
+			Code:     d.Code,
+			Date:     d.Date,
+			Reason:   reason,
+			Metadata: string(buf),
+		})
 	}
 
-	return req.SendAndClose(&pb.Count{Stock: stockCount, Day: dayCount, Week: weekCount})
+	if _, err := g.Repository.MetadataRejectedWithInsertOrUpdateMany(records, timeout); err != nil {
+		zlog.Error("MetadataRejectedWithInsertOrUpdateMany failure", zap.Any("records", records), zap.Error(err))
+	}
+	return result
 }
 
-func (g *GRPC) GetStockFull(_ *emptypb.Empty, resp pb.Service_GetStockFullServer) error {
+func (g *GRPC) GetStockFull(req *pb.StockRequest, resp pb.Service_GetStockFullServer) error {
+	if req == nil {
+		return fmt.Errorf("invalid parameter, req is nil")
+	}
+
 	var (
 		offset  int64 = 0
 		limit   int64 = 100
@@ -287,12 +681,18 @@ func (g *GRPC) GetStockFull(_ *emptypb.Empty, resp pb.Service_GetStockFullServer
 	)
 
 	for {
-		stocks, err := model.StockWithSelectRange(mysql.DB, offset, limit, timeout)
+		stocks, err := g.Repository.StockWithSelectRange(offset, limit, timeout)
 		if err != nil {
 			return err
 		}
 
 		for _, stock := range stocks {
+			if req.Status == pb.StockRequest_NORMAL && model.IsSuspended(stock.Suspend) {
+				continue
+			}
+			if req.Status == pb.StockRequest_SUSPENDED && !model.IsSuspended(stock.Suspend) {
+				continue
+			}
 			if err := resp.Send(&pb.Stock{Code: stock.Code, Name: stock.Name, Suspend: stock.Suspend}); err != nil {
 				return err
 			}
@@ -306,70 +706,805 @@ func (g *GRPC) GetStockFull(_ *emptypb.Empty, resp pb.Service_GetStockFullServer
 	return nil
 }
 
+func (g *GRPC) GetSuspensions(req *pb.SuspensionRequest, resp pb.Service_GetSuspensionsServer) error {
+	var timeout = 10 * time.Second
+	if req == nil {
+		return fmt.Errorf("invalid parameter, req is nil")
+	}
+	if _, err := time.ParseInLocation("2006-01-02", req.Date, time.Local); err != nil {
+		return fmt.Errorf("invalid parameter, date[%s] must be formatted as 2006-01-02", req.Date)
+	}
+
+	suspends, err := g.Repository.StockSuspendWithSelectManyByDate(req.Date, timeout)
+	if err != nil {
+		return err
+	}
+
+	var codes = make([]string, 0, len(suspends))
+	for _, suspend := range suspends {
+		codes = append(codes, suspend.Code)
+	}
+	stocks, err := g.Repository.StockWithSelectMany(codes, timeout)
+	if err != nil {
+		return err
+	}
+
+	for _, suspend := range suspends {
+		var data = &pb.Stock{Code: suspend.Code, Suspend: suspend.Suspend}
+		if stock, ok := stocks[suspend.Code]; ok {
+			data.Name = stock.Name
+		}
+		if err := resp.Send(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (g *GRPC) GetQuoteLatest(req *pb.QuoteRequest, resp pb.Service_GetQuoteLatestServer) error {
 	var (
 		limit   int64 = req.Limit
-		mode    string
-		timeout = 10 * time.Second
+		mode          = modeOf(req.Mode)
+		timeout       = 10 * time.Second
 	)
 	if limit >= 250 {
 		return fmt.Errorf("limit must be less than 250")
 	}
 
-	switch req.Mode {
-	case pb.QuoteRequest_Day:
-		mode = model.Day
-	case pb.QuoteRequest_Week:
-		mode = model.Week
-	default:
-		mode = model.Day
+	quotes, err := g.Repository.QuoteWithSelectManyLatest(mode, req.Code, req.Date, limit, timeout)
+	if err != nil {
+		return err
 	}
-
-	quotes, err := model.QuoteWithSelectManyLatest(mysql.DB, mode, req.Code, req.Date, limit, timeout)
+	quotes, err = service.AdjustQuotes(g.Repository, mode, adjustOf(req.Adjust), quotes, timeout)
 	if err != nil {
 		return err
 	}
 
 	for _, quote := range quotes {
-		if err := resp.Send(&pb.Quote{
-			Code:            quote.Code,
-			Open:            quote.Open,
-			Close:           quote.Close,
-			High:            quote.High,
-			Low:             quote.Low,
-			YesterdayClosed: quote.YesterdayClosed,
-			Volume:          quote.Volume,
-			Account:         quote.Account,
-			Date:            quote.Date.Format("2006-01-02"),
-			NumOfYear:       int32(quote.NumOfYear),
-		}); err != nil {
+		if err := resp.Send(toQuote(quote)); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func StartupGRPC() error {
-	listen, err := net.Listen("tcp", fmt.Sprintf("%s:%d", Host, Port))
-	if err != nil {
-		return err
+func (g *GRPC) GetQuoteRange(req *pb.QuoteRangeRequest, resp pb.Service_GetQuoteRangeServer) error {
+	if req == nil {
+		return fmt.Errorf("invalid parameter, req is nil")
 	}
 
-	server = grpc.NewServer(
-		grpc.ChainUnaryInterceptor(
-			middleware.UnaryServerRecoveryInterceptor,
-			middleware.UnaryServerLogInterceptor,
-		),
-		grpc.ChainStreamInterceptor(
-			middleware.StreamServerRecoveryInterceptor,
-			middleware.StreamServerLogInterceptor,
-		),
-	)
-
-	reflection.Register(server)
-	pb.RegisterServiceServer(server, &GRPC{})
-
-	localIp, err := znet.GetLocalIP2()
+	var mode = modeOf(req.Mode)
+	begin, err := time.ParseInLocation("2006-01-02", req.Begin, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid parameter, begin[%s] must be formatted as 2006-01-02", req.Begin)
+	}
+	end, err := time.ParseInLocation("2006-01-02", req.End, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid parameter, end[%s] must be formatted as 2006-01-02", req.End)
+	}
+	if begin.After(end) {
+		return fmt.Errorf("invalid parameter, begin[%s] is after end[%s]", req.Begin, req.End)
+	}
+	if begin.AddDate(maxQuoteRangeYears, 0, 0).Before(end) {
+		return fmt.Errorf("invalid parameter, range[%s, %s] must be no more than %d years", req.Begin, req.End, maxQuoteRangeYears)
+	}
+
+	// 按自然年分段查询，每段使用独立的超时时间，避免长区间一次性加载
+	for from := begin; !from.After(end); from = from.AddDate(1, 0, 0) {
+		var to = from.AddDate(1, 0, -1)
+		if to.After(end) {
+			to = end
+		}
+
+		quotes, err := g.Repository.QuoteWithSelectBetweenByCodeAndDate(mode, req.Code, from.Format("2006-01-02"), to.Format("2006-01-02"), timeout)
+		if err != nil {
+			return err
+		}
+		quotes, err = service.AdjustQuotes(g.Repository, mode, adjustOf(req.Adjust), quotes, timeout)
+		if err != nil {
+			return err
+		}
+
+		for _, quote := range quotes {
+			if err := resp.Send(toQuote(quote)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (g *GRPC) GetQuoteLatestBatch(req *pb.QuoteBatchRequest, resp pb.Service_GetQuoteLatestBatchServer) error {
+	if req == nil {
+		return fmt.Errorf("invalid parameter, req is nil")
+	}
+
+	var (
+		size    int64 = 100
+		mode          = modeOf(req.Mode)
+		timeout       = 20 * time.Second
+	)
+	if req.Limit <= 0 || req.Limit >= 250 {
+		return fmt.Errorf("limit must be between 1 and 249")
+	}
+	if !req.All && len(req.Codes) == 0 {
+		return fmt.Errorf("invalid parameter, codes is nil")
+	}
+
+	var send = func(codes []string) error {
+		groups, err := g.Repository.QuoteWithSelectManyLatestByCodes(mode, codes, req.Date, req.Limit, timeout)
+		if err != nil {
+			return err
+		}
+		groups, err = service.AdjustQuoteGroups(g.Repository, mode, adjustOf(req.Adjust), groups, timeout)
+		if err != nil {
+			return err
+		}
+		for _, code := range codes {
+			var group = &pb.QuoteGroup{Code: code, Quotes: make([]*pb.Quote, 0, len(groups[code]))}
+			for _, quote := range groups[code] {
+				group.Quotes = append(group.Quotes, toQuote(quote))
+			}
+			if err := resp.Send(group); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if req.All {
+		var offset int64
+		for {
+			stocks, err := g.Repository.StockWithSelectRange(offset, size, timeout)
+			if err != nil {
+				return err
+			}
+
+			var codes = make([]string, 0, len(stocks))
+			for _, stock := range stocks {
+				codes = append(codes, stock.Code)
+			}
+			if err := send(codes); err != nil {
+				return err
+			}
+
+			if int64(len(stocks)) < size {
+				break
+			}
+			offset += size
+		}
+		return nil
+	}
+
+	var (
+		codes = make([]string, 0, len(req.Codes))
+		exist = make(map[string]struct{}, len(req.Codes))
+	)
+	for _, code := range req.Codes {
+		if _, ok := exist[code]; ok {
+			continue
+		}
+		exist[code] = struct{}{}
+		codes = append(codes, code)
+	}
+	for i := 0; i < len(codes); i += int(size) {
+		var end = i + int(size)
+		if end > len(codes) {
+			end = len(codes)
+		}
+		if err := send(codes[i:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *GRPC) GetMarketSnapshot(req *pb.SnapshotRequest, resp pb.Service_GetMarketSnapshotServer) error {
+	if req == nil {
+		return fmt.Errorf("invalid parameter, req is nil")
+	}
+	if _, err := time.ParseInLocation("2006-01-02", req.Date, time.Local); err != nil {
+		return fmt.Errorf("invalid parameter, date[%s] must be formatted as 2006-01-02", req.Date)
+	}
+
+	var (
+		offset  int64 = 0
+		limit   int64 = 100
+		mode          = modeOf(req.Period)
+		timeout       = 20 * time.Second
+	)
+	for {
+		quotes, err := g.Repository.QuoteWithSelectRangeByDate(mode, req.Date, offset, limit, timeout)
+		if err != nil {
+			return err
+		}
+		quotes, err = service.AdjustQuotes(g.Repository, mode, adjustOf(req.Adjust), quotes, timeout)
+		if err != nil {
+			return err
+		}
+
+		var codes = make([]string, 0, len(quotes))
+		for _, quote := range quotes {
+			codes = append(codes, quote.Code)
+		}
+		stocks, err := g.Repository.StockWithSelectMany(codes, timeout)
+		if err != nil {
+			return err
+		}
+		// 停牌状态取 date 当日的记录，stock 中仅为最新状态
+		suspends, err := g.Repository.StockSuspendWithSelectManyByCodesAndDate(codes, req.Date, timeout)
+		if err != nil {
+			return err
+		}
+
+		for _, quote := range quotes {
+			var snapshot = &pb.Snapshot{Code: quote.Code, Quote: toQuote(quote)}
+			if stock, ok := stocks[quote.Code]; ok {
+				snapshot.Name = stock.Name
+			}
+			if suspend, ok := suspends[quote.Code]; ok {
+				snapshot.Suspend = suspend.Suspend
+			}
+			if err := resp.Send(snapshot); err != nil {
+				return err
+			}
+		}
+
+		if int64(len(quotes)) < limit {
+			break
+		}
+		offset += limit
+	}
+	return nil
+}
+
+func (g *GRPC) PushCorporateAction(req pb.Service_PushCorporateActionServer) error {
+	var (
+		timeout = 20 * time.Second
+		actions = make([]*model.CorporateAction, 0, 16)
+	)
+	for {
+		data, err := req.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		date, err := time.ParseInLocation("2006-01-02", data.Date, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid parameter, date[%s] must be formatted as 2006-01-02", data.Date)
+		}
+		if data.Code == "" || data.Value <= 0 {
+			return fmt.Errorf("invalid parameter, corporate action[%s]", data.String())
+		}
+		actions = append(actions, &model.CorporateAction{
+			Code:            data.Code,
+			Date:            date,
+			Kind:            kindOf(data.Kind),
+			Value:           data.Value,
+			Price:           data.Price,
+			CreateTimestamp: time.Now(),
+		})
+	}
+
+	cal, err := calendar.Load(g.Repository, timeout)
+	if err != nil {
+		return fmt.Errorf("load calendar failure, nest error: %v", err)
+	}
+
+	var (
+		size  = 50
+		count int64
+	)
+	for i := 0; i < len(actions); i += size {
+		var end = i + size
+		if end > len(actions) {
+			end = len(actions)
+		}
+		if _, err := g.Repository.CorporateActionWithInsertOrUpdateMany(actions[i:end], timeout); err != nil {
+			return err
+		}
+		count += int64(end - i)
+	}
+
+	// 已入库的日线按公司行为重新计算复权比例，并重新汇总所在周期已入库的周期线
+	var exist = make(map[string]struct{}, len(actions))
+	for _, action := range actions {
+		var date = action.Date.Format("2006-01-02")
+		if _, ok := exist[action.Code+date]; ok {
+			continue
+		}
+		exist[action.Code+date] = struct{}{}
+
+		if _, err := service.RefreshQuoteXd(g.Repository, cal, action.Code, date, timeout); err != nil {
+			zlog.Error("RefreshQuoteXd failure", zap.String("action", action.String()), zap.Error(err))
+		}
+	}
+	return req.SendAndClose(&wrapperspb.Int64Value{Value: count})
+}
+
+func (g *GRPC) GetCorporateAction(req *pb.CorporateActionRequest, resp pb.Service_GetCorporateActionServer) error {
+	if req == nil {
+		return fmt.Errorf("invalid parameter, req is nil")
+	}
+
+	var (
+		begin = req.Begin
+		end   = req.End
+	)
+	if begin == "" {
+		begin = "0000-01-01"
+	}
+	if end == "" {
+		end = "9999-12-31"
+	}
+
+	actions, err := g.Repository.CorporateActionWithSelectMany(req.Code, begin, end, timeout)
+	if err != nil {
+		return err
+	}
+	for _, action := range actions {
+		if err := resp.Send(&pb.CorporateAction{
+			Code:  action.Code,
+			Date:  action.Date.Format("2006-01-02"),
+			Kind:  pbKindOf(action.Kind),
+			Value: action.Value,
+			Price: action.Price,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *GRPC) GetMetadataRange(req *pb.MetadataRangeRequest, resp pb.Service_GetMetadataRangeServer) error {
+	if req == nil {
+		return fmt.Errorf("invalid parameter, req is nil")
+	}
+	if req.Code == "" {
+		return fmt.Errorf("invalid parameter, code is empty")
+	}
+
+	var (
+		begin = req.Begin
+		end   = req.End
+	)
+	if begin == "" {
+		begin = "0000-01-01"
+	}
+	if end == "" {
+		end = "9999-12-31"
+	}
+
+	data, err := g.Repository.MetadataWithSelectBetweenByCodeAndDate(req.Code, begin, end, timeout)
+	if err != nil {
+		return err
+	}
+	for _, d := range data {
+		if err := resp.Send(&pb.Metadata{
+			Code:            d.Code,
+			Name:            d.Name,
+			Open:            d.Open,
+			YesterdayClosed: d.YesterdayClosed,
+			Latest:          d.Latest,
+			High:            d.High,
+			Low:             d.Low,
+			Volume:          d.Volume,
+			Account:         d.Account,
+			Date:            d.Date.Format("2006-01-02"),
+			Time:            d.Time,
+			Suspend:         d.Suspend,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *GRPC) ListRejected(req *pb.RejectedRequest, resp pb.Service_ListRejectedServer) error {
+	if req == nil {
+		return fmt.Errorf("invalid parameter, req is nil")
+	}
+
+	var (
+		offset = req.Offset
+		size   = int64(100)
+	)
+	for {
+		var limit = size
+		if req.Limit > 0 {
+			if remain := req.Offset + req.Limit - offset; remain < limit {
+				limit = remain
+			}
+		}
+		if limit <= 0 {
+			break
+		}
+
+		records, err := g.Repository.MetadataRejectedWithSelectRange(req.Code, offset, limit, timeout)
+		if err != nil {
+			return err
+		}
+		for _, record := range records {
+			data, err := toRejectedMetadata(record)
+			if err != nil {
+				return err
+			}
+			if err := resp.Send(data); err != nil {
+				return err
+			}
+		}
+
+		if int64(len(records)) < limit {
+			break
+		}
+		offset += int64(len(records))
+	}
+	return nil
+}
+
+func (g *GRPC) GetRejected(ctx context.Context, req *wrapperspb.Int64Value) (*pb.RejectedMetadata, error) {
+	if req == nil {
+		return nil, fmt.Errorf("invalid parameter, req is nil")
+	}
+
+	record, err := g.Repository.MetadataRejectedWithSelectOne(req.Value, timeout)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("not found rejected metadata with id[%d]", req.Value)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toRejectedMetadata(record)
+}
+
+// ReplayRejected 按正常写入流程重放 metadata_rejected 中的数据，写入成功的记录被删除，仍失败的记录更新原因
+func (g *GRPC) ReplayRejected(ctx context.Context, req *pb.ReplayRequest) (*pb.Count, error) {
+	if req == nil {
+		return nil, fmt.Errorf("invalid parameter, req is nil")
+	}
+
+	var (
+		timeout = 20 * time.Second
+		size    = g.batchSize()
+		records = make([]*model.MetadataRejected, 0, 64)
+	)
+	if req.All {
+		var offset int64
+		for {
+			data, err := g.Repository.MetadataRejectedWithSelectRange("", offset, 100, timeout)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, data...)
+			if len(data) < 100 {
+				break
+			}
+			offset += int64(len(data))
+		}
+	} else {
+		for _, id := range req.Ids {
+			record, err := g.Repository.MetadataRejectedWithSelectOne(id, timeout)
+			if err == sql.ErrNoRows {
+				return nil, fmt.Errorf("not found rejected metadata with id[%d]", id)
+			}
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, record)
+		}
+	}
+
+	cal, err := calendar.Load(g.Repository, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("load calendar failure, nest error: %v", err)
+	}
+
+	var count = &pb.Count{}
+	for i := 0; i < len(records); i += size {
+		var end = i + size
+		if end > len(records) {
+			end = len(records)
+		}
+
+		var (
+			cache = make([]*pb.Metadata, 0, end-i)
+			ids   = make(map[string]int64, end-i)
+		)
+		for _, record := range records[i:end] {
+			var data = &pb.Metadata{}
+			if err := protojson.Unmarshal([]byte(record.Metadata), data); err != nil {
+				return nil, fmt.Errorf("unmarshal rejected metadata[%d] failure, nest error: %v", record.Id, err)
+			}
+			cache = append(cache, data)
+			ids[record.Code+"|"+record.Date] = record.Id
+		}
+
+		for _, r := range g.saveMetadata(cal, cache, count, timeout) {
+			delete(ids, r.Code+"|"+r.Date)
+		}
+
+		var replayed = make([]int64, 0, len(ids))
+		for _, id := range ids {
+			replayed = append(replayed, id)
+		}
+		if _, err := g.Repository.MetadataRejectedWithDeleteMany(replayed, timeout); err != nil {
+			return nil, err
+		}
+	}
+	return count, nil
+}
+
+// PushHoliday 写入节假日，同一日期重复写入时更新名称
+func (g *GRPC) PushHoliday(req pb.Service_PushHolidayServer) error {
+	var holidays = make([]*model.Holiday, 0, 16)
+	for {
+		data, err := req.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		date, err := time.ParseInLocation("2006-01-02", data.Date, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid parameter, date[%s] must be formatted as 2006-01-02", data.Date)
+		}
+		holidays = append(holidays, &model.Holiday{Date: date, Name: data.Name})
+	}
+
+	var (
+		size  = 50
+		count int64
+	)
+	for i := 0; i < len(holidays); i += size {
+		var end = i + size
+		if end > len(holidays) {
+			end = len(holidays)
+		}
+		if _, err := g.Repository.HolidayWithInsertOrUpdateMany(holidays[i:end], timeout); err != nil {
+			return err
+		}
+		count += int64(end - i)
+	}
+	return req.SendAndClose(&wrapperspb.Int64Value{Value: count})
+}
+
+func (g *GRPC) GetHoliday(req *pb.HolidayRequest, resp pb.Service_GetHolidayServer) error {
+	if req == nil {
+		return fmt.Errorf("invalid parameter, req is nil")
+	}
+
+	var (
+		begin = req.Begin
+		end   = req.End
+	)
+	if begin == "" {
+		begin = "0000-01-01"
+	}
+	if end == "" {
+		end = "9999-12-31"
+	}
+
+	holidays, err := g.Repository.HolidayWithSelectMany(begin, end, timeout)
+	if err != nil {
+		return err
+	}
+	for _, holiday := range holidays {
+		if err := resp.Send(&pb.Holiday{
+			Date: holiday.Date.Format("2006-01-02"),
+			Name: holiday.Name,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *GRPC) batchSize() int {
+	if g.BatchSize <= 0 {
+		return 50
+	}
+	return g.BatchSize
+}
+
+// batchIDOf 从 gRPC metadata 中获取 PushData 批次 ID
+func batchIDOf(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(BatchIDKey); len(values) != 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func modeOf(mode pb.QuoteRequest_Mode) string {
+	switch mode {
+	case pb.QuoteRequest_Day:
+		return model.Day
+	case pb.QuoteRequest_Week:
+		return model.Week
+	case pb.QuoteRequest_Month:
+		return model.Month
+	case pb.QuoteRequest_Quarter:
+		return model.Quarter
+	case pb.QuoteRequest_Year:
+		return model.Year
+	default:
+		return model.Day
+	}
+}
+
+func adjustOf(adjust pb.Adjust) string {
+	switch adjust {
+	case pb.Adjust_NONE:
+		return model.AdjustNone
+	case pb.Adjust_BACKWARD:
+		return model.AdjustBackward
+	default:
+		return model.AdjustForward
+	}
+}
+
+func kindOf(kind pb.CorporateAction_Kind) string {
+	switch kind {
+	case pb.CorporateAction_Bonus:
+		return model.ActionBonus
+	case pb.CorporateAction_Split:
+		return model.ActionSplit
+	case pb.CorporateAction_Rights:
+		return model.ActionRights
+	default:
+		return model.ActionDividend
+	}
+}
+
+func pbKindOf(kind string) pb.CorporateAction_Kind {
+	switch kind {
+	case model.ActionBonus:
+		return pb.CorporateAction_Bonus
+	case model.ActionSplit:
+		return pb.CorporateAction_Split
+	case model.ActionRights:
+		return pb.CorporateAction_Rights
+	default:
+		return pb.CorporateAction_Dividend
+	}
+}
+
+func toTaskInfo(task *model.Task) *pb.TaskInfo {
+	var format = func(t sql.NullTime) string {
+		if !t.Valid {
+			return ""
+		}
+		return t.Time.Format("2006-01-02 15:04:05")
+	}
+	return &pb.TaskInfo{
+		Date:                     task.Date,
+		State:                    task.Status,
+		LastError:                task.LastError,
+		MetadataCount:            task.MetadataCount,
+		StockCount:               task.StockCount,
+		DayCount:                 task.DayCount,
+		WeekCount:                task.WeekCount,
+		CallbackUrl:              task.CallbackURL,
+		CallbackMode:             task.CallbackMode,
+		ActualStockCount:         task.ActualStockCount,
+		ActualDayCount:           task.ActualDayCount,
+		ActualWeekCount:          task.ActualWeekCount,
+		Inconsistent:             task.Inconsistent,
+		CreateTimestamp:          task.CreateTimestamp.Format("2006-01-02 15:04:05"),
+		IngestingTimestamp:       format(task.IngestingTimestamp),
+		VerifyingTimestamp:       format(task.VerifyingTimestamp),
+		CallbackPendingTimestamp: format(task.CallbackPendingTimestamp),
+		CompletedTimestamp:       format(task.CompletedTimestamp),
+		FailedTimestamp:          format(task.FailedTimestamp),
+		ModifyTimestamp:          format(task.ModifyTimestamp),
+		CallbackAttempts:         task.CallbackAttempts,
+		NextCallbackTimestamp:    format(task.NextCallbackTimestamp),
+	}
+}
+
+func toRejectedMetadata(record *model.MetadataRejected) (*pb.RejectedMetadata, error) {
+	var data = &pb.Metadata{}
+	if err := protojson.Unmarshal([]byte(record.Metadata), data); err != nil {
+		return nil, fmt.Errorf("unmarshal rejected metadata[%d] failure, nest error: %v", record.Id, err)
+	}
+
+	var modifyTimestamp string
+	if record.ModifyTimestamp.Valid {
+		modifyTimestamp = record.ModifyTimestamp.Time.Format("2006-01-02 15:04:05")
+	}
+	return &pb.RejectedMetadata{
+		Id:              record.Id,
+		Metadata:        data,
+		Reason:          record.Reason,
+		CreateTimestamp: record.CreateTimestamp.Format("2006-01-02 15:04:05"),
+		ModifyTimestamp: modifyTimestamp,
+	}, nil
+}
+
+func toMetadata(data *pb.Metadata, date time.Time) *model.Metadata {
+	return &model.Metadata{
+		Code:            data.Code,
+		Name:            data.Name,
+		Open:            data.Open,
+		YesterdayClosed: data.YesterdayClosed,
+		Latest:          data.Latest,
+		High:            data.High,
+		Low:             data.Low,
+		Volume:          data.Volume,
+		Account:         data.Account,
+		Date:            date,
+		Time:            data.Time,
+		Suspend:         data.Suspend,
+	}
+}
+
+// toCount 根据已处理批次的写入结果生成 Count
+func toCount(batch *model.Batch) *pb.Count {
+	return &pb.Count{
+		Stock:   batch.StockCount,
+		Day:     batch.DayCount,
+		Week:    batch.WeekCount,
+		Month:   batch.MonthCount,
+		Quarter: batch.QuarterCount,
+		Year:    batch.YearCount,
+		Inserted: &pb.Affected{
+			Stock:   batch.StockCount - batch.StockUpdated,
+			Day:     batch.DayCount - batch.DayUpdated,
+			Week:    batch.WeekCount - batch.WeekUpdated,
+			Month:   batch.MonthCount - batch.MonthUpdated,
+			Quarter: batch.QuarterCount - batch.QuarterUpdated,
+			Year:    batch.YearCount - batch.YearUpdated,
+		},
+		Updated: &pb.Affected{
+			Stock:   batch.StockUpdated,
+			Day:     batch.DayUpdated,
+			Week:    batch.WeekUpdated,
+			Month:   batch.MonthUpdated,
+			Quarter: batch.QuarterUpdated,
+			Year:    batch.YearUpdated,
+		},
+	}
+}
+
+func toQuote(quote *model.Quote) *pb.Quote {
+	return &pb.Quote{
+		Code:            quote.Code,
+		Open:            quote.Open,
+		Close:           quote.Close,
+		High:            quote.High,
+		Low:             quote.Low,
+		YesterdayClosed: quote.YesterdayClosed,
+		Volume:          quote.Volume,
+		Account:         quote.Account,
+		Date:            quote.Date.Format("2006-01-02"),
+		NumOfYear:       int32(quote.NumOfYear),
+	}
+}
+
+func StartupGRPC() error {
+	listen, err := net.Listen("tcp", fmt.Sprintf("%s:%d", Host, Port))
+	if err != nil {
+		return err
+	}
+
+	dispatcher = service.NewCallbackDispatcher(Repository)
+	dispatcher.MaxAttempts = CallbackMaxAttempts
+	dispatcher.Backoff = CallbackBackoff
+	dispatcher.MaxBackoff = CallbackMaxBackoff
+	dispatcher.Timeout = CallbackTimeout
+	dispatcher.Start()
+
+	server = NewServer(Repository, dispatcher)
+
+	localIp, err := znet.GetLocalIP2()
 	if err != nil {
 		return fmt.Errorf("get local ip failure, nest error: %v", err)
 	}
@@ -391,10 +1526,30 @@ func StartupGRPC() error {
 	return nil
 }
 
+// NewServer 创建注册了 GRPC 服务的 grpc.Server，dispatcher 由调用方启动及停止
+func NewServer(repo repository.Repository, dispatcher *service.CallbackDispatcher) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			middleware.UnaryServerRecoveryInterceptor,
+			middleware.UnaryServerLogInterceptor,
+		),
+		grpc.ChainStreamInterceptor(
+			middleware.StreamServerRecoveryInterceptor,
+			middleware.StreamServerLogInterceptor,
+		),
+	)
+
+	reflection.Register(server)
+	pb.RegisterServiceServer(server, &GRPC{Repository: repo, BatchSize: BatchSize, Parallelism: Parallelism, CountTolerance: CountTolerance, Dispatcher: dispatcher})
+	return server
+}
+
 func ShutdownGRPC() error {
-	if server == nil {
-		return nil
+	if server != nil {
+		server.Stop()
+	}
+	if dispatcher != nil {
+		return dispatcher.Stop()
 	}
-	server.Stop()
 	return nil
 }
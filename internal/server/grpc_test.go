@@ -0,0 +1,1491 @@
+package server_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/eviltomorrow/robber-repository/internal/model"
+	"github.com/eviltomorrow/robber-repository/internal/repository"
+	"github.com/eviltomorrow/robber-repository/internal/server"
+	"github.com/eviltomorrow/robber-repository/internal/service"
+	"github.com/eviltomorrow/robber-repository/internal/testutil"
+	"github.com/eviltomorrow/robber-repository/pkg/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var timeout = 10 * time.Second
+
+// week of 2021-12-13 (Monday) ~ 2021-12-17 (Friday)
+var week = []*pb.Metadata{
+	{Code: "sz000001", Name: "平安银行", Open: 10.00, YesterdayClosed: 9.90, Latest: 10.10, High: 10.20, Low: 9.95, Volume: 1000, Account: 10000, Date: "2021-12-13", Suspend: "正常"},
+	{Code: "sz000001", Name: "平安银行", Open: 10.10, YesterdayClosed: 10.10, Latest: 10.30, High: 10.50, Low: 10.00, Volume: 2000, Account: 20000, Date: "2021-12-14", Suspend: "正常"},
+	{Code: "sz000001", Name: "平安银行", Open: 10.30, YesterdayClosed: 10.30, Latest: 10.20, High: 10.40, Low: 10.10, Volume: 3000, Account: 30000, Date: "2021-12-15", Suspend: "正常"},
+	{Code: "sz000001", Name: "平安银行", Open: 10.20, YesterdayClosed: 10.20, Latest: 10.00, High: 10.25, Low: 9.80, Volume: 4000, Account: 40000, Date: "2021-12-16", Suspend: "正常"},
+	{Code: "sz000001", Name: "平安银行", Open: 10.00, YesterdayClosed: 10.00, Latest: 10.60, High: 10.80, Low: 9.90, Volume: 5000, Account: 50000, Date: "2021-12-17", Suspend: "正常"},
+}
+
+func pushData(t *testing.T, client pb.ServiceClient, data ...*pb.Metadata) *pb.Count {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	stream, err := client.PushData(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range data {
+		if err := stream.Send(d); err != nil {
+			t.Fatal(err)
+		}
+	}
+	count, err := stream.CloseAndRecv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return count
+}
+
+func getQuoteLatest(t *testing.T, client pb.ServiceClient, req *pb.QuoteRequest) []*pb.Quote {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	stream, err := client.GetQuoteLatest(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var quotes []*pb.Quote
+	for {
+		quote, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		quotes = append(quotes, quote)
+	}
+	return quotes
+}
+
+func TestPushDataWeek(t *testing.T) {
+	_assert := assert.New(t)
+	client, _, close, err := testutil.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	for i, d := range week {
+		count := pushData(t, client, d)
+		_assert.Equal(int64(1), count.Day)
+		if i == len(week)-1 {
+			_assert.Equal(int64(1), count.Week)
+		} else {
+			_assert.Equal(int64(0), count.Week)
+		}
+	}
+
+	weeks := getQuoteLatest(t, client, &pb.QuoteRequest{Code: "sz000001", Date: "2021-12-17", Limit: 10, Mode: pb.QuoteRequest_Week})
+	_assert.Equal(1, len(weeks))
+	_assert.Equal(10.00, weeks[0].Open)
+	_assert.Equal(10.60, weeks[0].Close)
+	_assert.Equal(10.80, weeks[0].High)
+	_assert.Equal(9.80, weeks[0].Low)
+	_assert.Equal(uint64(15000), weeks[0].Volume)
+
+	days := getQuoteLatest(t, client, &pb.QuoteRequest{Code: "sz000001", Date: "2021-12-17", Limit: 10, Mode: pb.QuoteRequest_Day})
+	_assert.Equal(5, len(days))
+	_assert.Equal("2021-12-17", days[0].Date)
+}
+
+func TestPushDataXd(t *testing.T) {
+	_assert := assert.New(t)
+	client, repo, close, err := testutil.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	pushData(t, client, week[0])
+	// 除权: 昨收由 10.10 调整为 5.05
+	var xd = &pb.Metadata{Code: "sz000001", Name: "平安银行", Open: 5.10, YesterdayClosed: 5.05, Latest: 5.20, High: 5.25, Low: 5.00, Volume: 2000, Account: 20000, Date: "2021-12-14", Suspend: "正常"}
+	pushData(t, client, xd)
+
+	quote, err := repo.QuoteWithSelectOneByCodeAndDate("day", "sz000001", "2021-12-14", timeout)
+	_assert.Nil(err)
+	_assert.Equal(0.5, quote.Xd)
+
+	days := getQuoteLatest(t, client, &pb.QuoteRequest{Code: "sz000001", Date: "2021-12-14", Limit: 10})
+	_assert.Equal(2, len(days))
+	_assert.Equal(5.20, days[0].Close)
+	_assert.Equal(5.05, days[1].Close)
+	_assert.Equal(5.00, days[1].Open)
+}
+
+func TestTaskComplete(t *testing.T) {
+	_assert := assert.New(t)
+	client, repo, close, err := testutil.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	var called int32
+	callback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("inconsistent") != "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		atomic.AddInt32(&called, 1)
+		w.Write([]byte("ok"))
+	}))
+	defer callback.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	_, err = client.CreateTask(ctx, &pb.Task{Date: "2021-12-17", CallbackUrl: callback.URL})
+	_assert.Nil(err)
+	_, err = client.CreateTask(ctx, &pb.Task{Date: "2021-12-17", CallbackUrl: callback.URL})
+	_assert.NotNil(err)
+
+	task, err := repo.TaskWithSelectOne("2021-12-17", timeout)
+	_assert.Nil(err)
+	_assert.Equal(model.TaskCreated, task.Status)
+
+	pushData(t, client, week[4])
+	task, err = repo.TaskWithSelectOne("2021-12-17", timeout)
+	_assert.Nil(err)
+	_assert.Equal(model.TaskIngesting, task.Status)
+	_assert.True(task.IngestingTimestamp.Valid)
+
+	_, err = client.Complete(ctx, &pb.Task{Date: "2021-12-17", MetadataCount: 1, StockCount: 1, DayCount: 1, WeekCount: 1})
+	_assert.Nil(err)
+
+	task = waitTask(t, repo, "2021-12-17", model.TaskCompleted)
+	_assert.Equal(model.TaskCompleted, task.Status)
+	_assert.Equal(int32(1), atomic.LoadInt32(&called))
+	_assert.Equal(int64(1), task.DayCount)
+	_assert.Equal(int64(1), task.ActualStockCount)
+	_assert.Equal(int64(1), task.ActualDayCount)
+	_assert.Equal(int64(1), task.ActualWeekCount)
+	_assert.False(task.Inconsistent)
+	_assert.Equal(int64(1), task.CallbackAttempts)
+	_assert.True(task.VerifyingTimestamp.Valid)
+	_assert.True(task.CallbackPendingTimestamp.Valid)
+	_assert.True(task.CompletedTimestamp.Valid)
+
+	attempts := listCallbackAttempts(t, client, "2021-12-17")
+	if _assert.Equal(1, len(attempts)) {
+		_assert.Equal(int64(http.StatusOK), attempts[0].StatusCode)
+		_assert.Equal("ok", attempts[0].Body)
+		_assert.Equal(callback.URL, attempts[0].Url)
+		_assert.Empty(attempts[0].Error)
+	}
+
+	// completed 为终态
+	_, err = client.Complete(ctx, &pb.Task{Date: "2021-12-17"})
+	_assert.NotNil(err)
+	_, err = client.RedeliverCallback(ctx, &wrapperspb.StringValue{Value: "2021-12-17"})
+	_assert.NotNil(err)
+
+	_, err = client.Complete(ctx, &pb.Task{Date: "2021-12-18"})
+	_assert.NotNil(err)
+}
+
+func TestTaskCallbackRetry(t *testing.T) {
+	_assert := assert.New(t)
+	client, repo, close, err := testutil.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	// 首次投递时下游不可用，重试后成功
+	var called int32
+	callback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&called, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer callback.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	_, err = client.CreateTask(ctx, &pb.Task{Date: "2021-12-17", CallbackUrl: callback.URL})
+	_assert.Nil(err)
+	_, err = client.Complete(ctx, &pb.Task{Date: "2021-12-17", DayCount: 5})
+	_assert.Nil(err)
+
+	task := waitTask(t, repo, "2021-12-17", model.TaskCompleted)
+	_assert.Equal(model.TaskCompleted, task.Status)
+	_assert.Equal(int64(2), task.CallbackAttempts)
+	_assert.Equal("", task.LastError)
+	_assert.False(task.FailedTimestamp.Valid)
+
+	attempts := listCallbackAttempts(t, client, "2021-12-17")
+	if _assert.Equal(2, len(attempts)) {
+		_assert.Equal(int64(http.StatusServiceUnavailable), attempts[0].StatusCode)
+		_assert.Contains(attempts[0].Error, "503")
+		_assert.Equal(int64(2), attempts[1].Attempt)
+		_assert.Equal(int64(http.StatusOK), attempts[1].StatusCode)
+	}
+}
+
+func TestTaskCallbackFailure(t *testing.T) {
+	_assert := assert.New(t)
+	client, repo, close, err := testutil.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	var unavailable int32 = 1
+	callback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&unavailable) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer callback.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	_, err = client.CreateTask(ctx, &pb.Task{Date: "2021-12-17", CallbackUrl: callback.URL})
+	_assert.Nil(err)
+
+	// 未写入数据时由 created 直接进入 verifying，回调失败不影响 Complete 返回
+	_, err = client.Complete(ctx, &pb.Task{Date: "2021-12-17", DayCount: 5})
+	_assert.Nil(err)
+
+	// 达到最大投递次数后流转到 failed
+	task := waitTask(t, repo, "2021-12-17", model.TaskFailed)
+	_assert.Equal(model.TaskFailed, task.Status)
+	_assert.Contains(task.LastError, "callback failure")
+	_assert.Equal(int64(3), task.CallbackAttempts)
+	_assert.True(task.FailedTimestamp.Valid)
+	_assert.False(task.IngestingTimestamp.Valid)
+	_assert.Equal(3, len(listCallbackAttempts(t, client, "2021-12-17")))
+
+	atomic.StoreInt32(&unavailable, 0)
+	_, err = client.RedeliverCallback(ctx, &wrapperspb.StringValue{Value: "2021-12-17"})
+	_assert.Nil(err)
+
+	task = waitTask(t, repo, "2021-12-17", model.TaskCompleted)
+	_assert.Equal(model.TaskCompleted, task.Status)
+	_assert.Equal("", task.LastError)
+	_assert.Equal(int64(1), task.CallbackAttempts)
+
+	attempts := listCallbackAttempts(t, client, "2021-12-17")
+	if _assert.Equal(4, len(attempts)) {
+		_assert.Equal(int64(1), attempts[3].Attempt)
+		_assert.Equal(int64(http.StatusOK), attempts[3].StatusCode)
+	}
+
+	_, err = client.RedeliverCallback(ctx, &wrapperspb.StringValue{Value: "2021-12-18"})
+	_assert.NotNil(err)
+}
+
+func TestTaskCallbackPost(t *testing.T) {
+	_assert := assert.New(t)
+	client, repo, close, err := testutil.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	var received = make(chan *service.CallbackPayload, 1)
+	callback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil || r.Method != http.MethodPost || r.Header.Get(service.CallbackSignatureHeader) != service.SignCallback("secret", body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var payload = &service.CallbackPayload{}
+		if err := json.Unmarshal(body, payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- payload
+		w.Write([]byte("ok"))
+	}))
+	defer callback.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// post 回调必须指定签名密钥
+	_, err = client.CreateTask(ctx, &pb.Task{Date: "2021-12-17", CallbackUrl: callback.URL, CallbackMode: pb.Task_POST})
+	_assert.NotNil(err)
+
+	_, err = client.CreateTask(ctx, &pb.Task{Date: "2021-12-17", CallbackUrl: callback.URL, CallbackMode: pb.Task_POST, CallbackSecret: "secret"})
+	_assert.Nil(err)
+	pushData(t, client, week[4])
+	_, err = client.Complete(ctx, &pb.Task{Date: "2021-12-17", MetadataCount: 5, StockCount: 1, DayCount: 5, WeekCount: 1})
+	_assert.Nil(err)
+
+	task := waitTask(t, repo, "2021-12-17", model.TaskCompleted)
+	_assert.Equal(model.TaskCompleted, task.Status)
+	select {
+	case payload := <-received:
+		_assert.Equal("2021-12-17", payload.Date)
+		_assert.Equal(model.TaskCompleted, payload.State)
+		_assert.Equal(int64(5), payload.MetadataCount)
+		_assert.Equal(int64(1), payload.StockCount)
+		_assert.Equal(int64(5), payload.DayCount)
+		_assert.Equal(int64(1), payload.WeekCount)
+		// 仅写入了 1 条日线，上报的 day 数量不一致
+		_assert.True(payload.Inconsistent)
+		_assert.Equal(int64(1), payload.ActualStockCount)
+		_assert.Equal(int64(1), payload.ActualDayCount)
+		_assert.Equal(int64(1), payload.ActualWeekCount)
+		_assert.True(payload.Duration >= 0)
+		_assert.NotZero(payload.Timestamp)
+	default:
+		t.Fatal("callback payload not received")
+	}
+
+	info, err := client.GetTask(ctx, &wrapperspb.StringValue{Value: "2021-12-17"})
+	_assert.Nil(err)
+	_assert.Equal(model.CallbackPost, info.CallbackMode)
+	_assert.True(info.Inconsistent)
+	_assert.Equal(int64(5), info.DayCount)
+	_assert.Equal(int64(1), info.ActualDayCount)
+}
+
+func TestTaskInconsistent(t *testing.T) {
+	_assert := assert.New(t)
+	client, repo, close, err := testutil.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	var query = make(chan string, 1)
+	callback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query <- r.URL.RawQuery
+		w.Write([]byte("ok"))
+	}))
+	defer callback.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	_, err = client.CreateTask(ctx, &pb.Task{Date: "2021-12-17", CallbackUrl: callback.URL + "?source=robber"})
+	_assert.Nil(err)
+	pushData(t, client, week[4])
+	_, err = client.Complete(ctx, &pb.Task{Date: "2021-12-17", StockCount: 1, DayCount: 1})
+	_assert.Nil(err)
+
+	// 周五生成了周线，上报的 week 数量为 0
+	task := waitTask(t, repo, "2021-12-17", model.TaskCompleted)
+	_assert.Equal(model.TaskCompleted, task.Status)
+	_assert.True(task.Inconsistent)
+	_assert.Equal(int64(0), task.WeekCount)
+	_assert.Equal(int64(1), task.ActualWeekCount)
+	select {
+	case q := <-query:
+		_assert.Contains(q, "inconsistent=true")
+		_assert.Contains(q, "source=robber")
+	default:
+		t.Fatal("callback not received")
+	}
+}
+
+// waitTask 等待后台投递回调后 task 流转到 status，超时后返回最近一次查询的 task
+func waitTask(t *testing.T, repo repository.Repository, date string, status string) *model.Task {
+	var deadline = time.Now().Add(timeout)
+	for {
+		task, err := repo.TaskWithSelectOne(date, timeout)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if task.Status == status || time.Now().After(deadline) {
+			return task
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func listCallbackAttempts(t *testing.T, client pb.ServiceClient, date string) []*pb.CallbackAttempt {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	stream, err := client.ListCallbackAttempts(ctx, &wrapperspb.StringValue{Value: date})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var attempts []*pb.CallbackAttempt
+	for {
+		attempt, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		attempts = append(attempts, attempt)
+	}
+	return attempts
+}
+
+func TestGetStockFull(t *testing.T) {
+	_assert := assert.New(t)
+	client, _, close, err := testutil.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	getStockFull := func(status pb.StockRequest_Status) []*pb.Stock {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		stream, err := client.GetStockFull(ctx, &pb.StockRequest{Status: status})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var stocks []*pb.Stock
+		for {
+			stock, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			_assert.Nil(err)
+			stocks = append(stocks, stock)
+		}
+		return stocks
+	}
+
+	count := pushData(t, client, week[0])
+	_assert.Equal(int64(1), count.Stock)
+
+	stocks := getStockFull(pb.StockRequest_ALL)
+	_assert.Equal(1, len(stocks))
+	_assert.Equal("平安银行", stocks[0].Name)
+
+	// 停牌状态变化时更新 stock
+	var suspended = proto.Clone(week[1]).(*pb.Metadata)
+	suspended.Suspend = "停牌"
+	count = pushData(t, client, suspended, &pb.Metadata{Code: "sh601012", Name: "隆基股份", Open: 40.00, YesterdayClosed: 40.00, Latest: 40.50, High: 41.00, Low: 39.80, Volume: 1000, Account: 40000, Date: "2021-12-14", Suspend: "正常"})
+	_assert.Equal(int64(1), count.Inserted.Stock)
+	_assert.Equal(int64(1), count.Updated.Stock)
+
+	stocks = getStockFull(pb.StockRequest_SUSPENDED)
+	if _assert.Equal(1, len(stocks)) {
+		_assert.Equal("sz000001", stocks[0].Code)
+		_assert.Equal("停牌", stocks[0].Suspend)
+	}
+	stocks = getStockFull(pb.StockRequest_NORMAL)
+	if _assert.Equal(1, len(stocks)) {
+		_assert.Equal("sh601012", stocks[0].Code)
+	}
+	_assert.Equal(2, len(getStockFull(pb.StockRequest_ALL)))
+}
+
+func TestGetSuspensions(t *testing.T) {
+	_assert := assert.New(t)
+	client, _, close, err := testutil.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	getSuspensions := func(date string) ([]*pb.Stock, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		stream, err := client.GetSuspensions(ctx, &pb.SuspensionRequest{Date: date})
+		if err != nil {
+			return nil, err
+		}
+		var stocks []*pb.Stock
+		for {
+			stock, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			stocks = append(stocks, stock)
+		}
+		return stocks, nil
+	}
+
+	// 2021-12-14、2021-12-15 停牌，2021-12-16 复牌
+	var data = make([]*pb.Metadata, 0, len(week))
+	for _, d := range week {
+		var c = proto.Clone(d).(*pb.Metadata)
+		if c.Date == "2021-12-14" || c.Date == "2021-12-15" {
+			c.Suspend = "停牌"
+		}
+		data = append(data, c)
+	}
+	// 乱序写入，stock 的当前停牌状态仍以最新 date 为准
+	pushData(t, client, data[4], data[0], data[1], data[2], data[3])
+
+	stocks, err := getSuspensions("2021-12-14")
+	_assert.Nil(err)
+	if _assert.Equal(1, len(stocks)) {
+		_assert.Equal("sz000001", stocks[0].Code)
+		_assert.Equal("平安银行", stocks[0].Name)
+		_assert.Equal("停牌", stocks[0].Suspend)
+	}
+
+	stocks, err = getSuspensions("2021-12-16")
+	_assert.Nil(err)
+	_assert.Equal(0, len(stocks))
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	stream, err := client.GetStockFull(ctx, &pb.StockRequest{Status: pb.StockRequest_SUSPENDED})
+	_assert.Nil(err)
+	_, err = stream.Recv()
+	_assert.Equal(io.EOF, err)
+
+	_, err = getSuspensions("2021/12/14")
+	_assert.NotNil(err)
+}
+
+func TestGetQuoteRange(t *testing.T) {
+	_assert := assert.New(t)
+	client, _, close, err := testutil.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	for _, d := range week {
+		pushData(t, client, d)
+	}
+
+	getQuoteRange := func(req *pb.QuoteRangeRequest) ([]*pb.Quote, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		stream, err := client.GetQuoteRange(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		var quotes []*pb.Quote
+		for {
+			quote, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			quotes = append(quotes, quote)
+		}
+		return quotes, nil
+	}
+
+	days, err := getQuoteRange(&pb.QuoteRangeRequest{Code: "sz000001", Begin: "2021-12-14", End: "2021-12-16"})
+	_assert.Nil(err)
+	_assert.Equal(3, len(days))
+	_assert.Equal("2021-12-14", days[0].Date)
+	_assert.Equal("2021-12-16", days[2].Date)
+
+	days, err = getQuoteRange(&pb.QuoteRangeRequest{Code: "sz000001", Begin: "2000-01-01", End: "2030-12-31"})
+	_assert.Nil(err)
+	_assert.Equal(5, len(days))
+
+	// 跨分段查询时按 date 升序返回，不重复不遗漏
+	days, err = getQuoteRange(&pb.QuoteRangeRequest{Code: "sz000001", Begin: "2020-12-16", End: "2021-12-31"})
+	_assert.Nil(err)
+	_assert.Equal(5, len(days))
+	for i, day := range days {
+		_assert.Equal(week[i].Date, day.Date)
+	}
+
+	_, err = getQuoteRange(&pb.QuoteRangeRequest{Code: "sz000001", Begin: "1900-01-01", End: "2021-12-31"})
+	_assert.NotNil(err)
+
+	weeks, err := getQuoteRange(&pb.QuoteRangeRequest{Code: "sz000001", Begin: "2021-12-01", End: "2021-12-31", Mode: pb.QuoteRequest_Week})
+	_assert.Nil(err)
+	_assert.Equal(1, len(weeks))
+
+	_, err = getQuoteRange(&pb.QuoteRangeRequest{Code: "sz000001", Begin: "2021-12-16", End: "2021-12-14"})
+	_assert.NotNil(err)
+
+	_, err = getQuoteRange(&pb.QuoteRangeRequest{Code: "sz000001", Begin: "20211214", End: "2021-12-16"})
+	_assert.NotNil(err)
+}
+
+func TestGetQuoteLatestBatch(t *testing.T) {
+	_assert := assert.New(t)
+	client, _, close, err := testutil.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	for _, d := range week {
+		var other = proto.Clone(d).(*pb.Metadata)
+		other.Code, other.Name = "sh601012", "隆基股份"
+		pushData(t, client, d, other)
+	}
+
+	getQuoteLatestBatch := func(req *pb.QuoteBatchRequest) ([]*pb.QuoteGroup, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		stream, err := client.GetQuoteLatestBatch(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		var groups []*pb.QuoteGroup
+		for {
+			group, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			groups = append(groups, group)
+		}
+		return groups, nil
+	}
+
+	groups, err := getQuoteLatestBatch(&pb.QuoteBatchRequest{Codes: []string{"sz000001", "sz000002", "sz000001"}, Date: "2021-12-16", Limit: 3})
+	_assert.Nil(err)
+	_assert.Equal(2, len(groups))
+	_assert.Equal("sz000001", groups[0].Code)
+	_assert.Equal(3, len(groups[0].Quotes))
+	_assert.Equal("2021-12-16", groups[0].Quotes[0].Date)
+	_assert.Equal("sz000002", groups[1].Code)
+	_assert.Equal(0, len(groups[1].Quotes))
+
+	groups, err = getQuoteLatestBatch(&pb.QuoteBatchRequest{All: true, Date: "2021-12-17", Limit: 1, Mode: pb.QuoteRequest_Week})
+	_assert.Nil(err)
+	_assert.Equal(2, len(groups))
+	for _, group := range groups {
+		_assert.Equal(1, len(group.Quotes))
+	}
+
+	_, err = getQuoteLatestBatch(&pb.QuoteBatchRequest{Date: "2021-12-17", Limit: 1})
+	_assert.NotNil(err)
+	_, err = getQuoteLatestBatch(&pb.QuoteBatchRequest{All: true, Date: "2021-12-17", Limit: 250})
+	_assert.NotNil(err)
+}
+
+func TestGetMarketSnapshot(t *testing.T) {
+	_assert := assert.New(t)
+	client, _, close, err := testutil.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	var earlier = proto.Clone(week[3]).(*pb.Metadata)
+	earlier.Code, earlier.Name = "sh601012", "隆基股份"
+	var other = proto.Clone(week[4]).(*pb.Metadata)
+	other.Code, other.Name, other.Suspend = "sh601012", "隆基股份", "停牌一天"
+	pushData(t, client, week[3], earlier, week[4], other)
+
+	getMarketSnapshot := func(req *pb.SnapshotRequest) ([]*pb.Snapshot, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		stream, err := client.GetMarketSnapshot(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		var snapshots []*pb.Snapshot
+		for {
+			snapshot, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			snapshots = append(snapshots, snapshot)
+		}
+		return snapshots, nil
+	}
+
+	snapshots, err := getMarketSnapshot(&pb.SnapshotRequest{Date: "2021-12-17"})
+	_assert.Nil(err)
+	_assert.Equal(2, len(snapshots))
+	_assert.Equal("sh601012", snapshots[0].Code)
+	_assert.Equal("隆基股份", snapshots[0].Name)
+	_assert.Equal("停牌一天", snapshots[0].Suspend)
+	_assert.Equal(10.60, snapshots[0].Quote.Close)
+	_assert.Equal("平安银行", snapshots[1].Name)
+
+	// 历史日期的停牌状态取当日记录
+	snapshots, err = getMarketSnapshot(&pb.SnapshotRequest{Date: "2021-12-16"})
+	_assert.Nil(err)
+	if _assert.Equal(2, len(snapshots)) {
+		_assert.Equal("sh601012", snapshots[0].Code)
+		_assert.Equal("正常", snapshots[0].Suspend)
+	}
+
+	snapshots, err = getMarketSnapshot(&pb.SnapshotRequest{Date: "2021-12-15"})
+	_assert.Nil(err)
+	_assert.Equal(0, len(snapshots))
+
+	_, err = getMarketSnapshot(&pb.SnapshotRequest{Date: "20211217"})
+	_assert.NotNil(err)
+}
+
+func TestPushDataPeriod(t *testing.T) {
+	_assert := assert.New(t)
+	client, _, close, err := testutil.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	// 2021-12-31 为周五，同时是月、季、年的最后一个交易日
+	var data = make([]*pb.Metadata, 0, len(week))
+	for i, d := range week {
+		var m = proto.Clone(d).(*pb.Metadata)
+		m.Date = fmt.Sprintf("2021-12-%d", 27+i)
+		data = append(data, m)
+	}
+	count := pushData(t, client, data...)
+	_assert.Equal(int64(5), count.Day)
+	_assert.Equal(int64(1), count.Week)
+	_assert.Equal(int64(1), count.Month)
+	_assert.Equal(int64(1), count.Quarter)
+	_assert.Equal(int64(1), count.Year)
+
+	for _, mode := range []pb.QuoteRequest_Mode{pb.QuoteRequest_Month, pb.QuoteRequest_Quarter, pb.QuoteRequest_Year} {
+		quotes := getQuoteLatest(t, client, &pb.QuoteRequest{Code: "sz000001", Date: "2021-12-31", Limit: 10, Mode: mode})
+		_assert.Equal(1, len(quotes), mode)
+		_assert.Equal(10.00, quotes[0].Open)
+		_assert.Equal(10.60, quotes[0].Close)
+		_assert.Equal(uint64(15000), quotes[0].Volume)
+	}
+
+	quotes := getQuoteLatest(t, client, &pb.QuoteRequest{Code: "sz000001", Date: "2021-12-31", Limit: 10, Mode: pb.QuoteRequest_Year})
+	_assert.Equal(int32(2021), quotes[0].NumOfYear)
+}
+
+func TestGetQuoteAdjust(t *testing.T) {
+	_assert := assert.New(t)
+	client, _, close, err := testutil.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	// 2021-12-14 除权，比例 0.5
+	var xd = proto.Clone(week[1]).(*pb.Metadata)
+	xd.Open, xd.YesterdayClosed, xd.Latest, xd.High, xd.Low = 5.10, 5.05, 5.20, 5.25, 5.00
+	pushData(t, client, week[0])
+	pushData(t, client, xd)
+
+	var cases = []struct {
+		adjust    pb.Adjust
+		old, last float64
+	}{
+		{pb.Adjust_FORWARD, 5.05, 5.20},
+		{pb.Adjust_NONE, 10.10, 5.20},
+		{pb.Adjust_BACKWARD, 10.10, 10.40},
+	}
+	for _, c := range cases {
+		days := getQuoteLatest(t, client, &pb.QuoteRequest{Code: "sz000001", Date: "2021-12-14", Limit: 10, Adjust: c.adjust})
+		_assert.Equal(2, len(days), c.adjust)
+		_assert.Equal(c.last, days[0].Close, c.adjust)
+		_assert.Equal(c.old, days[1].Close, c.adjust)
+	}
+
+	// 查询范围不包含除权日时，前复权仍以最新价格为基准
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	stream, err := client.GetMarketSnapshot(ctx, &pb.SnapshotRequest{Date: "2021-12-13"})
+	_assert.Nil(err)
+	snapshot, err := stream.Recv()
+	_assert.Nil(err)
+	_assert.Equal(5.05, snapshot.Quote.Close)
+}
+
+func TestCorporateAction(t *testing.T) {
+	_assert := assert.New(t)
+	client, repo, close, err := testutil.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	pushCorporateAction := func(actions ...*pb.CorporateAction) (int64, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		stream, err := client.PushCorporateAction(ctx)
+		if err != nil {
+			return 0, err
+		}
+		for _, action := range actions {
+			if err := stream.Send(action); err != nil {
+				return 0, err
+			}
+		}
+		count, err := stream.CloseAndRecv()
+		if err != nil {
+			return 0, err
+		}
+		return count.Value, nil
+	}
+
+	// 公司行为先于行情入库: 1 拆 2，昨收未调整时仍按公司行为计算复权比例
+	count, err := pushCorporateAction(&pb.CorporateAction{Code: "sz000001", Date: "2021-12-14", Kind: pb.CorporateAction_Split, Value: 2})
+	_assert.Nil(err)
+	_assert.Equal(int64(1), count)
+
+	pushData(t, client, week[0])
+	var split = proto.Clone(week[1]).(*pb.Metadata)
+	split.Open, split.Latest, split.High, split.Low = 5.10, 5.20, 5.25, 5.00
+	pushData(t, client, split)
+
+	quote, err := repo.QuoteWithSelectOneByCodeAndDate("day", "sz000001", "2021-12-14", timeout)
+	_assert.Nil(err)
+	_assert.InDelta(0.5, quote.Xd, 1e-9)
+
+	// 行情先于公司行为入库: 派息后重新计算已入库日线的复权比例
+	var next = proto.Clone(week[2]).(*pb.Metadata)
+	next.Open, next.YesterdayClosed, next.Latest, next.High, next.Low = 5.20, 5.20, 5.10, 5.20, 5.05
+	pushData(t, client, next)
+	quote, err = repo.QuoteWithSelectOneByCodeAndDate("day", "sz000001", "2021-12-15", timeout)
+	_assert.Nil(err)
+	_assert.Equal(1.0, quote.Xd)
+
+	count, err = pushCorporateAction(&pb.CorporateAction{Code: "sz000001", Date: "2021-12-15", Kind: pb.CorporateAction_Dividend, Value: 0.52})
+	_assert.Nil(err)
+	_assert.Equal(int64(1), count)
+	quote, err = repo.QuoteWithSelectOneByCodeAndDate("day", "sz000001", "2021-12-15", timeout)
+	_assert.Nil(err)
+	_assert.InDelta(0.9, quote.Xd, 1e-9)
+
+	// 周线已生成后补录公司行为: 重新汇总所在周的周线，周线与日线的复权比例及累计复权因子保持一致
+	var thursday, friday = proto.Clone(week[3]).(*pb.Metadata), proto.Clone(week[4]).(*pb.Metadata)
+	thursday.Open, thursday.YesterdayClosed, thursday.Latest, thursday.High, thursday.Low = 5.10, 5.10, 5.00, 5.15, 4.90
+	friday.Open, friday.YesterdayClosed, friday.Latest, friday.High, friday.Low = 5.00, 5.00, 5.30, 5.40, 4.95
+	pushData(t, client, thursday)
+	pushData(t, client, friday)
+	weekQuote, err := repo.QuoteWithSelectOneByCodeAndDate("week", "sz000001", "2021-12-17", timeout)
+	_assert.Nil(err)
+	_assert.InDelta(0.45, weekQuote.Xd, 1e-9)
+
+	count, err = pushCorporateAction(&pb.CorporateAction{Code: "sz000001", Date: "2021-12-16", Kind: pb.CorporateAction_Dividend, Value: 0.51})
+	_assert.Nil(err)
+	_assert.Equal(int64(1), count)
+	quote, err = repo.QuoteWithSelectOneByCodeAndDate("day", "sz000001", "2021-12-17", timeout)
+	_assert.Nil(err)
+	weekQuote, err = repo.QuoteWithSelectOneByCodeAndDate("week", "sz000001", "2021-12-17", timeout)
+	_assert.Nil(err)
+	_assert.InDelta(0.405, weekQuote.Xd, 1e-9)
+	_assert.InDelta(quote.Factor, weekQuote.Factor, 1e-9)
+
+	_, err = pushCorporateAction(&pb.CorporateAction{Code: "sz000001", Date: "20211215", Value: 0.52})
+	_assert.NotNil(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	stream, err := client.GetCorporateAction(ctx, &pb.CorporateActionRequest{Code: "sz000001"})
+	_assert.Nil(err)
+	var actions []*pb.CorporateAction
+	for {
+		action, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		_assert.Nil(err)
+		actions = append(actions, action)
+	}
+	_assert.Equal(3, len(actions))
+	_assert.Equal(pb.CorporateAction_Split, actions[0].Kind)
+	_assert.Equal("2021-12-15", actions[1].Date)
+	_assert.Equal(0.52, actions[1].Value)
+}
+
+func TestQuoteFactor(t *testing.T) {
+	_assert := assert.New(t)
+	client, repo, close, err := testutil.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	var (
+		xd   = proto.Clone(week[1]).(*pb.Metadata)
+		next = proto.Clone(week[2]).(*pb.Metadata)
+	)
+	xd.Open, xd.YesterdayClosed, xd.Latest, xd.High, xd.Low = 5.10, 5.05, 5.20, 5.25, 5.00
+	next.Open, next.YesterdayClosed, next.Latest, next.High, next.Low = 5.20, 5.20, 5.10, 5.20, 5.05
+	pushData(t, client, week[0])
+	pushData(t, client, xd)
+	pushData(t, client, next)
+
+	factorOf := func(date string) float64 {
+		quote, err := repo.QuoteWithSelectOneByCodeAndDate("day", "sz000001", date, timeout)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return quote.Factor
+	}
+	_assert.Equal(1.0, factorOf("2021-12-13"))
+	_assert.Equal(0.5, factorOf("2021-12-14"))
+	_assert.Equal(0.5, factorOf("2021-12-15"))
+
+	// 修正历史数据后，之后的累计复权因子同步修正
+	var fixed = proto.Clone(xd).(*pb.Metadata)
+	fixed.YesterdayClosed = 10.10
+	pushData(t, client, fixed)
+	_assert.Equal(1.0, factorOf("2021-12-14"))
+	_assert.Equal(1.0, factorOf("2021-12-15"))
+
+	days := getQuoteLatest(t, client, &pb.QuoteRequest{Code: "sz000001", Date: "2021-12-13", Limit: 1})
+	_assert.Equal(10.10, days[0].Close)
+}
+
+func TestPushDataBatch(t *testing.T) {
+	_assert := assert.New(t)
+	client, repo, close, err := testutil.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	pushBatch := func(id string, data ...*pb.Metadata) *pb.Count {
+		ctx, cancel := context.WithTimeout(metadata.AppendToOutgoingContext(context.Background(), server.BatchIDKey, id), timeout)
+		defer cancel()
+
+		stream, err := client.PushData(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, d := range data {
+			if err := stream.Send(d); err != nil {
+				t.Fatal(err)
+			}
+		}
+		count, err := stream.CloseAndRecv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return count
+	}
+
+	count := pushBatch("20211217-0001", week...)
+	_assert.Equal(int64(1), count.Stock)
+	_assert.Equal(int64(5), count.Day)
+	_assert.Equal(int64(5), count.Inserted.Day)
+	_assert.Equal(int64(0), count.Updated.Day)
+
+	// 重放同一批次，不再写入数据
+	var changed = proto.Clone(week[4]).(*pb.Metadata)
+	changed.Latest = 11.00
+	count = pushBatch("20211217-0001", changed)
+	_assert.Equal(int64(1), count.Stock)
+	_assert.Equal(int64(5), count.Day)
+	_assert.Equal(int64(1), count.Week)
+	_assert.Equal(int64(5), count.Inserted.Day)
+	_assert.Equal(int64(0), count.Updated.Day)
+
+	quote, err := repo.QuoteWithSelectOneByCodeAndDate("day", "sz000001", "2021-12-17", timeout)
+	_assert.Nil(err)
+	_assert.Equal(10.60, quote.Close)
+
+	count = pushBatch("20211217-0002", changed)
+	_assert.Equal(int64(0), count.Stock)
+	_assert.Equal(int64(1), count.Day)
+	_assert.Equal(int64(0), count.Inserted.Day)
+	_assert.Equal(int64(1), count.Updated.Day)
+	_assert.Equal(int64(0), count.Inserted.Week)
+	_assert.Equal(int64(1), count.Updated.Week)
+
+	// 重放更新批次，返回原有的新增与更新数量
+	count = pushBatch("20211217-0002", changed)
+	_assert.Equal(int64(1), count.Day)
+	_assert.Equal(int64(1), count.Updated.Day)
+	_assert.Equal(int64(1), count.Updated.Week)
+	quote, err = repo.QuoteWithSelectOneByCodeAndDate("day", "sz000001", "2021-12-17", timeout)
+	_assert.Nil(err)
+	_assert.Equal(11.00, quote.Close)
+}
+
+func TestPushDataBatchPending(t *testing.T) {
+	_assert := assert.New(t)
+	var repo = &unsavedBatchRepository{Memory: repository.NewMemory()}
+	client, close, err := testutil.NewServerWithRepository(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	pushBatch := func(id string, data ...*pb.Metadata) (*pb.Count, error) {
+		ctx, cancel := context.WithTimeout(metadata.AppendToOutgoingContext(context.Background(), server.BatchIDKey, id), timeout)
+		defer cancel()
+
+		stream, err := client.PushData(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range data {
+			if err := stream.Send(d); err != nil {
+				return nil, err
+			}
+		}
+		return stream.CloseAndRecv()
+	}
+
+	// 其他请求正在处理同一批次时不写入数据
+	_, err = repo.BatchWithInsertOne(&model.Batch{Id: "20211217-0004", Status: model.BatchPending}, timeout)
+	_assert.Nil(err)
+	_, err = pushBatch("20211217-0004", week...)
+	_assert.NotNil(err)
+	_, err = repo.QuoteWithSelectOneByCodeAndDate(model.Day, "sz000001", "2021-12-17", timeout)
+	_assert.Equal(sql.ErrNoRows, err)
+
+	// 记录批次失败时返回错误
+	repo.unsaved = true
+	_, err = pushBatch("20211217-0005", week...)
+	_assert.NotNil(err)
+	batch, err := repo.BatchWithSelectOne("20211217-0005", timeout)
+	_assert.Nil(err)
+	_assert.Equal(model.BatchPending, batch.Status)
+
+	repo.unsaved = false
+	_, err = repo.BatchWithDeleteOne("20211217-0005", time.Time{}, timeout)
+	_assert.Nil(err)
+	count, err := pushBatch("20211217-0005", week...)
+	_assert.Nil(err)
+	_assert.Equal(int64(5), count.Day)
+	batch, err = repo.BatchWithSelectOne("20211217-0005", timeout)
+	_assert.Nil(err)
+	_assert.Equal(model.BatchDone, batch.Status)
+	_assert.Equal(int64(5), batch.DayCount)
+}
+
+type unsavedBatchRepository struct {
+	*repository.Memory
+	unsaved bool
+}
+
+func (u *unsavedBatchRepository) BatchWithUpdateOne(batch *model.Batch, timeout time.Duration) (int64, error) {
+	if u.unsaved {
+		return 0, fmt.Errorf("database is unavailable")
+	}
+	return u.Memory.BatchWithUpdateOne(batch, timeout)
+}
+
+func TestPushDataRejected(t *testing.T) {
+	_assert := assert.New(t)
+	client, repo, close, err := testutil.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	var invalid = proto.Clone(week[0]).(*pb.Metadata)
+	invalid.Code = "sz000002"
+	invalid.Date = "2021/12/13"
+
+	// date 无效的数据不更新 stock 的名称及停牌状态
+	var malformed = proto.Clone(week[4]).(*pb.Metadata)
+	malformed.Name, malformed.Date, malformed.Suspend = "错误名称", "9999-xx", "停牌"
+
+	ctx, cancel := context.WithTimeout(metadata.AppendToOutgoingContext(context.Background(), server.BatchIDKey, "20211217-0003"), timeout)
+	defer cancel()
+
+	stream, err := client.PushData(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range append(append([]*pb.Metadata{invalid}, week...), malformed) {
+		if err := stream.Send(d); err != nil {
+			t.Fatal(err)
+		}
+	}
+	count, err := stream.CloseAndRecv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_assert.Equal(int64(1), count.Stock)
+	_assert.Equal(int64(5), count.Day)
+	_assert.Equal(int64(1), count.Week)
+	if _assert.Len(count.Rejected, 2) {
+		_assert.Equal("sz000002", count.Rejected[0].Code)
+		_assert.Equal("2021/12/13", count.Rejected[0].Date)
+		_assert.Contains(count.Rejected[0].Reason, "invalid date")
+		_assert.Equal("9999-xx", count.Rejected[1].Date)
+	}
+	stocks, err := repo.StockWithSelectMany([]string{"sz000001", "sz000002"}, timeout)
+	_assert.Nil(err)
+	_assert.Len(stocks, 1)
+	_assert.Equal("平安银行", stocks["sz000001"].Name)
+	_assert.Equal("正常", stocks["sz000001"].Suspend)
+
+	// 存在未写入数据的批次不记录，重复提交会重新处理
+	stream, err = client.PushData(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(invalid); err != nil {
+		t.Fatal(err)
+	}
+	count, err = stream.CloseAndRecv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_assert.Equal(int64(0), count.Day)
+	_assert.Len(count.Rejected, 1)
+}
+
+// unavailableRepository 模拟写入 quote 失败的存储
+type unavailableRepository struct {
+	*repository.Memory
+	unavailable bool
+}
+
+func (u *unavailableRepository) QuoteWithInsertOrUpdateManyAndFactor(mode string, quotes []*model.Quote, ids []int64, date string, ratios map[string]float64, timeout time.Duration) (int64, int64, error) {
+	if u.unavailable {
+		return 0, 0, fmt.Errorf("database is unavailable")
+	}
+	return u.Memory.QuoteWithInsertOrUpdateManyAndFactor(mode, quotes, ids, date, ratios, timeout)
+}
+
+func TestReplayRejected(t *testing.T) {
+	_assert := assert.New(t)
+	var repo = &unavailableRepository{Memory: repository.NewMemory(), unavailable: true}
+	client, close, err := testutil.NewServerWithRepository(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	stream, err := client.PushData(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range week[:2] {
+		if err := stream.Send(d); err != nil {
+			t.Fatal(err)
+		}
+	}
+	count, err := stream.CloseAndRecv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_assert.Equal(int64(0), count.Day)
+	_assert.Len(count.Rejected, 2)
+
+	resp, err := client.ListRejected(ctx, &pb.RejectedRequest{Code: "sz000001"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids = make([]int64, 0, 2)
+	for {
+		record, err := resp.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		_assert.Contains(record.Reason, "database is unavailable")
+		ids = append(ids, record.Id)
+	}
+	_assert.Len(ids, 2)
+
+	record, err := client.GetRejected(ctx, &wrapperspb.Int64Value{Value: ids[0]})
+	_assert.Nil(err)
+	_assert.True(proto.Equal(week[0], record.Metadata))
+
+	// 仍然失败时保留记录
+	count, err = client.ReplayRejected(ctx, &pb.ReplayRequest{Ids: ids[:1]})
+	_assert.Nil(err)
+	_assert.Len(count.Rejected, 1)
+	_, err = client.GetRejected(ctx, &wrapperspb.Int64Value{Value: ids[0]})
+	_assert.Nil(err)
+
+	repo.unavailable = false
+	count, err = client.ReplayRejected(ctx, &pb.ReplayRequest{All: true})
+	_assert.Nil(err)
+	_assert.Equal(int64(2), count.Day)
+	_assert.Len(count.Rejected, 0)
+
+	_, err = client.GetRejected(ctx, &wrapperspb.Int64Value{Value: ids[0]})
+	_assert.NotNil(err)
+	quote, err := repo.QuoteWithSelectOneByCodeAndDate(model.Day, "sz000001", week[1].Date, timeout)
+	_assert.Nil(err)
+	_assert.Equal(week[1].Latest, quote.Close)
+}
+
+func TestGetMetadataRange(t *testing.T) {
+	_assert := assert.New(t)
+	client, _, close, err := testutil.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	var intraday = proto.Clone(week[1]).(*pb.Metadata)
+	intraday.Latest = 10.25
+	intraday.Time = "11:30:00"
+	var closed = proto.Clone(week[1]).(*pb.Metadata)
+	closed.Time = "15:00:00"
+	var invalid = proto.Clone(week[2]).(*pb.Metadata)
+	invalid.Date = "2021/12/15"
+	pushData(t, client, week[0], intraday, invalid)
+	pushData(t, client, closed)
+	// 重复提交同一时间的原始数据时不重复归档
+	pushData(t, client, closed)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := client.GetMetadataRange(ctx, &pb.MetadataRangeRequest{Code: "sz000001", Begin: "2021-12-14"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var data = make([]*pb.Metadata, 0, 2)
+	for {
+		d, err := resp.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data = append(data, d)
+	}
+	if _assert.Len(data, 2) {
+		_assert.True(proto.Equal(intraday, data[0]))
+		_assert.True(proto.Equal(closed, data[1]))
+	}
+
+	resp, err = client.GetMetadataRange(ctx, &pb.MetadataRangeRequest{})
+	_assert.Nil(err)
+	_, err = resp.Recv()
+	_assert.NotNil(err)
+}
+
+func TestPushDataHoliday(t *testing.T) {
+	_assert := assert.New(t)
+	client, _, close, err := testutil.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// 2021-12-17 休市，2021-12-16 为当周最后一个交易日
+	stream, err := client.PushHoliday(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_assert.Nil(stream.Send(&pb.Holiday{Date: "2021-12-17", Name: "休市"}))
+	count, err := stream.CloseAndRecv()
+	_assert.Nil(err)
+	_assert.Equal(int64(1), count.Value)
+
+	holidays, err := client.GetHoliday(ctx, &pb.HolidayRequest{Begin: "2021-12-01", End: "2021-12-31"})
+	_assert.Nil(err)
+	holiday, err := holidays.Recv()
+	_assert.Nil(err)
+	_assert.Equal("2021-12-17", holiday.Date)
+	_assert.Equal("休市", holiday.Name)
+	_, err = holidays.Recv()
+	_assert.Equal(io.EOF, err)
+
+	for i, d := range week[:4] {
+		count := pushData(t, client, d)
+		if i == 3 {
+			_assert.Equal(int64(1), count.Week)
+		} else {
+			_assert.Equal(int64(0), count.Week)
+		}
+	}
+
+	weeks := getQuoteLatest(t, client, &pb.QuoteRequest{Code: "sz000001", Date: "2021-12-31", Limit: 10, Mode: pb.QuoteRequest_Week})
+	if _assert.Equal(1, len(weeks)) {
+		_assert.Equal("2021-12-16", weeks[0].Date)
+		_assert.Equal(10.00, weeks[0].Open)
+		_assert.Equal(10.00, weeks[0].Close)
+		_assert.Equal(uint64(10000), weeks[0].Volume)
+		_assert.Equal(int32(51), weeks[0].NumOfYear)
+	}
+}
+
+func TestPushDataPipeline(t *testing.T) {
+	_assert := assert.New(t)
+
+	// 每批 4 条，同一 code 的前一个交易日可能在同一批次内尚未写入
+	batchSize, parallelism := server.BatchSize, server.Parallelism
+	server.BatchSize, server.Parallelism = 4, 2
+	defer func() {
+		server.BatchSize, server.Parallelism = batchSize, parallelism
+	}()
+
+	var repo = &calendarLoadRepository{Memory: repository.NewMemory()}
+	client, close, err := testutil.NewServerWithRepository(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	_, err = repo.CorporateActionWithInsertOrUpdateMany([]*model.CorporateAction{{Code: "sz000002", Date: time.Date(2021, time.December, 16, 0, 0, 0, 0, time.Local), Kind: model.ActionDividend, Value: 0.5}}, timeout)
+	_assert.Nil(err)
+
+	var data = make([]*pb.Metadata, 0, 2*len(week))
+	for _, d := range week {
+		var (
+			first  = proto.Clone(d).(*pb.Metadata)
+			second = proto.Clone(d).(*pb.Metadata)
+		)
+		// sz000001 在 2021-12-14 除权，昨收减半
+		if first.Date == "2021-12-14" {
+			first.YesterdayClosed = 5.05
+		}
+		second.Code, second.Name = "sz000002", "万科A"
+		data = append(data, first, second)
+	}
+
+	count := pushData(t, client, data...)
+	_assert.Equal(int64(10), count.Day)
+	_assert.Equal(int64(2), count.Week)
+	_assert.Len(count.Rejected, 0)
+	// 多个批次共用同一交易日历
+	_assert.Equal(int32(1), atomic.LoadInt32(&repo.loads))
+
+	quote, err := repo.QuoteWithSelectOneByCodeAndDate(model.Day, "sz000001", "2021-12-14", timeout)
+	_assert.Nil(err)
+	_assert.Equal(0.5, quote.Xd)
+	quote, err = repo.QuoteWithSelectOneByCodeAndDate(model.Day, "sz000001", "2021-12-17", timeout)
+	_assert.Nil(err)
+	_assert.Equal(0.5, quote.Factor)
+
+	quote, err = repo.QuoteWithSelectOneByCodeAndDate(model.Day, "sz000002", "2021-12-16", timeout)
+	_assert.Nil(err)
+	_assert.InDelta((10.20-0.5)/10.20, quote.Xd, 1e-9)
+	quote, err = repo.QuoteWithSelectOneByCodeAndDate(model.Day, "sz000002", "2021-12-15", timeout)
+	_assert.Nil(err)
+	_assert.Equal(1.0, quote.Xd)
+
+	weeks := getQuoteLatest(t, client, &pb.QuoteRequest{Code: "sz000002", Date: "2021-12-17", Limit: 10, Mode: pb.QuoteRequest_Week})
+	if _assert.Equal(1, len(weeks)) {
+		_assert.Equal(uint64(15000), weeks[0].Volume)
+		_assert.Equal(10.60, weeks[0].Close)
+	}
+}
+
+type calendarLoadRepository struct {
+	*repository.Memory
+	loads int32
+}
+
+func (c *calendarLoadRepository) HolidayWithSelectMany(begin, end string, timeout time.Duration) ([]*model.Holiday, error) {
+	atomic.AddInt32(&c.loads, 1)
+	return c.Memory.HolidayWithSelectMany(begin, end, timeout)
+}
+
+func TestListTasks(t *testing.T) {
+	_assert := assert.New(t)
+	client, repo, close, err := testutil.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer close()
+
+	callback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer callback.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	for _, date := range []string{"2021-12-13", "2021-12-14", "2021-12-15", "2021-12-16"} {
+		_, err = client.CreateTask(ctx, &pb.Task{Date: date, CallbackUrl: callback.URL})
+		_assert.Nil(err)
+	}
+	pushData(t, client, week[1])
+	_, err = client.Complete(ctx, &pb.Task{Date: "2021-12-13", MetadataCount: 1, StockCount: 1, DayCount: 1})
+	_assert.Nil(err)
+	waitTask(t, repo, "2021-12-13", model.TaskCompleted)
+
+	task, err := client.GetTask(ctx, &wrapperspb.StringValue{Value: "2021-12-13"})
+	_assert.Nil(err)
+	_assert.Equal(model.TaskCompleted, task.State)
+	_assert.Equal(int64(1), task.DayCount)
+	_assert.Equal(callback.URL, task.CallbackUrl)
+	_assert.NotEmpty(task.CompletedTimestamp)
+	_assert.Equal(int64(1), task.CallbackAttempts)
+	_assert.Empty(task.IngestingTimestamp)
+	_assert.Empty(task.FailedTimestamp)
+
+	_, err = client.GetTask(ctx, &wrapperspb.StringValue{Value: "2021-12-17"})
+	_assert.NotNil(err)
+
+	listTasks := func(req *pb.TaskListRequest) ([]*pb.TaskInfo, error) {
+		stream, err := client.ListTasks(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		var tasks []*pb.TaskInfo
+		for {
+			task, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			tasks = append(tasks, task)
+		}
+		return tasks, nil
+	}
+
+	tasks, err := listTasks(&pb.TaskListRequest{})
+	_assert.Nil(err)
+	if _assert.Equal(4, len(tasks)) {
+		_assert.Equal("2021-12-16", tasks[0].Date)
+		_assert.Equal("2021-12-13", tasks[3].Date)
+	}
+
+	tasks, err = listTasks(&pb.TaskListRequest{From: "2021-12-14", To: "2021-12-16", Offset: 1, Limit: 1})
+	_assert.Nil(err)
+	if _assert.Equal(1, len(tasks)) {
+		_assert.Equal("2021-12-15", tasks[0].Date)
+	}
+
+	tasks, err = listTasks(&pb.TaskListRequest{State: model.TaskIngesting})
+	_assert.Nil(err)
+	if _assert.Equal(1, len(tasks)) {
+		_assert.Equal("2021-12-14", tasks[0].Date)
+		_assert.NotEmpty(tasks[0].IngestingTimestamp)
+	}
+
+	_, err = listTasks(&pb.TaskListRequest{State: "running"})
+	_assert.NotNil(err)
+	_, err = listTasks(&pb.TaskListRequest{From: "2021/12/14"})
+	_assert.NotNil(err)
+}
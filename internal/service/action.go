@@ -0,0 +1,65 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/eviltomorrow/robber-repository/internal/calendar"
+	"github.com/eviltomorrow/robber-repository/internal/model"
+	"github.com/eviltomorrow/robber-repository/internal/repository"
+)
+
+// RefreshQuoteXd 根据公司行为重新计算 code 在 date 的日线复权比例，date 无日线数据时忽略
+// 复权比例变化时按 cal 重新汇总 date 所在周期已入库的周、月、季、年线，并修正其后周期线的累计复权因子
+func RefreshQuoteXd(repo repository.Repository, cal *calendar.Calendar, code string, date string, timeout time.Duration) (bool, error) {
+	latest, err := repo.QuoteWithSelectManyLatest(model.Day, code, date, 2, timeout)
+	if err != nil {
+		return false, err
+	}
+	if len(latest) != 2 || latest[0].Date.Format("2006-01-02") != date || latest[1].Close == 0 {
+		return false, nil
+	}
+
+	var current, previous = latest[0], latest[1]
+	actions, err := repo.CorporateActionWithSelectMany(code, previous.Date.AddDate(0, 0, 1).Format("2006-01-02"), date, timeout)
+	if err != nil {
+		return false, err
+	}
+	if len(actions) == 0 {
+		return false, nil
+	}
+
+	var xd = model.ExRightFactor(actions, previous.Close)
+	if xd == current.Xd {
+		return false, nil
+	}
+	current.Xd = xd
+	if _, _, err := SaveQuotes(repo, []*model.Quote{current}, model.Day, timeout); err != nil {
+		return false, err
+	}
+	if err := refreshPeriods(repo, cal, code, current.Date, timeout); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+// refreshPeriods 重建 code 在 date 所在周期已入库的周期线，周期尚未结束时由 PushData 在周期最后一个交易日按新的日线生成
+func refreshPeriods(repo repository.Repository, cal *calendar.Calendar, code string, date time.Time, timeout time.Duration) error {
+	for _, mode := range []string{model.Week, model.Month, model.Quarter, model.Year} {
+		begin, end, _, err := cal.PeriodOf(mode, date)
+		if err != nil {
+			return err
+		}
+		stored, err := repo.QuoteWithSelectBetweenByCodeAndDate(mode, code, begin.Format("2006-01-02"), end.Format("2006-01-02"), timeout)
+		if err != nil {
+			return err
+		}
+		if len(stored) == 0 {
+			continue
+		}
+		if _, err := Rebuild(repo, cal, mode, []string{code}, begin, end, 1, false, timeout); err != nil {
+			return fmt.Errorf("rebuild %s failure, nest error: %v", mode, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"time"
+
+	"github.com/eviltomorrow/robber-repository/internal/model"
+	"github.com/eviltomorrow/robber-repository/internal/repository"
+)
+
+// AdjustQuotes 按 adjust 对 quotes 复权，quotes 可包含多个 code，返回结果保持原有顺序
+func AdjustQuotes(repo repository.Repository, mode string, adjust string, quotes []*model.Quote, timeout time.Duration) ([]*model.Quote, error) {
+	if adjust == model.AdjustNone || len(quotes) == 0 {
+		return quotes, nil
+	}
+
+	var (
+		codes = make([]string, 0, len(quotes))
+		exist = make(map[string]struct{}, len(quotes))
+	)
+	for _, quote := range quotes {
+		if _, ok := exist[quote.Code]; ok {
+			continue
+		}
+		exist[quote.Code] = struct{}{}
+		codes = append(codes, quote.Code)
+	}
+
+	latest, err := repo.QuoteWithSelectManyFactorByCodes(mode, codes, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	var result = make([]*model.Quote, 0, len(quotes))
+	for _, quote := range quotes {
+		result = append(result, model.AdjustQuotes([]*model.Quote{quote}, latest[quote.Code], adjust)...)
+	}
+	return result, nil
+}
+
+// AdjustQuoteGroups 按 adjust 对按 code 分组的 quotes 复权
+func AdjustQuoteGroups(repo repository.Repository, mode string, adjust string, groups map[string][]*model.Quote, timeout time.Duration) (map[string][]*model.Quote, error) {
+	if adjust == model.AdjustNone || len(groups) == 0 {
+		return groups, nil
+	}
+
+	var codes = make([]string, 0, len(groups))
+	for code := range groups {
+		codes = append(codes, code)
+	}
+
+	latest, err := repo.QuoteWithSelectManyFactorByCodes(mode, codes, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	var result = make(map[string][]*model.Quote, len(groups))
+	for code, quotes := range groups {
+		result[code] = model.AdjustQuotes(quotes, latest[code], adjust)
+	}
+	return result, nil
+}
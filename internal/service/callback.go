@@ -0,0 +1,337 @@
+package service
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eviltomorrow/robber-core/pkg/zlog"
+	"github.com/eviltomorrow/robber-repository/internal/model"
+	"github.com/eviltomorrow/robber-repository/internal/repository"
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+)
+
+// CallbackSignatureHeader POST 回调签名对应的 HTTP 头，值为 sha256=hex(HMAC-SHA256(callback_secret, body))
+const CallbackSignatureHeader = "X-Robber-Signature"
+
+const (
+	// maxCallbackBodyLength 投递记录中保存的响应内容最大长度
+	maxCallbackBodyLength = 512
+	// callbackBatchSize 每次扫描处理的 callback_pending task 数量
+	callbackBatchSize = 100
+)
+
+// CallbackDispatcher 在后台投递 callback_pending 状态 task 的回调
+// 投递失败时按指数退避重试，每次投递都记录到 task_callback_attempt，
+// 本轮投递次数达到 MaxAttempts 后 task 流转到 failed，可通过 RedeliverCallback 重新投递
+type CallbackDispatcher struct {
+	Repository repository.Repository
+	// MaxAttempts 每轮最多投递次数，Backoff 首次重试的间隔，此后每次翻倍直至 MaxBackoff
+	MaxAttempts int64
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+	// Timeout 单次投递的超时时间，Interval 扫描待投递 task 的间隔
+	Timeout  time.Duration
+	Interval time.Duration
+
+	client *http.Client
+	notify chan struct{}
+	stop   chan struct{}
+	done   chan struct{}
+	once   sync.Once
+}
+
+// NewCallbackDispatcher 创建使用默认重试策略的 CallbackDispatcher，需调用 Start 后才开始投递
+func NewCallbackDispatcher(repo repository.Repository) *CallbackDispatcher {
+	return &CallbackDispatcher{
+		Repository:  repo,
+		MaxAttempts: 5,
+		Backoff:     30 * time.Second,
+		MaxBackoff:  30 * time.Minute,
+		Timeout:     10 * time.Second,
+		Interval:    5 * time.Second,
+
+		notify: make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+	}
+}
+
+// Start 启动后台投递，进程重启后会继续投递未完成的回调
+func (d *CallbackDispatcher) Start() {
+	d.client = &http.Client{Timeout: d.Timeout}
+	d.done = make(chan struct{})
+	go d.run()
+}
+
+// Notify 唤醒后台立即扫描待投递的 task
+func (d *CallbackDispatcher) Notify() {
+	select {
+	case d.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Stop 停止后台投递并等待正在进行的投递结束
+func (d *CallbackDispatcher) Stop() error {
+	d.once.Do(func() {
+		close(d.stop)
+	})
+	if d.done != nil {
+		<-d.done
+	}
+	return nil
+}
+
+func (d *CallbackDispatcher) run() {
+	defer close(d.done)
+
+	var ticker = time.NewTicker(d.Interval)
+	defer ticker.Stop()
+
+	for {
+		d.dispatch()
+
+		select {
+		case <-d.stop:
+			return
+		case <-ticker.C:
+		case <-d.notify:
+		}
+	}
+}
+
+func (d *CallbackDispatcher) dispatch() {
+	// 按下次投递时间升序仅查询已到期的 task，避免未到期的 task 占满批次导致到期的 task 无法投递
+	tasks, err := d.Repository.TaskWithSelectDueCallback(time.Now(), callbackBatchSize, timeout)
+	if err != nil {
+		zlog.Error("Select callback pending task failure", zap.Error(err))
+		return
+	}
+
+	for _, task := range tasks {
+		if err := d.deliver(task); err != nil {
+			zlog.Error("Deliver callback failure", zap.String("date", task.Date), zap.Error(err))
+		}
+
+		select {
+		case <-d.stop:
+			return
+		default:
+		}
+	}
+}
+
+// deliver 投递一次回调，成功后 task 流转到 completed，失败且达到最大次数后流转到 failed
+func (d *CallbackDispatcher) deliver(task *model.Task) error {
+	if task.CallbackURL == "" {
+		return TransitTask(d.Repository, task, model.TaskCompleted, timeout)
+	}
+
+	// 投递前先登记投递次数及下次投递时间，投递过程中进程退出时按退避时间重新投递
+	var t = *task
+	t.CallbackAttempts++
+	t.NextCallbackTimestamp = sql.NullTime{Time: time.Now().Add(d.backoff(t.CallbackAttempts)), Valid: true}
+	affected, err := d.Repository.TaskWithUpdateOne(t.Date, model.TaskCallbackPending, &t, timeout)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return nil
+	}
+
+	var attempt = d.request(&t)
+	if _, err := d.Repository.TaskCallbackAttemptWithInsertOne(attempt, timeout); err != nil {
+		zlog.Error("Save callback attempt failure", zap.String("date", t.Date), zap.Error(err))
+	}
+	if attempt.Error == "" {
+		zlog.Info("Callback success", zap.String("url", t.CallbackURL), zap.String("result", attempt.Body))
+		return TransitTask(d.Repository, &t, model.TaskCompleted, timeout)
+	}
+
+	var reason = fmt.Errorf("callback failure, attempt: %d, nest error: %s", t.CallbackAttempts, attempt.Error)
+	zlog.Error("Callback failure", zap.String("url", t.CallbackURL), zap.Int64("attempt", t.CallbackAttempts), zap.String("error", attempt.Error))
+	if t.CallbackAttempts >= d.MaxAttempts {
+		return FailTask(d.Repository, &t, reason, timeout)
+	}
+
+	t.LastError = truncateTaskError(reason.Error())
+	_, err = d.Repository.TaskWithUpdateOne(t.Date, model.TaskCallbackPending, &t, timeout)
+	return err
+}
+
+// request 按 task 的回调方式请求 callback url 并记录状态码、响应内容摘要及耗时，非 2xx 状态码视为失败
+func (d *CallbackDispatcher) request(task *model.Task) *model.TaskCallbackAttempt {
+	var (
+		attempt = &model.TaskCallbackAttempt{Date: task.Date, Attempt: task.CallbackAttempts, URL: task.CallbackURL}
+		begin   = time.Now()
+	)
+	defer func() {
+		attempt.Latency = time.Since(begin).Milliseconds()
+		attempt.Error = truncateTaskError(attempt.Error)
+	}()
+
+	req, err := newCallbackRequest(task, begin)
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	defer resp.Body.Close()
+
+	attempt.StatusCode = int64(resp.StatusCode)
+	buf, err := io.ReadAll(io.LimitReader(resp.Body, maxCallbackBodyLength))
+	attempt.Body = strings.ToValidUTF8(string(buf), "")
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		attempt.Error = fmt.Sprintf("http status code: %d", resp.StatusCode)
+	}
+	return attempt
+}
+
+// CallbackPayload POST 回调的请求体
+type CallbackPayload struct {
+	Date string `json:"date"`
+	// State 回调成功后 task 的状态
+	State         string `json:"state"`
+	MetadataCount int64  `json:"metadata_count"`
+	StockCount    int64  `json:"stock_count"`
+	DayCount      int64  `json:"day_count"`
+	WeekCount     int64  `json:"week_count"`
+	// Inconsistent 上报数量与服务端统计的实际数量不一致，ActualXxxCount 为实际数量
+	Inconsistent     bool  `json:"inconsistent"`
+	ActualStockCount int64 `json:"actual_stock_count"`
+	ActualDayCount   int64 `json:"actual_day_count"`
+	ActualWeekCount  int64 `json:"actual_week_count"`
+	// Duration 开始写入至提交 Complete 的耗时，未写入数据时由创建开始计算，单位为秒
+	Duration int64 `json:"duration"`
+	// Timestamp 本次投递的 unix 时间戳(秒)，包含在签名内，接收方可据此拒绝重放的请求
+	Timestamp int64 `json:"timestamp"`
+}
+
+// SignCallback 使用 secret 计算 POST 回调请求体的签名
+func SignCallback(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// newCallbackRequest get 回调不带请求体，数量不一致时在 url 中附加 inconsistent=true；post 回调推送 CallbackPayload 并附带签名
+func newCallbackRequest(task *model.Task, now time.Time) (*http.Request, error) {
+	if task.CallbackMode != model.CallbackPost {
+		if !task.Inconsistent {
+			return http.NewRequest(http.MethodGet, task.CallbackURL, nil)
+		}
+		u, err := url.Parse(task.CallbackURL)
+		if err != nil {
+			return nil, err
+		}
+		var query = u.Query()
+		query.Set("inconsistent", "true")
+		u.RawQuery = query.Encode()
+		return http.NewRequest(http.MethodGet, u.String(), nil)
+	}
+
+	var begin = task.CreateTimestamp
+	if task.IngestingTimestamp.Valid {
+		begin = task.IngestingTimestamp.Time
+	}
+	var end = now
+	if task.VerifyingTimestamp.Valid {
+		end = task.VerifyingTimestamp.Time
+	}
+	var duration = int64(end.Sub(begin).Seconds())
+	if duration < 0 {
+		duration = 0
+	}
+
+	body, err := jsoniter.ConfigCompatibleWithStandardLibrary.Marshal(&CallbackPayload{
+		Date:             task.Date,
+		State:            model.TaskCompleted,
+		MetadataCount:    task.MetadataCount,
+		StockCount:       task.StockCount,
+		DayCount:         task.DayCount,
+		WeekCount:        task.WeekCount,
+		Inconsistent:     task.Inconsistent,
+		ActualStockCount: task.ActualStockCount,
+		ActualDayCount:   task.ActualDayCount,
+		ActualWeekCount:  task.ActualWeekCount,
+		Duration:         duration,
+		Timestamp:        now.Unix(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal callback payload failure, nest error: %v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, task.CallbackURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(CallbackSignatureHeader, SignCallback(task.CallbackSecret, body))
+	return req, nil
+}
+
+// backoff 第 attempts 次投递失败后距下次投递的间隔
+func (d *CallbackDispatcher) backoff(attempts int64) time.Duration {
+	var interval = d.Backoff
+	for i := int64(1); i < attempts && interval < d.MaxBackoff; i++ {
+		interval *= 2
+	}
+	if interval > d.MaxBackoff {
+		interval = d.MaxBackoff
+	}
+	return interval
+}
+
+// RedeliverCallback 手动重新投递 date 对应 task 的回调
+// 回调投递失败的 task 流转到 callback_pending 开始新一轮投递，callback_pending 的 task 立即投递；写入或校验失败的 task 须重新写入或校验，不能直接回调
+func RedeliverCallback(repo repository.Repository, date string, timeout time.Duration) (*model.Task, error) {
+	task, err := repo.TaskWithSelectOne(date, timeout)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("not found task with date[%s]", date)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch task.Status {
+	case model.TaskFailed:
+		if task.FailedStatus != model.TaskCallbackPending {
+			return nil, fmt.Errorf("task[%s] failed in %s status, only task failed in callback_pending status can be redelivered", task.Date, task.FailedStatus)
+		}
+		if err := TransitTask(repo, task, model.TaskCallbackPending, timeout); err != nil {
+			return nil, err
+		}
+	case model.TaskCallbackPending:
+		var t = *task
+		t.NextCallbackTimestamp = sql.NullTime{Time: time.Now(), Valid: true}
+		affected, err := repo.TaskWithUpdateOne(t.Date, task.Status, &t, timeout)
+		if err != nil {
+			return nil, err
+		}
+		if affected == 0 {
+			return nil, fmt.Errorf("task[%s] status is no longer %s, maybe modified by other request", task.Date, task.Status)
+		}
+		*task = t
+	default:
+		return nil, fmt.Errorf("task[%s] status is %s, only failed or callback_pending task can be redelivered", task.Date, task.Status)
+	}
+	return task, nil
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/eviltomorrow/robber-repository/internal/model"
+	"github.com/eviltomorrow/robber-repository/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallbackBackoff(t *testing.T) {
+	_assert := assert.New(t)
+	var d = NewCallbackDispatcher(repository.NewMemory())
+	d.Backoff = time.Second
+	d.MaxBackoff = 5 * time.Second
+
+	_assert.Equal(time.Second, d.backoff(1))
+	_assert.Equal(2*time.Second, d.backoff(2))
+	_assert.Equal(4*time.Second, d.backoff(3))
+	_assert.Equal(5*time.Second, d.backoff(4))
+	_assert.Equal(5*time.Second, d.backoff(100))
+}
+
+func TestCallbackDispatch(t *testing.T) {
+	_assert := assert.New(t)
+	var repo = repository.NewMemory()
+
+	// 未到投递时间的 task 超过一个批次时，已到期的 task 仍能投递
+	var begin = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.Local)
+	for i := 0; i <= callbackBatchSize; i++ {
+		var task = &model.Task{Date: begin.AddDate(0, 0, i).Format("2006-01-02")}
+		_assert.Nil(CreateTask(repo, task, timeout))
+		_assert.Nil(TransitTask(repo, task, model.TaskVerifying, timeout))
+		_assert.Nil(TransitTask(repo, task, model.TaskCallbackPending, timeout))
+
+		var t = *task
+		t.NextCallbackTimestamp = sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true}
+		_, err := repo.TaskWithUpdateOne(t.Date, model.TaskCallbackPending, &t, timeout)
+		_assert.Nil(err)
+	}
+	var due = &model.Task{Date: "2019-12-17"}
+	_assert.Nil(CreateTask(repo, due, timeout))
+	_assert.Nil(TransitTask(repo, due, model.TaskVerifying, timeout))
+	_assert.Nil(TransitTask(repo, due, model.TaskCallbackPending, timeout))
+
+	var d = NewCallbackDispatcher(repo)
+	d.dispatch()
+
+	task, err := repo.TaskWithSelectOne("2019-12-17", timeout)
+	_assert.Nil(err)
+	_assert.Equal(model.TaskCompleted, task.Status)
+	tasks, err := repo.TaskWithSelectRange("", "", model.TaskCallbackPending, 0, 1000, timeout)
+	_assert.Nil(err)
+	_assert.Equal(callbackBatchSize+1, len(tasks))
+}
+
+func TestRedeliverCallback(t *testing.T) {
+	_assert := assert.New(t)
+	var repo = repository.NewMemory()
+
+	var task = &model.Task{Date: "2021-12-17", CallbackURL: "http://127.0.0.1"}
+	_assert.Nil(CreateTask(repo, task, timeout))
+	_, err := RedeliverCallback(repo, "2021-12-17", timeout)
+	_assert.NotNil(err)
+	_, err = RedeliverCallback(repo, "2021-12-18", timeout)
+	_assert.NotNil(err)
+
+	_assert.Nil(TransitTask(repo, task, model.TaskVerifying, timeout))
+	_assert.Nil(TransitTask(repo, task, model.TaskCallbackPending, timeout))
+	task.CallbackAttempts = 3
+	_assert.Nil(FailTask(repo, task, fmt.Errorf("callback failure"), timeout))
+
+	// 重新投递时开始新一轮，投递次数清零
+	task, err = RedeliverCallback(repo, "2021-12-17", timeout)
+	_assert.Nil(err)
+	_assert.Equal(model.TaskCallbackPending, task.Status)
+	_assert.Equal(int64(0), task.CallbackAttempts)
+	_assert.True(task.NextCallbackTimestamp.Valid)
+
+	task, err = RedeliverCallback(repo, "2021-12-17", timeout)
+	_assert.Nil(err)
+	_assert.Equal(model.TaskCallbackPending, task.Status)
+
+	// 回调失败后重新写入，写入失败的 task 不能直接回调
+	_assert.Nil(FailTask(repo, task, fmt.Errorf("callback failure"), timeout))
+	_assert.Equal(model.TaskCallbackPending, task.FailedStatus)
+	_assert.Nil(TransitTask(repo, task, model.TaskIngesting, timeout))
+	_assert.Nil(FailTask(repo, task, fmt.Errorf("ingest failure"), timeout))
+	_assert.Equal(model.TaskIngesting, task.FailedStatus)
+	_, err = RedeliverCallback(repo, "2021-12-17", timeout)
+	_assert.NotNil(err)
+
+	// 校验失败的 task 不能直接回调
+	var verifying = &model.Task{Date: "2021-12-20", CallbackURL: "http://127.0.0.1"}
+	_assert.Nil(CreateTask(repo, verifying, timeout))
+	_assert.Nil(TransitTask(repo, verifying, model.TaskVerifying, timeout))
+	_assert.Nil(FailTask(repo, verifying, fmt.Errorf("count stock failure"), timeout))
+	_, err = RedeliverCallback(repo, "2021-12-20", timeout)
+	_assert.NotNil(err)
+	task, err = repo.TaskWithSelectOne("2021-12-20", timeout)
+	_assert.Nil(err)
+	_assert.Equal(model.TaskFailed, task.Status)
+}
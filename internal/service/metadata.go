@@ -2,12 +2,16 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"sort"
+	"sync"
 	"time"
 
-	"github.com/eviltomorrow/robber-core/pkg/mysql"
 	"github.com/eviltomorrow/robber-core/pkg/zmath"
 	"github.com/eviltomorrow/robber-core/pkg/ztime"
+	"github.com/eviltomorrow/robber-repository/internal/calendar"
 	"github.com/eviltomorrow/robber-repository/internal/model"
+	"github.com/eviltomorrow/robber-repository/internal/repository"
 	"github.com/eviltomorrow/robber-repository/pkg/pb"
 )
 
@@ -16,18 +20,112 @@ var (
 	ErrNoData = errors.New("no data")
 )
 
-func BuildQuoteDay(data *pb.Metadata, date time.Time) (*model.Quote, error) {
-	latest, err := model.QuoteWithSelectManyLatest(mysql.DB, model.Day, data.Code, data.Date, 1, timeout)
-	if err != nil {
-		return nil, err
+func BuildQuoteDay(repo repository.Repository, data *pb.Metadata, date time.Time) (*model.Quote, error) {
+	quotes, errs := BuildQuoteDays(repo, []*pb.Metadata{data}, []time.Time{date})
+	return quotes[0], errs[0]
+}
+
+// BuildQuoteDays 批量生成日线，dates[i] 为 data[i] 的日期，返回结果及错误与 data 一一对应
+// 按日期分组批量查询前一个交易日的日线及公司行为，同一 code 的多条数据以更早日期的数据作为前一个交易日
+func BuildQuoteDays(repo repository.Repository, data []*pb.Metadata, dates []time.Time) ([]*model.Quote, []error) {
+	var (
+		quotes = make([]*model.Quote, len(data))
+		errs   = make([]error, len(data))
+		keys   = make([]string, 0, 1)
+		groups = make(map[string][]int, 1)
+	)
+	for i, d := range data {
+		if _, ok := groups[d.Date]; !ok {
+			keys = append(keys, d.Date)
+		}
+		groups[d.Date] = append(groups[d.Date], i)
 	}
+	sort.Strings(keys)
+
+	var built = make(map[string]*model.Quote, len(data))
+	for _, date := range keys {
+		var (
+			group    = groups[date]
+			codes    = make([]string, 0, len(group))
+			previous = make(map[string]*model.Quote, len(group))
+		)
+		for _, i := range group {
+			codes = append(codes, data[i].Code)
+		}
+
+		latest, err := repo.QuoteWithSelectManyLatestByCodes(model.Day, codes, date, 2, timeout)
+		if err != nil {
+			for _, i := range group {
+				errs[i] = err
+			}
+			continue
+		}
+
+		var (
+			begin = date
+			exist = make([]string, 0, len(codes))
+		)
+		for _, code := range codes {
+			if _, ok := previous[code]; ok {
+				continue
+			}
+
+			// 重复写入同一天的数据时以前一个交易日为准
+			var stored = latest[code]
+			if len(stored) != 0 && stored[0].Date.Format("2006-01-02") == date {
+				stored = stored[1:]
+			}
+			var p *model.Quote
+			if len(stored) != 0 {
+				p = stored[0]
+			}
+			if b, ok := built[code]; ok && (p == nil || !b.Date.Before(p.Date)) {
+				p = b
+			}
+			previous[code] = p
+
+			if p != nil && p.Close != 0 {
+				exist = append(exist, code)
+				if next := p.Date.AddDate(0, 0, 1).Format("2006-01-02"); next < begin {
+					begin = next
+				}
+			}
+		}
 
-	var xd float64 = 1.0
-	if len(latest) == 1 && latest[0].Close != 0 && latest[0].Date.Format("2006-01-02") != data.Date && latest[0].Close != data.YesterdayClosed {
-		xd = data.YesterdayClosed / latest[0].Close
+		actions, err := repo.CorporateActionWithSelectManyByCodes(exist, begin, date, timeout)
+		if err != nil {
+			for _, i := range group {
+				errs[i] = err
+			}
+			continue
+		}
+
+		for _, i := range group {
+			var (
+				d = data[i]
+				p = previous[d.Code]
+			)
+			var xd = 1.0
+			if p != nil && p.Close != 0 {
+				var between = make([]*model.CorporateAction, 0, len(actions[d.Code]))
+				for _, action := range actions[d.Code] {
+					if action.Date.After(p.Date) {
+						between = append(between, action)
+					}
+				}
+				xd = inferXd(between, p, d.YesterdayClosed)
+			}
+			quotes[i] = newQuoteDay(d, dates[i], xd)
+		}
+		for _, i := range group {
+			built[data[i].Code] = quotes[i]
+		}
 	}
+	return quotes, errs
+}
 
-	quote := &model.Quote{
+func newQuoteDay(data *pb.Metadata, date time.Time, xd float64) *model.Quote {
+	return &model.Quote{
 		Code:            data.Code,
 		Open:            data.Open,
 		Close:           data.Latest,
@@ -41,16 +139,117 @@ func BuildQuoteDay(data *pb.Metadata, date time.Time) (*model.Quote, error) {
 		Xd:              xd,
 		CreateTimestamp: time.Now(),
 	}
-	return quote, nil
 }
 
-func BuildQuoteWeek(code string, date time.Time) (*model.Quote, error) {
+// InferXd 计算 code 在 date 相对前一个交易日 previous 的复权比例
+// 优先使用公司行为计算复权比例，无公司行为时根据昨收推断
+func InferXd(repo repository.Repository, code string, previous *model.Quote, yesterdayClosed float64, date string) (float64, error) {
+	if previous == nil || previous.Close == 0 {
+		return 1.0, nil
+	}
+
+	actions, err := repo.CorporateActionWithSelectMany(code, previous.Date.AddDate(0, 0, 1).Format("2006-01-02"), date, timeout)
+	if err != nil {
+		return 0, err
+	}
+	return inferXd(actions, previous, yesterdayClosed), nil
+}
+
+func inferXd(actions []*model.CorporateAction, previous *model.Quote, yesterdayClosed float64) float64 {
+	if len(actions) != 0 {
+		return model.ExRightFactor(actions, previous.Close)
+	}
+	if previous.Close != yesterdayClosed {
+		return yesterdayClosed / previous.Close
+	}
+	return 1.0
+}
+
+// BuildQuoteWeek 汇总当周(周一至 date)日线生成周线
+func BuildQuoteWeek(repo repository.Repository, code string, date time.Time) (*model.Quote, error) {
+	begin, _, err := calendar.Period(model.Week, date)
+	if err != nil {
+		return nil, err
+	}
+	return buildQuotePeriod(repo, code, begin, date, ztime.YearWeek(date))
+}
+
+// BuildQuoteMonth 汇总当月日线生成月线
+func BuildQuoteMonth(repo repository.Repository, code string, date time.Time) (*model.Quote, error) {
+	var begin = time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+	return buildQuotePeriod(repo, code, begin, date, int(date.Month()))
+}
+
+// BuildQuoteQuarter 汇总当季日线生成季线
+func BuildQuoteQuarter(repo repository.Repository, code string, date time.Time) (*model.Quote, error) {
 	var (
-		begin = date.AddDate(0, 0, -5).Format("2006-01-02")
+		quarter = (int(date.Month())-1)/3 + 1
+		begin   = time.Date(date.Year(), time.Month((quarter-1)*3+1), 1, 0, 0, 0, 0, date.Location())
+	)
+	return buildQuotePeriod(repo, code, begin, date, quarter)
+}
+
+// BuildQuoteYear 汇总当年日线生成年线
+func BuildQuoteYear(repo repository.Repository, code string, date time.Time) (*model.Quote, error) {
+	var begin = time.Date(date.Year(), time.January, 1, 0, 0, 0, 0, date.Location())
+	return buildQuotePeriod(repo, code, begin, date, date.Year())
+}
+
+// BuildQuotePeriod 按 mode 汇总日线生成对应周期的 K 线
+func BuildQuotePeriod(repo repository.Repository, mode string, code string, date time.Time) (*model.Quote, error) {
+	switch mode {
+	case model.Week:
+		return BuildQuoteWeek(repo, code, date)
+	case model.Month:
+		return BuildQuoteMonth(repo, code, date)
+	case model.Quarter:
+		return BuildQuoteQuarter(repo, code, date)
+	case model.Year:
+		return BuildQuoteYear(repo, code, date)
+	default:
+		return nil, fmt.Errorf("not support period mode[%s]", mode)
+	}
+}
+
+// BuildQuotePeriods 使用最多 parallelism 个 goroutine 并发生成 codes[i] 在 dates[i] 对应 mode 周期的 K 线，返回结果及错误与 codes 一一对应
+func BuildQuotePeriods(repo repository.Repository, mode string, codes []string, dates []time.Time, parallelism int) ([]*model.Quote, []error) {
+	var (
+		quotes = make([]*model.Quote, len(codes))
+		errs   = make([]error, len(codes))
+		index  = make(chan int, len(codes))
+		wg     sync.WaitGroup
+	)
+	for i := range codes {
+		index <- i
+	}
+	close(index)
+
+	if parallelism <= 0 {
+		parallelism = 1
+	}
+	if parallelism > len(codes) {
+		parallelism = len(codes)
+	}
+	wg.Add(parallelism)
+	for n := 0; n < parallelism; n++ {
+		go func() {
+			defer wg.Done()
+			for i := range index {
+				quotes[i], errs[i] = BuildQuotePeriod(repo, mode, codes[i], dates[i])
+			}
+		}()
+	}
+	wg.Wait()
+	return quotes, errs
+}
+
+func buildQuotePeriod(repo repository.Repository, code string, from, date time.Time, numOfYear int) (*model.Quote, error) {
+	var (
+		begin = from.Format("2006-01-02")
 		end   = date.Format("2006-01-02")
 	)
 
-	days, err := model.QuoteWithSelectBetweenByCodeAndDate(mysql.DB, model.Day, code, begin, end, timeout)
+	days, err := repo.QuoteWithSelectBetweenByCodeAndDate(model.Day, code, begin, end, timeout)
 	if err != nil {
 		return nil, err
 	}
@@ -58,6 +257,13 @@ func BuildQuoteWeek(code string, date time.Time) (*model.Quote, error) {
 	if len(days) == 0 {
 		return nil, ErrNoData
 	}
+	return AggregateQuotes(days, date, numOfYear), nil
+}
+
+// AggregateQuotes 汇总周期内按 date 升序的日线生成周期 K 线，days 不能为空
+func AggregateQuotes(days []*model.Quote, date time.Time, numOfYear int) *model.Quote {
+	// 周期内的日线以周期最后一个交易日为基准前复权
+	days = model.AdjustQuotes(days, days[len(days)-1].Factor, model.AdjustForward)
 
 	var (
 		first, last = days[0], days[len(days)-1]
@@ -73,12 +279,10 @@ func BuildQuoteWeek(code string, date time.Time) (*model.Quote, error) {
 		lows = append(lows, d.Low)
 		volumes = append(volumes, d.Volume)
 		accounts = append(accounts, d.Account)
-		if d.Xd != 1.0 {
-			xd = d.Xd
-		}
+		xd *= d.Xd
 	}
 
-	var week = &model.Quote{
+	return &model.Quote{
 		Code:            first.Code,
 		Open:            first.Open,
 		Close:           last.Close,
@@ -88,9 +292,8 @@ func BuildQuoteWeek(code string, date time.Time) (*model.Quote, error) {
 		Volume:          zmath.SumUint64(volumes),
 		Account:         zmath.SumFloat64(accounts),
 		Date:            date,
-		NumOfYear:       ztime.YearWeek(date),
+		NumOfYear:       numOfYear,
 		Xd:              xd,
 		CreateTimestamp: time.Now(),
 	}
-	return week, nil
 }
@@ -4,8 +4,8 @@ import (
 	"testing"
 	"time"
 
-	"github.com/eviltomorrow/robber-core/pkg/mysql"
 	"github.com/eviltomorrow/robber-repository/internal/model"
+	"github.com/eviltomorrow/robber-repository/internal/repository"
 	"github.com/eviltomorrow/robber-repository/pkg/pb"
 	"github.com/stretchr/testify/assert"
 )
@@ -57,29 +57,31 @@ var (
 )
 
 func TestBuildQuoteDay(t *testing.T) {
+	requireMySQL(t)
 	_assert := assert.New(t)
-	affected, err := SaveQuotes([]*model.Quote{Metadata1, Metadata2}, model.Day, timeout)
+	inserted, updated, err := SaveQuotes(repo, []*model.Quote{Metadata1, Metadata2}, model.Day, timeout)
 	_assert.Nil(err)
-	_assert.Equal(int64(2), affected)
+	_assert.Equal(int64(2), inserted+updated)
 
-	md3, err := BuildQuoteDay(pbdata, date.Add(24*time.Hour))
+	md3, err := BuildQuoteDay(repo, pbdata, date.Add(24*time.Hour))
 	_assert.Nil(err)
 	_assert.Equal(float64(1.0), md3.Xd)
 
 	pbdata.YesterdayClosed = 85.00
-	md3, err = BuildQuoteDay(pbdata, date.Add(24*time.Hour))
+	md3, err = BuildQuoteDay(repo, pbdata, date.Add(24*time.Hour))
 	_assert.Nil(err)
 	_assert.Equal(pbdata.YesterdayClosed/Metadata2.Close, md3.Xd)
 
 }
 
 func TestBuildQuoteWeek(t *testing.T) {
+	requireMySQL(t)
 	_assert := assert.New(t)
-	affected, err := SaveQuotes([]*model.Quote{Metadata1, Metadata2}, model.Day, timeout)
+	inserted, updated, err := SaveQuotes(repo, []*model.Quote{Metadata1, Metadata2}, model.Day, timeout)
 	_assert.Nil(err)
-	_assert.Equal(int64(2), affected)
+	_assert.Equal(int64(2), inserted+updated)
 
-	md3, err := BuildQuoteWeek(Metadata1.Code, date.Add(24*time.Hour))
+	md3, err := BuildQuoteWeek(repo, Metadata1.Code, date.Add(24*time.Hour))
 	_assert.Nil(err)
 	_assert.Equal(float64(1.0), md3.Xd)
 	_assert.Equal(Metadata2.Close, md3.Close)
@@ -87,6 +89,7 @@ func TestBuildQuoteWeek(t *testing.T) {
 }
 
 func TestBuildQuoteWeek2(t *testing.T) {
+	requireMySQL(t)
 	var (
 		offset int64 = 0
 		limit  int64 = 30
@@ -95,12 +98,12 @@ func TestBuildQuoteWeek2(t *testing.T) {
 		count int
 	)
 	for {
-		stocks, err := model.StockWithSelectRange(mysql.DB, offset, limit, timeout)
+		stocks, err := repo.StockWithSelectRange(offset, limit, timeout)
 		if err != nil {
 			t.Fatal(err)
 		}
 		for _, stock := range stocks {
-			week, err := BuildQuoteWeek(stock.Code, date)
+			week, err := BuildQuoteWeek(repo, stock.Code, date)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -115,3 +118,41 @@ func TestBuildQuoteWeek2(t *testing.T) {
 
 	t.Logf("count: %v\r\n", count)
 }
+
+func TestBuildQuoteDays(t *testing.T) {
+	_assert := assert.New(t)
+	var repo = repository.NewMemory()
+
+	var (
+		d1 = time.Date(2021, time.December, 13, 0, 0, 0, 0, time.Local)
+		d2 = time.Date(2021, time.December, 14, 0, 0, 0, 0, time.Local)
+		d3 = time.Date(2021, time.December, 15, 0, 0, 0, 0, time.Local)
+	)
+	_, _, err := repo.QuoteWithInsertOrUpdateMany(model.Day, []*model.Quote{
+		{Code: "sz000001", Close: 10.00, Date: d1, Xd: 1, Factor: 1},
+		{Code: "sz000001", Close: 10.20, Date: d2, Xd: 1, Factor: 1},
+	}, timeout)
+	_assert.Nil(err)
+	_, err = repo.CorporateActionWithInsertOrUpdateMany([]*model.CorporateAction{{Code: "sz000002", Date: d3, Kind: model.ActionSplit, Value: 2}}, timeout)
+	_assert.Nil(err)
+
+	// 重复写入 d2 时以 d1 为前一个交易日；d3 以本批次的 d2 为前一个交易日
+	quotes, errs := BuildQuoteDays(repo, []*pb.Metadata{
+		{Code: "sz000001", Latest: 10.40, YesterdayClosed: 5.00, Date: "2021-12-14"},
+		{Code: "sz000002", Latest: 20.00, YesterdayClosed: 19.00, Date: "2021-12-14"},
+		{Code: "sz000001", Latest: 10.50, YesterdayClosed: 10.40, Date: "2021-12-15"},
+		{Code: "sz000002", Latest: 10.10, YesterdayClosed: 20.00, Date: "2021-12-15"},
+	}, []time.Time{d2, d2, d3, d3})
+	_assert.Equal([]error{nil, nil, nil, nil}, errs)
+	_assert.Equal(0.5, quotes[0].Xd)
+	_assert.Equal(1.0, quotes[1].Xd)
+	_assert.Equal(1.0, quotes[2].Xd)
+	_assert.Equal(0.5, quotes[3].Xd)
+	_assert.Equal(d3, quotes[3].Date)
+	_assert.Equal(349, quotes[3].NumOfYear)
+
+	periods, errs := BuildQuotePeriods(repo, model.Week, []string{"sz000001", "sz000003"}, []time.Time{d2, d2}, 4)
+	_assert.Nil(errs[0])
+	_assert.Equal(10.20, periods[0].Close)
+	_assert.Equal(ErrNoData, errs[1])
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/eviltomorrow/robber-core/pkg/zmath"
+	"github.com/eviltomorrow/robber-repository/internal/calendar"
+	"github.com/eviltomorrow/robber-repository/internal/model"
+	"github.com/eviltomorrow/robber-repository/internal/repository"
+)
+
+// RebuildDiff 重建结果与已存储数据的差异，Stored 为 nil 表示新增，Rebuilt 为 nil 表示已存储数据不在重建结果中，重建时删除
+type RebuildDiff struct {
+	Code    string
+	Date    string
+	Stored  *model.Quote
+	Rebuilt *model.Quote
+}
+
+// RebuildResult 重建结果，Inserted、Updated、Deleted 为新增、覆盖更新及删除的数量，dry run 时为 0
+type RebuildResult struct {
+	Mode     string
+	Codes    int64
+	Rebuilt  int64
+	Inserted int64
+	Updated  int64
+	Deleted  int64
+	Diffs    []*RebuildDiff
+}
+
+// Rebuild 根据 quote_day 重新计算 codes 在 [from, to] 之间 mode 对应的 K 线及累计复权因子，codes 为空时重建全部股票
+// mode 为 day 时根据公司行为及昨收重新推断日线复权比例，其他周期按交易日历汇总日线，K 线日期为周期最后一个交易日
+// 每 batch 个 code 在同一事务内覆盖写入，删除 [from, to] 之间不在重建结果中的已存储数据，并按比例修正 to 之后数据的累计复权因子，
+// 失败时该批次不写入任何数据；dryRun 为 true 时不写入
+func Rebuild(repo repository.Repository, cal *calendar.Calendar, mode string, codes []string, from, to time.Time, batch int, dryRun bool, timeout time.Duration) (*RebuildResult, error) {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, to.Location())
+	if from.After(to) {
+		return nil, fmt.Errorf("invalid parameter, from[%s] is after to[%s]", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+	if mode != model.Day {
+		if _, _, err := calendar.Period(mode, from); err != nil {
+			return nil, err
+		}
+	}
+	if batch <= 0 {
+		batch = 50
+	}
+
+	if len(codes) == 0 {
+		var offset int64
+		for {
+			stocks, err := repo.StockWithSelectRange(offset, 100, timeout)
+			if err != nil {
+				return nil, err
+			}
+			for _, stock := range stocks {
+				codes = append(codes, stock.Code)
+			}
+			if len(stocks) < 100 {
+				break
+			}
+			offset += int64(len(stocks))
+		}
+	}
+
+	var result = &RebuildResult{Mode: mode, Codes: int64(len(codes))}
+	for i := 0; i < len(codes); i += batch {
+		var end = i + batch
+		if end > len(codes) {
+			end = len(codes)
+		}
+
+		var (
+			quotes = make([]*model.Quote, 0, 64)
+			ids    = make([]int64, 0, 4)
+			ratios = make(map[string]float64, end-i)
+		)
+		for _, code := range codes[i:end] {
+			rebuilt, stored, ratio, err := rebuildCode(repo, cal, mode, code, from, to, timeout)
+			if err != nil {
+				return result, fmt.Errorf("rebuild %s[%s] failure, nest error: %v", mode, code, err)
+			}
+			quotes = append(quotes, rebuilt...)
+			for _, quote := range staleQuotes(stored, rebuilt) {
+				ids = append(ids, quote.Id)
+			}
+			if ratio != 1.0 {
+				ratios[code] = ratio
+			}
+			if dryRun {
+				result.Diffs = append(result.Diffs, diffQuotes(code, stored, rebuilt)...)
+			}
+		}
+		result.Rebuilt += int64(len(quotes))
+		if dryRun {
+			continue
+		}
+
+		inserted, updated, err := repo.QuoteWithInsertOrUpdateManyAndFactor(mode, quotes, ids, to.Format("2006-01-02"), ratios, timeout)
+		if err != nil {
+			return result, err
+		}
+		result.Inserted += inserted
+		result.Updated += updated
+		result.Deleted += int64(len(ids))
+	}
+	return result, nil
+}
+
+// rebuildCode 重建 code 在 [from, to] 之间的 K 线，返回重建结果、已存储数据及 to 之后数据累计复权因子的修正比例
+func rebuildCode(repo repository.Repository, cal *calendar.Calendar, mode string, code string, from, to time.Time, timeout time.Duration) ([]*model.Quote, []*model.Quote, float64, error) {
+	var (
+		begin = from.Format("2006-01-02")
+		end   = to.Format("2006-01-02")
+	)
+
+	stored, err := repo.QuoteWithSelectBetweenByCodeAndDate(mode, code, begin, end, timeout)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	// 重建前后截至 to 的累计复权因子
+	var base, previous = 1.0, 1.0
+	latest, err := repo.QuoteWithSelectManyLatest(mode, code, from.AddDate(0, 0, -1).Format("2006-01-02"), 1, timeout)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if len(latest) == 1 && latest[0].Factor > 0 {
+		base = latest[0].Factor
+	}
+	last, err := repo.QuoteWithSelectManyLatest(mode, code, end, 1, timeout)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if len(last) == 1 && last[0].Factor > 0 {
+		previous = last[0].Factor
+	}
+
+	var rebuilt []*model.Quote
+	if mode == model.Day {
+		var before *model.Quote
+		if len(latest) == 1 {
+			before = latest[0]
+		}
+		rebuilt = make([]*model.Quote, 0, len(stored))
+		for _, day := range stored {
+			var date = day.Date.Format("2006-01-02")
+			xd, err := InferXd(repo, code, before, day.YesterdayClosed, date)
+			if err != nil {
+				return nil, nil, 0, err
+			}
+
+			var quote = *day
+			quote.Xd = xd
+			rebuilt = append(rebuilt, &quote)
+			before = day
+		}
+	} else {
+		first, _, _, _ := cal.PeriodOf(mode, from)
+		days, err := repo.QuoteWithSelectBetweenByCodeAndDate(model.Day, code, first.Format("2006-01-02"), end, timeout)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+
+		var (
+			group     = make([]*model.Quote, 0, 32)
+			periodEnd time.Time
+			numOfYear int
+		)
+		var flush = func() {
+			// 仅重建周期最后一个交易日在 [from, to] 之间的周期
+			if len(group) != 0 && !periodEnd.Before(from) && !periodEnd.After(to) {
+				rebuilt = append(rebuilt, AggregateQuotes(group, periodEnd, numOfYear))
+			}
+			group = make([]*model.Quote, 0, 32)
+		}
+		for _, day := range days {
+			_, e, n, _ := cal.PeriodOf(mode, day.Date)
+			if !e.Equal(periodEnd) {
+				flush()
+				periodEnd, numOfYear = e, n
+			}
+			group = append(group, day)
+		}
+		flush()
+	}
+
+	var factor = base
+	for _, quote := range rebuilt {
+		factor *= quote.Xd
+		quote.Factor = factor
+	}
+	return rebuilt, stored, factor / previous, nil
+}
+
+// diffQuotes 比较 code 已存储的数据与重建结果
+func diffQuotes(code string, stored, rebuilt []*model.Quote) []*RebuildDiff {
+	var (
+		diffs  = make([]*RebuildDiff, 0, 4)
+		exists = make(map[string]*model.Quote, len(stored))
+	)
+	for _, quote := range stored {
+		exists[quote.Date.Format("2006-01-02")] = quote
+	}
+	for _, quote := range rebuilt {
+		var date = quote.Date.Format("2006-01-02")
+		s, ok := exists[date]
+		if !ok {
+			diffs = append(diffs, &RebuildDiff{Code: code, Date: date, Rebuilt: quote})
+			continue
+		}
+		if quoteChanged(s, quote) {
+			diffs = append(diffs, &RebuildDiff{Code: code, Date: date, Stored: s, Rebuilt: quote})
+		}
+	}
+	for _, quote := range staleQuotes(stored, rebuilt) {
+		diffs = append(diffs, &RebuildDiff{Code: code, Date: quote.Date.Format("2006-01-02"), Stored: quote})
+	}
+	return diffs
+}
+
+// staleQuotes 已存储但日期不在重建结果中的数据，如按旧交易日历汇总、周期结束日已变化的 K 线
+func staleQuotes(stored, rebuilt []*model.Quote) []*model.Quote {
+	var (
+		stale = make([]*model.Quote, 0, 4)
+		seen  = make(map[string]struct{}, len(rebuilt))
+	)
+	for _, quote := range rebuilt {
+		seen[quote.Date.Format("2006-01-02")] = struct{}{}
+	}
+	for _, quote := range stored {
+		if _, ok := seen[quote.Date.Format("2006-01-02")]; !ok {
+			stale = append(stale, quote)
+		}
+	}
+	return stale
+}
+
+// quoteChanged 价格按 DECIMAL(10,2) 精度比较，复权比例及累计复权因子按相对误差比较
+func quoteChanged(stored, rebuilt *model.Quote) bool {
+	return zmath.Trunc2(stored.Open) != zmath.Trunc2(rebuilt.Open) ||
+		zmath.Trunc2(stored.Close) != zmath.Trunc2(rebuilt.Close) ||
+		zmath.Trunc2(stored.High) != zmath.Trunc2(rebuilt.High) ||
+		zmath.Trunc2(stored.Low) != zmath.Trunc2(rebuilt.Low) ||
+		zmath.Trunc2(stored.YesterdayClosed) != zmath.Trunc2(rebuilt.YesterdayClosed) ||
+		zmath.Trunc2(stored.Account) != zmath.Trunc2(rebuilt.Account) ||
+		stored.Volume != rebuilt.Volume ||
+		stored.NumOfYear != rebuilt.NumOfYear ||
+		!approximate(stored.Xd, rebuilt.Xd) ||
+		!approximate(stored.Factor, rebuilt.Factor)
+}
+
+func approximate(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-6*math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+}
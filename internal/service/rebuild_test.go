@@ -0,0 +1,126 @@
+package service
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/eviltomorrow/robber-repository/internal/calendar"
+	"github.com/eviltomorrow/robber-repository/internal/model"
+	"github.com/eviltomorrow/robber-repository/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebuild(t *testing.T) {
+	_assert := assert.New(t)
+	var (
+		repo = repository.NewMemory()
+		cal  = calendar.New(nil)
+	)
+	_, _, err := repo.StockWithInsertOrUpdateMany([]*model.Stock{{Code: "sz000001", Name: "平安银行", Suspend: "正常"}}, timeout)
+	_assert.Nil(err)
+
+	// 2021-12-06 ~ 2021-12-17 两周日线，2021-12-14 除权但保存时复权比例为 1
+	var (
+		monday = time.Date(2021, time.December, 6, 0, 0, 0, 0, time.Local)
+		days   = make([]*model.Quote, 0, 10)
+		closed = 10.00
+	)
+	for i := 0; i < 12; i++ {
+		var date = monday.AddDate(0, 0, i)
+		if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+			continue
+		}
+		var yesterdayClosed = closed
+		if date.Day() == 14 {
+			yesterdayClosed = closed / 2
+		}
+		closed = yesterdayClosed + 0.10
+		days = append(days, &model.Quote{
+			Code:            "sz000001",
+			Open:            yesterdayClosed,
+			Close:           closed,
+			High:            closed,
+			Low:             yesterdayClosed,
+			YesterdayClosed: yesterdayClosed,
+			Volume:          1000,
+			Account:         10000,
+			Date:            date,
+			NumOfYear:       date.YearDay(),
+			Xd:              1.0,
+			CreateTimestamp: time.Now(),
+		})
+	}
+	for _, day := range days {
+		_, _, err := SaveQuotes(repo, []*model.Quote{day}, model.Day, timeout)
+		_assert.Nil(err)
+	}
+
+	var (
+		from = time.Date(2021, time.December, 13, 0, 0, 0, 0, time.Local)
+		to   = time.Date(2021, time.December, 16, 0, 0, 0, 0, time.Local)
+	)
+	result, err := Rebuild(repo, cal, model.Day, []string{"sz000001"}, from, to, 0, true, timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(4), result.Rebuilt)
+	_assert.Equal(int64(0), result.Inserted+result.Updated)
+	if _assert.Len(result.Diffs, 3) {
+		_assert.Equal("2021-12-14", result.Diffs[0].Date)
+		_assert.Equal(1.0, result.Diffs[0].Stored.Xd)
+		_assert.Equal(0.5, result.Diffs[0].Rebuilt.Xd)
+	}
+	quote, err := repo.QuoteWithSelectOneByCodeAndDate(model.Day, "sz000001", "2021-12-14", timeout)
+	_assert.Nil(err)
+	_assert.Equal(1.0, quote.Xd)
+
+	result, err = Rebuild(repo, cal, model.Day, []string{"sz000001"}, from, to, 0, false, timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(0), result.Inserted)
+	_assert.Equal(int64(4), result.Updated)
+	for date, factor := range map[string]float64{"2021-12-13": 1.0, "2021-12-14": 0.5, "2021-12-16": 0.5, "2021-12-17": 0.5} {
+		quote, err := repo.QuoteWithSelectOneByCodeAndDate(model.Day, "sz000001", date, timeout)
+		_assert.Nil(err)
+		_assert.Equal(factor, quote.Factor, date)
+	}
+
+	result, err = Rebuild(repo, cal, model.Week, nil, monday, time.Date(2021, time.December, 31, 0, 0, 0, 0, time.Local), 0, false, timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(1), result.Codes)
+	_assert.Equal(int64(2), result.Rebuilt)
+
+	week, err := repo.QuoteWithSelectOneByCodeAndDate(model.Week, "sz000001", "2021-12-17", timeout)
+	_assert.Nil(err)
+	_assert.Equal(0.5, week.Xd)
+	_assert.Equal(0.5, week.Factor)
+	_assert.Equal(int64(5000), int64(week.Volume))
+
+	expected, err := BuildQuoteWeek(repo, "sz000001", time.Date(2021, time.December, 17, 0, 0, 0, 0, time.Local))
+	_assert.Nil(err)
+	_assert.InDelta(expected.Open, week.Open, 0.005)
+	_assert.InDelta(expected.High, week.High, 0.005)
+
+	result, err = Rebuild(repo, cal, model.Week, []string{"sz000001"}, monday, to, 0, true, timeout)
+	_assert.Nil(err)
+	_assert.Len(result.Diffs, 0)
+
+	// 2021-12-17 休市时周线日期为 2021-12-16
+	var holiday = calendar.New([]*model.Holiday{{Date: time.Date(2021, time.December, 17, 0, 0, 0, 0, time.Local)}})
+	result, err = Rebuild(repo, holiday, model.Week, []string{"sz000001"}, monday, time.Date(2021, time.December, 31, 0, 0, 0, 0, time.Local), 0, true, timeout)
+	_assert.Nil(err)
+	var dates = make(map[string]bool, len(result.Diffs))
+	for _, diff := range result.Diffs {
+		dates[diff.Date] = diff.Rebuilt != nil
+	}
+	_assert.Equal(map[string]bool{"2021-12-16": true, "2021-12-17": false}, dates)
+
+	// 按新交易日历重建后删除旧周期结束日的周线
+	result, err = Rebuild(repo, holiday, model.Week, []string{"sz000001"}, monday, time.Date(2021, time.December, 31, 0, 0, 0, 0, time.Local), 0, false, timeout)
+	_assert.Nil(err)
+	_assert.Equal(int64(1), result.Inserted)
+	_assert.Equal(int64(1), result.Deleted)
+	_, err = repo.QuoteWithSelectOneByCodeAndDate(model.Week, "sz000001", "2021-12-17", timeout)
+	_assert.Equal(sql.ErrNoRows, err)
+	week, err = repo.QuoteWithSelectOneByCodeAndDate(model.Week, "sz000001", "2021-12-16", timeout)
+	_assert.Nil(err)
+	_assert.Equal(0.5, week.Factor)
+}
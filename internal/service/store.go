@@ -3,93 +3,87 @@ package service
 import (
 	"time"
 
-	"github.com/eviltomorrow/robber-core/pkg/mysql"
 	"github.com/eviltomorrow/robber-repository/internal/model"
+	"github.com/eviltomorrow/robber-repository/internal/repository"
 )
 
-func SaveStocks(stocks []*model.Stock, timeout time.Duration) (int64, error) {
+// SaveStocks 写入 stocks，返回新增及更新的数量
+func SaveStocks(repo repository.Repository, stocks []*model.Stock, timeout time.Duration) (int64, int64, error) {
 	if len(stocks) == 0 {
-		return 0, nil
+		return 0, 0, nil
 	}
 
-	tx, err := mysql.DB.Begin()
+	inserted, updated, err := repo.StockWithInsertOrUpdateMany(stocks, timeout)
 	if err != nil {
-		return 0, nil
+		return 0, 0, err
 	}
-	affected, err := model.StockWithInsertOrUpdateMany(tx, stocks, timeout)
-	if err != nil {
-		tx.Rollback()
-		return 0, nil
-	}
-	if err := tx.Commit(); err != nil {
-		tx.Rollback()
-		return 0, nil
-	}
-	return affected, nil
+	return inserted, updated, nil
 }
 
-func SaveQuotes(quotes []*model.Quote, mode string, timeout time.Duration) (int64, error) {
+// SaveQuotes 按 date 分组新增或覆盖更新 quotes，同时维护累计复权因子，返回新增及更新的数量
+func SaveQuotes(repo repository.Repository, quotes []*model.Quote, mode string, timeout time.Duration) (int64, int64, error) {
 	if len(quotes) == 0 {
-		return 0, nil
+		return 0, 0, nil
 	}
 
 	var (
-		codes = make([]string, 0, len(quotes))
-		date  string
-		count int64
-		cache = make([]*model.Quote, 0, len(quotes))
+		dates  = make([]string, 0, 1)
+		groups = make(map[string][]*model.Quote, 1)
 	)
-
-	tx, err := mysql.DB.Begin()
-	if err != nil {
-		return 0, err
+	for _, quote := range quotes {
+		var date = quote.Date.Format("2006-01-02")
+		if _, ok := groups[date]; !ok {
+			dates = append(dates, date)
+		}
+		groups[date] = append(groups[date], quote)
 	}
-	for i, quote := range quotes {
-		var current = quote.Date.Format("2006-01-02")
-		if date == "" {
-			date = current
+
+	var inserted, updated int64
+	for _, date := range dates {
+		i, u, err := saveQuotesWithFactor(repo, groups[date], mode, date, timeout)
+		if err != nil {
+			return inserted, updated, err
 		}
+		inserted += i
+		updated += u
+	}
+	return inserted, updated, nil
+}
 
-		if date == current {
-			codes = append(codes, quote.Code)
-			cache = append(cache, quote)
-		} else {
-			_, err = model.QuoteWithDeleteManyByCodesAndDate(tx, mode, codes, date, timeout)
-			if err != nil {
-				tx.Rollback()
-				return 0, err
-			}
-			affected, err := model.QuoteWithInsertMany(tx, mode, cache, timeout)
-			if err != nil {
-				tx.Rollback()
-				return 0, err
-			}
-			count += affected
+// saveQuotesWithFactor 写入同一 date 的 quotes，累计复权因子 = 前一条数据的累计复权因子 * xd
+// 覆盖或补录历史数据导致累计复权因子变化时，按比例修正 date 之后的数据
+func saveQuotesWithFactor(repo repository.Repository, quotes []*model.Quote, mode string, date string, timeout time.Duration) (int64, int64, error) {
+	var codes = make([]string, 0, len(quotes))
+	for _, quote := range quotes {
+		codes = append(codes, quote.Code)
+	}
+	latest, err := repo.QuoteWithSelectManyLatestByCodes(mode, codes, date, 2, timeout)
+	if err != nil {
+		return 0, 0, err
+	}
 
-			date = current
-			codes = codes[:0]
-			cache = cache[:0]
-			codes = append(codes, quote.Code)
-			cache = append(cache, quote)
+	var ratios = make(map[string]float64, len(quotes))
+	for _, quote := range quotes {
+		var (
+			data           = latest[quote.Code]
+			base, previous = 1.0, 0.0
+		)
+		if len(data) != 0 && data[0].Date.Format("2006-01-02") == date {
+			previous = data[0].Factor
+			data = data[1:]
 		}
-		if len(quotes)-1 == i {
-			_, err = model.QuoteWithDeleteManyByCodesAndDate(tx, mode, codes, date, timeout)
-			if err != nil {
-				tx.Rollback()
-				return 0, err
-			}
-			affected, err := model.QuoteWithInsertMany(tx, mode, cache, timeout)
-			if err != nil {
-				tx.Rollback()
-				return 0, err
-			}
-			count += affected
+		if len(data) != 0 && data[0].Factor > 0 {
+			base = data[0].Factor
+		}
+		if previous <= 0 {
+			previous = base
 		}
-	}
 
-	if err := tx.Commit(); err != nil {
-		tx.Rollback()
-		return 0, err
+		quote.Factor = base * quote.Xd
+		if quote.Factor != previous {
+			ratios[quote.Code] = quote.Factor / previous
+		}
 	}
-	return count, nil
+
+	return repo.QuoteWithInsertOrUpdateManyAndFactor(mode, quotes, nil, date, ratios, timeout)
 }
@@ -2,12 +2,14 @@ package service
 
 import (
 	"log"
+	"os"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/eviltomorrow/robber-core/pkg/mysql"
 	"github.com/eviltomorrow/robber-repository/internal/model"
+	"github.com/eviltomorrow/robber-repository/internal/repository"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -61,20 +63,31 @@ var (
 	}
 )
 
-var onece sync.Once
+var (
+	onece sync.Once
+	repo  repository.Repository
+)
 
-func init() {
-	mysql.DSN = "root:root@tcp(127.0.0.1:3306)/robber?charset=utf8mb4&parseTime=true&loc=Local"
-	mysql.MinOpen = 5
-	mysql.MaxOpen = 10
-	if err := mysql.Build(); err != nil {
-		log.Fatal(err)
+// TestMain 连接本地 MySQL，连接失败时依赖 MySQL 的测试跳过，其余测试使用 repository.NewMemory 照常执行
+func TestMain(m *testing.M) {
+	if db, err := repository.NewMySQL("root:root@tcp(127.0.0.1:3306)/robber?charset=utf8mb4&parseTime=true&loc=Local", 5, 10); err != nil {
+		log.Printf("[Warning] Connect to mysql failure, skip tests depend on mysql, nest error: %v\r\n", err)
+	} else {
+		repo = db
 	}
 	onece.Do(func() {
 		// truncateStock()
 		// truncateQuoteDay()
 		// truncateQuoteWeek()
 	})
+	os.Exit(m.Run())
+}
+
+// requireMySQL 未连接 MySQL 时跳过当前测试
+func requireMySQL(t *testing.T) {
+	if repo == nil {
+		t.Skip("mysql is not available")
+	}
 }
 
 func truncateStock() {
@@ -96,50 +109,56 @@ func truncateQuoteWeek() {
 }
 
 func TestSaveStocksNormal(t *testing.T) {
+	requireMySQL(t)
 	_assert := assert.New(t)
 	stocks := []*model.Stock{
 		Stock1,
 		Stock2,
 		Stock3,
 	}
-	affected, err := SaveStocks(stocks, timeout)
+	inserted, updated, err := SaveStocks(repo, stocks, timeout)
 	_assert.Nil(err)
-	_assert.Equal(int64(len(stocks)), affected)
+	_assert.Equal(int64(len(stocks)), inserted)
+	_assert.Equal(int64(0), updated)
 }
 
 func TestSaveStocksBlank(t *testing.T) {
+	requireMySQL(t)
 	_assert := assert.New(t)
 	stocks := []*model.Stock{}
-	affected, err := SaveStocks(stocks, timeout)
+	inserted, updated, err := SaveStocks(repo, stocks, timeout)
 	_assert.Nil(err)
-	_assert.Equal(int64(0), affected)
+	_assert.Equal(int64(0), inserted+updated)
 }
 
 func TestSaveStocksName(t *testing.T) {
+	requireMySQL(t)
 	_assert := assert.New(t)
 	oldname := Stock1.Name
 	Stock1.Name = "xd上海银行"
 	stocks := []*model.Stock{
 		Stock1,
 	}
-	affected, err := SaveStocks(stocks, timeout)
+	inserted, updated, err := SaveStocks(repo, stocks, timeout)
 	_assert.Nil(err)
-	_assert.Equal(int64(1), affected)
+	_assert.Equal(int64(0), inserted)
+	_assert.Equal(int64(1), updated)
 	Stock1.Name = oldname
 }
 
 func TestSaveQuoteNormal(t *testing.T) {
+	requireMySQL(t)
 	_assert := assert.New(t)
 	quotes := []*model.Quote{
 		Quote1,
 		Quote2,
 	}
-	affected, err := SaveQuotes(quotes, model.Day, timeout)
+	inserted, updated, err := SaveQuotes(repo, quotes, model.Day, timeout)
 	_assert.Nil(err)
-	_assert.Equal(int64(len(quotes)), affected)
+	_assert.Equal(int64(len(quotes)), inserted+updated)
 
-	affected, err = SaveQuotes(quotes, model.Week, timeout)
+	inserted, updated, err = SaveQuotes(repo, quotes, model.Week, timeout)
 	_assert.Nil(err)
-	_assert.Equal(int64(len(quotes)), affected)
+	_assert.Equal(int64(len(quotes)), inserted+updated)
 
 }
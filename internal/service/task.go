@@ -0,0 +1,200 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/eviltomorrow/robber-repository/internal/model"
+	"github.com/eviltomorrow/robber-repository/internal/repository"
+)
+
+// taskTransitions task 状态的合法流转，completed 为终态
+// 没有数据写入的 task(如休市日) 可由 created 直接进入 verifying；failed 可重新写入、校验或回调
+var taskTransitions = map[string][]string{
+	model.TaskCreated:         {model.TaskIngesting, model.TaskVerifying, model.TaskFailed},
+	model.TaskIngesting:       {model.TaskVerifying, model.TaskFailed},
+	model.TaskVerifying:       {model.TaskCallbackPending, model.TaskFailed},
+	model.TaskCallbackPending: {model.TaskCompleted, model.TaskFailed},
+	model.TaskFailed:          {model.TaskIngesting, model.TaskVerifying, model.TaskCallbackPending},
+}
+
+// maxTaskErrorLength last_error 最大长度
+const maxTaskErrorLength = 1024
+
+// CanTransitTask task 能否由 from 状态流转到 to 状态
+func CanTransitTask(from, to string) bool {
+	for _, status := range taskTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTaskStatus status 是否为合法的 task 状态
+func IsTaskStatus(status string) bool {
+	if status == model.TaskCompleted {
+		return true
+	}
+	_, ok := taskTransitions[status]
+	return ok
+}
+
+// CreateTask 创建 created 状态的 task，同一 date 仅能创建一次
+// 未指定回调方式时使用 get，post 回调必须指定签名密钥
+func CreateTask(repo repository.Repository, task *model.Task, timeout time.Duration) error {
+	if task == nil {
+		return fmt.Errorf("invalid parameter, task is nil")
+	}
+	if _, err := time.ParseInLocation("2006-01-02", task.Date, time.Local); err != nil {
+		return fmt.Errorf("invalid parameter, date[%s] must be formatted as 2006-01-02", task.Date)
+	}
+	switch task.CallbackMode {
+	case "":
+		task.CallbackMode = model.CallbackGet
+	case model.CallbackGet:
+	case model.CallbackPost:
+		if task.CallbackSecret == "" {
+			return fmt.Errorf("invalid parameter, callback_secret is required for post callback")
+		}
+	default:
+		return fmt.Errorf("invalid parameter, callback_mode[%s] must be get or post", task.CallbackMode)
+	}
+
+	_, err := repo.TaskWithSelectOne(task.Date, timeout)
+	if err == nil {
+		return fmt.Errorf("exist same date[%v] task", task.Date)
+	}
+	if err != sql.ErrNoRows {
+		return err
+	}
+
+	task.Status = model.TaskCreated
+	if _, err := repo.TaskWithInsertOne(task, timeout); err != nil {
+		return err
+	}
+	return nil
+}
+
+// TransitTask 将 task 流转到 status 并记录进入该状态的时间，task 的其他字段修改一并保存
+// 进入 callback_pending 时开始新一轮回调投递，投递次数清零并立即投递
+// 流转不合法或 task 状态已被其他请求修改时返回错误，成功后 task 更新为流转后的状态
+func TransitTask(repo repository.Repository, task *model.Task, status string, timeout time.Duration) error {
+	if task == nil {
+		return fmt.Errorf("invalid parameter, task is nil")
+	}
+	if !CanTransitTask(task.Status, status) {
+		return fmt.Errorf("invalid task[%s] transition from %s to %s", task.Date, task.Status, status)
+	}
+
+	var (
+		t   = *task
+		now = sql.NullTime{Time: time.Now(), Valid: true}
+	)
+	t.Status = status
+	switch status {
+	case model.TaskIngesting:
+		t.IngestingTimestamp = now
+	case model.TaskVerifying:
+		t.VerifyingTimestamp = now
+	case model.TaskCallbackPending:
+		t.CallbackPendingTimestamp = now
+		t.CallbackAttempts = 0
+		t.NextCallbackTimestamp = now
+	case model.TaskCompleted:
+		t.CompletedTimestamp = now
+		t.LastError = ""
+	case model.TaskFailed:
+		t.FailedTimestamp = now
+		t.FailedStatus = task.Status
+	}
+
+	affected, err := repo.TaskWithUpdateOne(task.Date, task.Status, &t, timeout)
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("task[%s] status is no longer %s, maybe modified by other request", task.Date, task.Status)
+	}
+	*task = t
+	return nil
+}
+
+// FailTask 将 task 流转到 failed 并记录失败原因
+func FailTask(repo repository.Repository, task *model.Task, reason error, timeout time.Duration) error {
+	if task == nil {
+		return fmt.Errorf("invalid parameter, task is nil")
+	}
+
+	var t = *task
+	t.LastError = truncateTaskError(reason.Error())
+	if err := TransitTask(repo, &t, model.TaskFailed, timeout); err != nil {
+		return err
+	}
+	*task = t
+	return nil
+}
+
+// VerifyTask 按 date 统计 stock、day、week 的实际数量记录到 task，与上报数量的差异超出 tolerance 时标记为不一致
+// tolerance 为允许的相对误差，如 0.01 表示允许相差实际数量的 1%，0 表示必须完全一致
+// stock 的实际数量为当日写入的 stock 每日状态数量；metadata 为追加写入的原始数据，不参与比较
+func VerifyTask(repo repository.Repository, task *model.Task, tolerance float64, timeout time.Duration) error {
+	if task == nil {
+		return fmt.Errorf("invalid parameter, task is nil")
+	}
+
+	stock, err := repo.StockSuspendWithCountByDate(task.Date, timeout)
+	if err != nil {
+		return fmt.Errorf("count stock failure, nest error: %v", err)
+	}
+	day, err := repo.QuoteWithCountByDate(model.Day, task.Date, timeout)
+	if err != nil {
+		return fmt.Errorf("count day failure, nest error: %v", err)
+	}
+	week, err := repo.QuoteWithCountByDate(model.Week, task.Date, timeout)
+	if err != nil {
+		return fmt.Errorf("count week failure, nest error: %v", err)
+	}
+
+	task.ActualStockCount, task.ActualDayCount, task.ActualWeekCount = stock, day, week
+	task.Inconsistent = false
+	for _, c := range [][2]int64{
+		{task.StockCount, stock},
+		{task.DayCount, day},
+		{task.WeekCount, week},
+	} {
+		var diff = c[0] - c[1]
+		if diff < 0 {
+			diff = -diff
+		}
+		if float64(diff) > tolerance*float64(c[1]) {
+			task.Inconsistent = true
+		}
+	}
+	return nil
+}
+
+// IngestTask 开始写入 date 的数据时将对应的 task 流转到 ingesting
+// task 不存在、已处于 ingesting 或当前状态不允许重新写入(如 completed)时忽略
+func IngestTask(repo repository.Repository, date string, timeout time.Duration) error {
+	task, err := repo.TaskWithSelectOne(date, timeout)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !CanTransitTask(task.Status, model.TaskIngesting) {
+		return nil
+	}
+	return TransitTask(repo, task, model.TaskIngesting, timeout)
+}
+
+// truncateTaskError 截断超出 last_error 长度的错误信息
+func truncateTaskError(s string) string {
+	if r := []rune(s); len(r) > maxTaskErrorLength {
+		return string(r[:maxTaskErrorLength])
+	}
+	return s
+}
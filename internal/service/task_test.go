@@ -0,0 +1,104 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/eviltomorrow/robber-repository/internal/model"
+	"github.com/eviltomorrow/robber-repository/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanTransitTask(t *testing.T) {
+	_assert := assert.New(t)
+
+	_assert.True(CanTransitTask(model.TaskCreated, model.TaskIngesting))
+	_assert.True(CanTransitTask(model.TaskCreated, model.TaskVerifying))
+	_assert.True(CanTransitTask(model.TaskCallbackPending, model.TaskCompleted))
+	_assert.True(CanTransitTask(model.TaskFailed, model.TaskCallbackPending))
+	_assert.False(CanTransitTask(model.TaskCreated, model.TaskCompleted))
+	_assert.False(CanTransitTask(model.TaskIngesting, model.TaskCallbackPending))
+	_assert.False(CanTransitTask(model.TaskCompleted, model.TaskIngesting))
+	_assert.False(CanTransitTask(model.TaskCompleted, model.TaskFailed))
+}
+
+func TestTransitTask(t *testing.T) {
+	_assert := assert.New(t)
+	var repo = repository.NewMemory()
+
+	var task = &model.Task{Date: "2021-12-17", CallbackURL: "http://127.0.0.1"}
+	_assert.Nil(CreateTask(repo, task, timeout))
+	_assert.Equal(model.TaskCreated, task.Status)
+	_assert.Equal(model.CallbackGet, task.CallbackMode)
+	_assert.NotNil(CreateTask(repo, &model.Task{Date: "2021-12-17"}, timeout))
+	_assert.NotNil(CreateTask(repo, &model.Task{Date: "2021/12/17", CallbackURL: "http://127.0.0.1"}, timeout))
+	_assert.NotNil(CreateTask(repo, &model.Task{Date: "2021-12-18", CallbackMode: model.CallbackPost}, timeout))
+	_assert.NotNil(CreateTask(repo, &model.Task{Date: "2021-12-18", CallbackMode: "put"}, timeout))
+
+	_assert.Nil(IngestTask(repo, "2021-12-17", timeout))
+	_assert.Nil(IngestTask(repo, "2021-12-17", timeout))
+	_assert.Nil(IngestTask(repo, "2021-12-18", timeout))
+
+	// 过期的 task 不能覆盖其他请求的修改
+	_assert.NotNil(TransitTask(repo, task, model.TaskVerifying, timeout))
+
+	task, err := repo.TaskWithSelectOne("2021-12-17", timeout)
+	_assert.Nil(err)
+	_assert.Equal(model.TaskIngesting, task.Status)
+	_assert.NotNil(TransitTask(repo, task, model.TaskCompleted, timeout))
+	_assert.Equal(model.TaskIngesting, task.Status)
+
+	_assert.Nil(FailTask(repo, task, fmt.Errorf("database is unavailable"), timeout))
+	_assert.Equal(model.TaskFailed, task.Status)
+	_assert.Equal("database is unavailable", task.LastError)
+
+	task.DayCount = 5
+	_assert.Nil(TransitTask(repo, task, model.TaskVerifying, timeout))
+	stored, err := repo.TaskWithSelectOne("2021-12-17", timeout)
+	_assert.Nil(err)
+	_assert.Equal(model.TaskVerifying, stored.Status)
+	_assert.Equal(int64(5), stored.DayCount)
+	_assert.Equal("database is unavailable", stored.LastError)
+	_assert.True(stored.IngestingTimestamp.Valid)
+	_assert.True(stored.FailedTimestamp.Valid)
+}
+
+func TestVerifyTask(t *testing.T) {
+	_assert := assert.New(t)
+	var repo = repository.NewMemory()
+
+	var (
+		date     = time.Date(2021, time.December, 17, 0, 0, 0, 0, time.Local)
+		quotes   = make([]*model.Quote, 0, 100)
+		suspends = make([]*model.StockSuspend, 0, 100)
+	)
+	for i := 0; i < 100; i++ {
+		var code = fmt.Sprintf("sz%06d", i+1)
+		quotes = append(quotes, &model.Quote{Code: code, Open: 10, Close: 10, High: 10, Low: 10, YesterdayClosed: 10, Date: date, Xd: 1, Factor: 1})
+		suspends = append(suspends, &model.StockSuspend{Code: code, Date: date, Suspend: model.SuspendNormal})
+	}
+	_, _, err := repo.QuoteWithInsertOrUpdateMany(model.Day, quotes, timeout)
+	_assert.Nil(err)
+	_, err = repo.StockSuspendWithInsertOrUpdateMany(suspends, timeout)
+	_assert.Nil(err)
+
+	var task = &model.Task{Date: "2021-12-17", StockCount: 100, DayCount: 100}
+	_assert.Nil(VerifyTask(repo, task, 0, timeout))
+	_assert.False(task.Inconsistent)
+	_assert.Equal(int64(100), task.ActualStockCount)
+	_assert.Equal(int64(100), task.ActualDayCount)
+	_assert.Equal(int64(0), task.ActualWeekCount)
+
+	task.DayCount = 99
+	_assert.Nil(VerifyTask(repo, task, 0, timeout))
+	_assert.True(task.Inconsistent)
+
+	// 允许相差 1%
+	_assert.Nil(VerifyTask(repo, task, 0.01, timeout))
+	_assert.False(task.Inconsistent)
+
+	task.WeekCount = 1
+	_assert.Nil(VerifyTask(repo, task, 0.01, timeout))
+	_assert.True(task.Inconsistent)
+}
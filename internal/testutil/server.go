@@ -0,0 +1,65 @@
+package testutil
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/eviltomorrow/robber-repository/internal/repository"
+	"github.com/eviltomorrow/robber-repository/internal/server"
+	"github.com/eviltomorrow/robber-repository/internal/service"
+	"github.com/eviltomorrow/robber-repository/pkg/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const bufSize = 1024 * 1024
+
+// NewServer 基于 bufconn 及内存存储启动 GRPC 服务，返回可直接使用的 client
+func NewServer() (pb.ServiceClient, *repository.Memory, func(), error) {
+	var repo = repository.NewMemory()
+	client, close, err := NewServerWithRepository(repo)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return client, repo, close, nil
+}
+
+// NewServerWithRepository 基于 bufconn 及指定存储启动 GRPC 服务
+// 回调最多投递 3 次，重试间隔为毫秒级以便测试等待投递结果
+func NewServerWithRepository(repo repository.Repository) (pb.ServiceClient, func(), error) {
+	var dispatcher = service.NewCallbackDispatcher(repo)
+	dispatcher.MaxAttempts = 3
+	dispatcher.Backoff = 10 * time.Millisecond
+	dispatcher.MaxBackoff = 40 * time.Millisecond
+	dispatcher.Interval = 10 * time.Millisecond
+	dispatcher.Start()
+
+	var (
+		listen = bufconn.Listen(bufSize)
+		s      = server.NewServer(repo, dispatcher)
+	)
+	go s.Serve(listen)
+
+	conn, err := grpc.DialContext(
+		context.Background(),
+		"bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listen.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		s.Stop()
+		dispatcher.Stop()
+		return nil, nil, err
+	}
+
+	return pb.NewServiceClient(conn), func() {
+		conn.Close()
+		s.Stop()
+		dispatcher.Stop()
+		repo.Close()
+	}, nil
+}
@@ -22,11 +22,64 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Adjust 复权方式，默认前复权
+type Adjust int32
+
+const (
+	Adjust_FORWARD  Adjust = 0
+	Adjust_NONE     Adjust = 1
+	Adjust_BACKWARD Adjust = 2
+)
+
+// Enum value maps for Adjust.
+var (
+	Adjust_name = map[int32]string{
+		0: "FORWARD",
+		1: "NONE",
+		2: "BACKWARD",
+	}
+	Adjust_value = map[string]int32{
+		"FORWARD":  0,
+		"NONE":     1,
+		"BACKWARD": 2,
+	}
+)
+
+func (x Adjust) Enum() *Adjust {
+	p := new(Adjust)
+	*p = x
+	return p
+}
+
+func (x Adjust) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Adjust) Descriptor() protoreflect.EnumDescriptor {
+	return file_repository_proto_enumTypes[0].Descriptor()
+}
+
+func (Adjust) Type() protoreflect.EnumType {
+	return &file_repository_proto_enumTypes[0]
+}
+
+func (x Adjust) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Adjust.Descriptor instead.
+func (Adjust) EnumDescriptor() ([]byte, []int) {
+	return file_repository_proto_rawDescGZIP(), []int{0}
+}
+
 type QuoteRequest_Mode int32
 
 const (
-	QuoteRequest_Day  QuoteRequest_Mode = 0
-	QuoteRequest_Week QuoteRequest_Mode = 1
+	QuoteRequest_Day     QuoteRequest_Mode = 0
+	QuoteRequest_Week    QuoteRequest_Mode = 1
+	QuoteRequest_Month   QuoteRequest_Mode = 2
+	QuoteRequest_Quarter QuoteRequest_Mode = 3
+	QuoteRequest_Year    QuoteRequest_Mode = 4
 )
 
 // Enum value maps for QuoteRequest_Mode.
@@ -34,10 +87,16 @@ var (
 	QuoteRequest_Mode_name = map[int32]string{
 		0: "Day",
 		1: "Week",
+		2: "Month",
+		3: "Quarter",
+		4: "Year",
 	}
 	QuoteRequest_Mode_value = map[string]int32{
-		"Day":  0,
-		"Week": 1,
+		"Day":     0,
+		"Week":    1,
+		"Month":   2,
+		"Quarter": 3,
+		"Year":    4,
 	}
 )
 
@@ -52,11 +111,11 @@ func (x QuoteRequest_Mode) String() string {
 }
 
 func (QuoteRequest_Mode) Descriptor() protoreflect.EnumDescriptor {
-	return file_repository_proto_enumTypes[0].Descriptor()
+	return file_repository_proto_enumTypes[1].Descriptor()
 }
 
 func (QuoteRequest_Mode) Type() protoreflect.EnumType {
-	return &file_repository_proto_enumTypes[0]
+	return &file_repository_proto_enumTypes[1]
 }
 
 func (x QuoteRequest_Mode) Number() protoreflect.EnumNumber {
@@ -68,15 +127,164 @@ func (QuoteRequest_Mode) EnumDescriptor() ([]byte, []int) {
 	return file_repository_proto_rawDescGZIP(), []int{0, 0}
 }
 
+type CorporateAction_Kind int32
+
+const (
+	CorporateAction_Dividend CorporateAction_Kind = 0
+	CorporateAction_Bonus    CorporateAction_Kind = 1
+	CorporateAction_Split    CorporateAction_Kind = 2
+	CorporateAction_Rights   CorporateAction_Kind = 3
+)
+
+// Enum value maps for CorporateAction_Kind.
+var (
+	CorporateAction_Kind_name = map[int32]string{
+		0: "Dividend",
+		1: "Bonus",
+		2: "Split",
+		3: "Rights",
+	}
+	CorporateAction_Kind_value = map[string]int32{
+		"Dividend": 0,
+		"Bonus":    1,
+		"Split":    2,
+		"Rights":   3,
+	}
+)
+
+func (x CorporateAction_Kind) Enum() *CorporateAction_Kind {
+	p := new(CorporateAction_Kind)
+	*p = x
+	return p
+}
+
+func (x CorporateAction_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CorporateAction_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_repository_proto_enumTypes[2].Descriptor()
+}
+
+func (CorporateAction_Kind) Type() protoreflect.EnumType {
+	return &file_repository_proto_enumTypes[2]
+}
+
+func (x CorporateAction_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CorporateAction_Kind.Descriptor instead.
+func (CorporateAction_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_repository_proto_rawDescGZIP(), []int{6, 0}
+}
+
+type StockRequest_Status int32
+
+const (
+	StockRequest_ALL       StockRequest_Status = 0
+	StockRequest_NORMAL    StockRequest_Status = 1
+	StockRequest_SUSPENDED StockRequest_Status = 2
+)
+
+// Enum value maps for StockRequest_Status.
+var (
+	StockRequest_Status_name = map[int32]string{
+		0: "ALL",
+		1: "NORMAL",
+		2: "SUSPENDED",
+	}
+	StockRequest_Status_value = map[string]int32{
+		"ALL":       0,
+		"NORMAL":    1,
+		"SUSPENDED": 2,
+	}
+)
+
+func (x StockRequest_Status) Enum() *StockRequest_Status {
+	p := new(StockRequest_Status)
+	*p = x
+	return p
+}
+
+func (x StockRequest_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StockRequest_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_repository_proto_enumTypes[3].Descriptor()
+}
+
+func (StockRequest_Status) Type() protoreflect.EnumType {
+	return &file_repository_proto_enumTypes[3]
+}
+
+func (x StockRequest_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StockRequest_Status.Descriptor instead.
+func (StockRequest_Status) EnumDescriptor() ([]byte, []int) {
+	return file_repository_proto_rawDescGZIP(), []int{18, 0}
+}
+
+// CallbackMode 回调方式，GET 为兼容旧版的无请求体回调，POST 以 JSON 推送 task 统计并附带签名
+type Task_CallbackMode int32
+
+const (
+	Task_GET  Task_CallbackMode = 0
+	Task_POST Task_CallbackMode = 1
+)
+
+// Enum value maps for Task_CallbackMode.
+var (
+	Task_CallbackMode_name = map[int32]string{
+		0: "GET",
+		1: "POST",
+	}
+	Task_CallbackMode_value = map[string]int32{
+		"GET":  0,
+		"POST": 1,
+	}
+)
+
+func (x Task_CallbackMode) Enum() *Task_CallbackMode {
+	p := new(Task_CallbackMode)
+	*p = x
+	return p
+}
+
+func (x Task_CallbackMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Task_CallbackMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_repository_proto_enumTypes[4].Descriptor()
+}
+
+func (Task_CallbackMode) Type() protoreflect.EnumType {
+	return &file_repository_proto_enumTypes[4]
+}
+
+func (x Task_CallbackMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Task_CallbackMode.Descriptor instead.
+func (Task_CallbackMode) EnumDescriptor() ([]byte, []int) {
+	return file_repository_proto_rawDescGZIP(), []int{22, 0}
+}
+
 type QuoteRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Code  string            `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
-	Date  string            `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
-	Limit int64             `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
-	Mode  QuoteRequest_Mode `protobuf:"varint,4,opt,name=mode,proto3,enum=repository.QuoteRequest_Mode" json:"mode,omitempty"`
+	Code   string            `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Date   string            `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	Limit  int64             `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Mode   QuoteRequest_Mode `protobuf:"varint,4,opt,name=mode,proto3,enum=repository.QuoteRequest_Mode" json:"mode,omitempty"`
+	Adjust Adjust            `protobuf:"varint,5,opt,name=adjust,proto3,enum=repository.Adjust" json:"adjust,omitempty"`
 }
 
 func (x *QuoteRequest) Reset() {
@@ -139,27 +347,27 @@ func (x *QuoteRequest) GetMode() QuoteRequest_Mode {
 	return QuoteRequest_Day
 }
 
-type Metadata struct {
+func (x *QuoteRequest) GetAdjust() Adjust {
+	if x != nil {
+		return x.Adjust
+	}
+	return Adjust_FORWARD
+}
+
+type QuoteRangeRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Code            string  `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
-	Name            string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Open            float64 `protobuf:"fixed64,3,opt,name=open,proto3" json:"open,omitempty"`
-	YesterdayClosed float64 `protobuf:"fixed64,4,opt,name=yesterday_closed,json=yesterdayClosed,proto3" json:"yesterday_closed,omitempty"`
-	Latest          float64 `protobuf:"fixed64,5,opt,name=latest,proto3" json:"latest,omitempty"`
-	High            float64 `protobuf:"fixed64,6,opt,name=high,proto3" json:"high,omitempty"`
-	Low             float64 `protobuf:"fixed64,7,opt,name=low,proto3" json:"low,omitempty"`
-	Volume          uint64  `protobuf:"varint,8,opt,name=volume,proto3" json:"volume,omitempty"`
-	Account         float64 `protobuf:"fixed64,9,opt,name=account,proto3" json:"account,omitempty"`
-	Date            string  `protobuf:"bytes,10,opt,name=date,proto3" json:"date,omitempty"`
-	Time            string  `protobuf:"bytes,11,opt,name=time,proto3" json:"time,omitempty"`
-	Suspend         string  `protobuf:"bytes,12,opt,name=suspend,proto3" json:"suspend,omitempty"`
+	Code   string            `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Begin  string            `protobuf:"bytes,2,opt,name=begin,proto3" json:"begin,omitempty"`
+	End    string            `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	Mode   QuoteRequest_Mode `protobuf:"varint,4,opt,name=mode,proto3,enum=repository.QuoteRequest_Mode" json:"mode,omitempty"`
+	Adjust Adjust            `protobuf:"varint,5,opt,name=adjust,proto3,enum=repository.Adjust" json:"adjust,omitempty"`
 }
 
-func (x *Metadata) Reset() {
-	*x = Metadata{}
+func (x *QuoteRangeRequest) Reset() {
+	*x = QuoteRangeRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_repository_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -167,13 +375,13 @@ func (x *Metadata) Reset() {
 	}
 }
 
-func (x *Metadata) String() string {
+func (x *QuoteRangeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Metadata) ProtoMessage() {}
+func (*QuoteRangeRequest) ProtoMessage() {}
 
-func (x *Metadata) ProtoReflect() protoreflect.Message {
+func (x *QuoteRangeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_repository_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -185,122 +393,160 @@ func (x *Metadata) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Metadata.ProtoReflect.Descriptor instead.
-func (*Metadata) Descriptor() ([]byte, []int) {
+// Deprecated: Use QuoteRangeRequest.ProtoReflect.Descriptor instead.
+func (*QuoteRangeRequest) Descriptor() ([]byte, []int) {
 	return file_repository_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *Metadata) GetCode() string {
+func (x *QuoteRangeRequest) GetCode() string {
 	if x != nil {
 		return x.Code
 	}
 	return ""
 }
 
-func (x *Metadata) GetName() string {
+func (x *QuoteRangeRequest) GetBegin() string {
 	if x != nil {
-		return x.Name
+		return x.Begin
 	}
 	return ""
 }
 
-func (x *Metadata) GetOpen() float64 {
+func (x *QuoteRangeRequest) GetEnd() string {
 	if x != nil {
-		return x.Open
+		return x.End
 	}
-	return 0
+	return ""
 }
 
-func (x *Metadata) GetYesterdayClosed() float64 {
+func (x *QuoteRangeRequest) GetMode() QuoteRequest_Mode {
 	if x != nil {
-		return x.YesterdayClosed
+		return x.Mode
 	}
-	return 0
+	return QuoteRequest_Day
 }
 
-func (x *Metadata) GetLatest() float64 {
+func (x *QuoteRangeRequest) GetAdjust() Adjust {
 	if x != nil {
-		return x.Latest
+		return x.Adjust
 	}
-	return 0
+	return Adjust_FORWARD
 }
 
-func (x *Metadata) GetHigh() float64 {
-	if x != nil {
-		return x.High
+type QuoteBatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Codes []string `protobuf:"bytes,1,rep,name=codes,proto3" json:"codes,omitempty"`
+	// all 为 true 时忽略 codes，查询全部股票
+	All    bool              `protobuf:"varint,2,opt,name=all,proto3" json:"all,omitempty"`
+	Date   string            `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	Limit  int64             `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Mode   QuoteRequest_Mode `protobuf:"varint,5,opt,name=mode,proto3,enum=repository.QuoteRequest_Mode" json:"mode,omitempty"`
+	Adjust Adjust            `protobuf:"varint,6,opt,name=adjust,proto3,enum=repository.Adjust" json:"adjust,omitempty"`
+}
+
+func (x *QuoteBatchRequest) Reset() {
+	*x = QuoteBatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_repository_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *Metadata) GetLow() float64 {
-	if x != nil {
-		return x.Low
+func (x *QuoteBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteBatchRequest) ProtoMessage() {}
+
+func (x *QuoteBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_repository_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *Metadata) GetVolume() uint64 {
+// Deprecated: Use QuoteBatchRequest.ProtoReflect.Descriptor instead.
+func (*QuoteBatchRequest) Descriptor() ([]byte, []int) {
+	return file_repository_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *QuoteBatchRequest) GetCodes() []string {
 	if x != nil {
-		return x.Volume
+		return x.Codes
 	}
-	return 0
+	return nil
 }
 
-func (x *Metadata) GetAccount() float64 {
+func (x *QuoteBatchRequest) GetAll() bool {
 	if x != nil {
-		return x.Account
+		return x.All
 	}
-	return 0
+	return false
 }
 
-func (x *Metadata) GetDate() string {
+func (x *QuoteBatchRequest) GetDate() string {
 	if x != nil {
 		return x.Date
 	}
 	return ""
 }
 
-func (x *Metadata) GetTime() string {
+func (x *QuoteBatchRequest) GetLimit() int64 {
 	if x != nil {
-		return x.Time
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-func (x *Metadata) GetSuspend() string {
+func (x *QuoteBatchRequest) GetMode() QuoteRequest_Mode {
 	if x != nil {
-		return x.Suspend
+		return x.Mode
 	}
-	return ""
+	return QuoteRequest_Day
 }
 
-type Count struct {
+func (x *QuoteBatchRequest) GetAdjust() Adjust {
+	if x != nil {
+		return x.Adjust
+	}
+	return Adjust_FORWARD
+}
+
+type QuoteGroup struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Stock int64 `protobuf:"varint,1,opt,name=stock,proto3" json:"stock,omitempty"`
-	Day   int64 `protobuf:"varint,2,opt,name=day,proto3" json:"day,omitempty"`
-	Week  int64 `protobuf:"varint,3,opt,name=week,proto3" json:"week,omitempty"`
+	Code   string   `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Quotes []*Quote `protobuf:"bytes,2,rep,name=quotes,proto3" json:"quotes,omitempty"`
 }
 
-func (x *Count) Reset() {
-	*x = Count{}
+func (x *QuoteGroup) Reset() {
+	*x = QuoteGroup{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_repository_proto_msgTypes[2]
+		mi := &file_repository_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Count) String() string {
+func (x *QuoteGroup) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Count) ProtoMessage() {}
+func (*QuoteGroup) ProtoMessage() {}
 
-func (x *Count) ProtoReflect() protoreflect.Message {
-	mi := &file_repository_proto_msgTypes[2]
+func (x *QuoteGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_repository_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -311,59 +557,52 @@ func (x *Count) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Count.ProtoReflect.Descriptor instead.
-func (*Count) Descriptor() ([]byte, []int) {
-	return file_repository_proto_rawDescGZIP(), []int{2}
-}
-
-func (x *Count) GetStock() int64 {
-	if x != nil {
-		return x.Stock
-	}
-	return 0
+// Deprecated: Use QuoteGroup.ProtoReflect.Descriptor instead.
+func (*QuoteGroup) Descriptor() ([]byte, []int) {
+	return file_repository_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *Count) GetDay() int64 {
+func (x *QuoteGroup) GetCode() string {
 	if x != nil {
-		return x.Day
+		return x.Code
 	}
-	return 0
+	return ""
 }
 
-func (x *Count) GetWeek() int64 {
+func (x *QuoteGroup) GetQuotes() []*Quote {
 	if x != nil {
-		return x.Week
+		return x.Quotes
 	}
-	return 0
+	return nil
 }
 
-type Stock struct {
+type SnapshotRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Code    string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
-	Name    string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Suspend string `protobuf:"bytes,3,opt,name=suspend,proto3" json:"suspend,omitempty"`
+	Date   string            `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Period QuoteRequest_Mode `protobuf:"varint,2,opt,name=period,proto3,enum=repository.QuoteRequest_Mode" json:"period,omitempty"`
+	Adjust Adjust            `protobuf:"varint,3,opt,name=adjust,proto3,enum=repository.Adjust" json:"adjust,omitempty"`
 }
 
-func (x *Stock) Reset() {
-	*x = Stock{}
+func (x *SnapshotRequest) Reset() {
+	*x = SnapshotRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_repository_proto_msgTypes[3]
+		mi := &file_repository_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Stock) String() string {
+func (x *SnapshotRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Stock) ProtoMessage() {}
+func (*SnapshotRequest) ProtoMessage() {}
 
-func (x *Stock) ProtoReflect() protoreflect.Message {
-	mi := &file_repository_proto_msgTypes[3]
+func (x *SnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_repository_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -374,38 +613,1182 @@ func (x *Stock) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Stock.ProtoReflect.Descriptor instead.
-func (*Stock) Descriptor() ([]byte, []int) {
-	return file_repository_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use SnapshotRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_repository_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *Stock) GetCode() string {
+func (x *SnapshotRequest) GetDate() string {
 	if x != nil {
-		return x.Code
+		return x.Date
 	}
 	return ""
 }
 
-func (x *Stock) GetName() string {
+func (x *SnapshotRequest) GetPeriod() QuoteRequest_Mode {
 	if x != nil {
-		return x.Name
+		return x.Period
 	}
-	return ""
+	return QuoteRequest_Day
 }
 
-func (x *Stock) GetSuspend() string {
+func (x *SnapshotRequest) GetAdjust() Adjust {
 	if x != nil {
-		return x.Suspend
+		return x.Adjust
 	}
-	return ""
+	return Adjust_FORWARD
 }
 
-type Quote struct {
+type Snapshot struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Code            string  `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Code    string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Name    string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Suspend string `protobuf:"bytes,3,opt,name=suspend,proto3" json:"suspend,omitempty"`
+	Quote   *Quote `protobuf:"bytes,4,opt,name=quote,proto3" json:"quote,omitempty"`
+}
+
+func (x *Snapshot) Reset() {
+	*x = Snapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_repository_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snapshot) ProtoMessage() {}
+
+func (x *Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_repository_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snapshot.ProtoReflect.Descriptor instead.
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return file_repository_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Snapshot) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Snapshot) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Snapshot) GetSuspend() string {
+	if x != nil {
+		return x.Suspend
+	}
+	return ""
+}
+
+func (x *Snapshot) GetQuote() *Quote {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+// CorporateAction 公司行为
+// value: 派息为每股现金，送转股、配股为每股股数，拆股为拆分后股数/拆分前股数；price: 配股价
+type CorporateAction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code  string               `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Date  string               `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	Kind  CorporateAction_Kind `protobuf:"varint,3,opt,name=kind,proto3,enum=repository.CorporateAction_Kind" json:"kind,omitempty"`
+	Value float64              `protobuf:"fixed64,4,opt,name=value,proto3" json:"value,omitempty"`
+	Price float64              `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+}
+
+func (x *CorporateAction) Reset() {
+	*x = CorporateAction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_repository_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CorporateAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CorporateAction) ProtoMessage() {}
+
+func (x *CorporateAction) ProtoReflect() protoreflect.Message {
+	mi := &file_repository_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CorporateAction.ProtoReflect.Descriptor instead.
+func (*CorporateAction) Descriptor() ([]byte, []int) {
+	return file_repository_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CorporateAction) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CorporateAction) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *CorporateAction) GetKind() CorporateAction_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return CorporateAction_Dividend
+}
+
+func (x *CorporateAction) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *CorporateAction) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+type CorporateActionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code  string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Begin string `protobuf:"bytes,2,opt,name=begin,proto3" json:"begin,omitempty"`
+	End   string `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+}
+
+func (x *CorporateActionRequest) Reset() {
+	*x = CorporateActionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_repository_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CorporateActionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CorporateActionRequest) ProtoMessage() {}
+
+func (x *CorporateActionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_repository_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CorporateActionRequest.ProtoReflect.Descriptor instead.
+func (*CorporateActionRequest) Descriptor() ([]byte, []int) {
+	return file_repository_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CorporateActionRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CorporateActionRequest) GetBegin() string {
+	if x != nil {
+		return x.Begin
+	}
+	return ""
+}
+
+func (x *CorporateActionRequest) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+type Holiday struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Date string `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *Holiday) Reset() {
+	*x = Holiday{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_repository_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Holiday) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Holiday) ProtoMessage() {}
+
+func (x *Holiday) ProtoReflect() protoreflect.Message {
+	mi := &file_repository_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Holiday.ProtoReflect.Descriptor instead.
+func (*Holiday) Descriptor() ([]byte, []int) {
+	return file_repository_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Holiday) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *Holiday) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type HolidayRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Begin string `protobuf:"bytes,1,opt,name=begin,proto3" json:"begin,omitempty"`
+	End   string `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+}
+
+func (x *HolidayRequest) Reset() {
+	*x = HolidayRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_repository_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HolidayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HolidayRequest) ProtoMessage() {}
+
+func (x *HolidayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_repository_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HolidayRequest.ProtoReflect.Descriptor instead.
+func (*HolidayRequest) Descriptor() ([]byte, []int) {
+	return file_repository_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *HolidayRequest) GetBegin() string {
+	if x != nil {
+		return x.Begin
+	}
+	return ""
+}
+
+func (x *HolidayRequest) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+type MetadataRangeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code  string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Begin string `protobuf:"bytes,2,opt,name=begin,proto3" json:"begin,omitempty"`
+	End   string `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+}
+
+func (x *MetadataRangeRequest) Reset() {
+	*x = MetadataRangeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_repository_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MetadataRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetadataRangeRequest) ProtoMessage() {}
+
+func (x *MetadataRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_repository_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetadataRangeRequest.ProtoReflect.Descriptor instead.
+func (*MetadataRangeRequest) Descriptor() ([]byte, []int) {
+	return file_repository_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *MetadataRangeRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *MetadataRangeRequest) GetBegin() string {
+	if x != nil {
+		return x.Begin
+	}
+	return ""
+}
+
+func (x *MetadataRangeRequest) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+type RejectedRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// code 为空时查询全部
+	Code   string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Offset int64  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// limit 小于等于 0 时不限制
+	Limit int64 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *RejectedRequest) Reset() {
+	*x = RejectedRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_repository_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RejectedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectedRequest) ProtoMessage() {}
+
+func (x *RejectedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_repository_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectedRequest.ProtoReflect.Descriptor instead.
+func (*RejectedRequest) Descriptor() ([]byte, []int) {
+	return file_repository_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RejectedRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *RejectedRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *RejectedRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type RejectedMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id              int64     `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Metadata        *Metadata `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Reason          string    `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreateTimestamp string    `protobuf:"bytes,4,opt,name=create_timestamp,json=createTimestamp,proto3" json:"create_timestamp,omitempty"`
+	ModifyTimestamp string    `protobuf:"bytes,5,opt,name=modify_timestamp,json=modifyTimestamp,proto3" json:"modify_timestamp,omitempty"`
+}
+
+func (x *RejectedMetadata) Reset() {
+	*x = RejectedMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_repository_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RejectedMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectedMetadata) ProtoMessage() {}
+
+func (x *RejectedMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_repository_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectedMetadata.ProtoReflect.Descriptor instead.
+func (*RejectedMetadata) Descriptor() ([]byte, []int) {
+	return file_repository_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RejectedMetadata) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RejectedMetadata) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *RejectedMetadata) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RejectedMetadata) GetCreateTimestamp() string {
+	if x != nil {
+		return x.CreateTimestamp
+	}
+	return ""
+}
+
+func (x *RejectedMetadata) GetModifyTimestamp() string {
+	if x != nil {
+		return x.ModifyTimestamp
+	}
+	return ""
+}
+
+type ReplayRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ids []int64 `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	// all 为 true 时忽略 ids，重放全部
+	All bool `protobuf:"varint,2,opt,name=all,proto3" json:"all,omitempty"`
+}
+
+func (x *ReplayRequest) Reset() {
+	*x = ReplayRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_repository_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayRequest) ProtoMessage() {}
+
+func (x *ReplayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_repository_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayRequest.ProtoReflect.Descriptor instead.
+func (*ReplayRequest) Descriptor() ([]byte, []int) {
+	return file_repository_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ReplayRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *ReplayRequest) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+type Metadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code            string  `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Name            string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Open            float64 `protobuf:"fixed64,3,opt,name=open,proto3" json:"open,omitempty"`
+	YesterdayClosed float64 `protobuf:"fixed64,4,opt,name=yesterday_closed,json=yesterdayClosed,proto3" json:"yesterday_closed,omitempty"`
+	Latest          float64 `protobuf:"fixed64,5,opt,name=latest,proto3" json:"latest,omitempty"`
+	High            float64 `protobuf:"fixed64,6,opt,name=high,proto3" json:"high,omitempty"`
+	Low             float64 `protobuf:"fixed64,7,opt,name=low,proto3" json:"low,omitempty"`
+	Volume          uint64  `protobuf:"varint,8,opt,name=volume,proto3" json:"volume,omitempty"`
+	Account         float64 `protobuf:"fixed64,9,opt,name=account,proto3" json:"account,omitempty"`
+	Date            string  `protobuf:"bytes,10,opt,name=date,proto3" json:"date,omitempty"`
+	Time            string  `protobuf:"bytes,11,opt,name=time,proto3" json:"time,omitempty"`
+	Suspend         string  `protobuf:"bytes,12,opt,name=suspend,proto3" json:"suspend,omitempty"`
+}
+
+func (x *Metadata) Reset() {
+	*x = Metadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_repository_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Metadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Metadata) ProtoMessage() {}
+
+func (x *Metadata) ProtoReflect() protoreflect.Message {
+	mi := &file_repository_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Metadata.ProtoReflect.Descriptor instead.
+func (*Metadata) Descriptor() ([]byte, []int) {
+	return file_repository_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Metadata) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Metadata) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Metadata) GetOpen() float64 {
+	if x != nil {
+		return x.Open
+	}
+	return 0
+}
+
+func (x *Metadata) GetYesterdayClosed() float64 {
+	if x != nil {
+		return x.YesterdayClosed
+	}
+	return 0
+}
+
+func (x *Metadata) GetLatest() float64 {
+	if x != nil {
+		return x.Latest
+	}
+	return 0
+}
+
+func (x *Metadata) GetHigh() float64 {
+	if x != nil {
+		return x.High
+	}
+	return 0
+}
+
+func (x *Metadata) GetLow() float64 {
+	if x != nil {
+		return x.Low
+	}
+	return 0
+}
+
+func (x *Metadata) GetVolume() uint64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *Metadata) GetAccount() float64 {
+	if x != nil {
+		return x.Account
+	}
+	return 0
+}
+
+func (x *Metadata) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *Metadata) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+func (x *Metadata) GetSuspend() string {
+	if x != nil {
+		return x.Suspend
+	}
+	return ""
+}
+
+// Count 写入数量，stock、day 等为新增与更新数量之和
+type Count struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stock    int64       `protobuf:"varint,1,opt,name=stock,proto3" json:"stock,omitempty"`
+	Day      int64       `protobuf:"varint,2,opt,name=day,proto3" json:"day,omitempty"`
+	Week     int64       `protobuf:"varint,3,opt,name=week,proto3" json:"week,omitempty"`
+	Month    int64       `protobuf:"varint,4,opt,name=month,proto3" json:"month,omitempty"`
+	Quarter  int64       `protobuf:"varint,5,opt,name=quarter,proto3" json:"quarter,omitempty"`
+	Year     int64       `protobuf:"varint,6,opt,name=year,proto3" json:"year,omitempty"`
+	Rejected []*Rejected `protobuf:"bytes,7,rep,name=rejected,proto3" json:"rejected,omitempty"`
+	Inserted *Affected   `protobuf:"bytes,8,opt,name=inserted,proto3" json:"inserted,omitempty"`
+	Updated  *Affected   `protobuf:"bytes,9,opt,name=updated,proto3" json:"updated,omitempty"`
+}
+
+func (x *Count) Reset() {
+	*x = Count{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_repository_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Count) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Count) ProtoMessage() {}
+
+func (x *Count) ProtoReflect() protoreflect.Message {
+	mi := &file_repository_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Count.ProtoReflect.Descriptor instead.
+func (*Count) Descriptor() ([]byte, []int) {
+	return file_repository_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Count) GetStock() int64 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+func (x *Count) GetDay() int64 {
+	if x != nil {
+		return x.Day
+	}
+	return 0
+}
+
+func (x *Count) GetWeek() int64 {
+	if x != nil {
+		return x.Week
+	}
+	return 0
+}
+
+func (x *Count) GetMonth() int64 {
+	if x != nil {
+		return x.Month
+	}
+	return 0
+}
+
+func (x *Count) GetQuarter() int64 {
+	if x != nil {
+		return x.Quarter
+	}
+	return 0
+}
+
+func (x *Count) GetYear() int64 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *Count) GetRejected() []*Rejected {
+	if x != nil {
+		return x.Rejected
+	}
+	return nil
+}
+
+func (x *Count) GetInserted() *Affected {
+	if x != nil {
+		return x.Inserted
+	}
+	return nil
+}
+
+func (x *Count) GetUpdated() *Affected {
+	if x != nil {
+		return x.Updated
+	}
+	return nil
+}
+
+// Affected 各类数据的新增或更新数量，stock 仅在名称变化时更新
+type Affected struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stock   int64 `protobuf:"varint,1,opt,name=stock,proto3" json:"stock,omitempty"`
+	Day     int64 `protobuf:"varint,2,opt,name=day,proto3" json:"day,omitempty"`
+	Week    int64 `protobuf:"varint,3,opt,name=week,proto3" json:"week,omitempty"`
+	Month   int64 `protobuf:"varint,4,opt,name=month,proto3" json:"month,omitempty"`
+	Quarter int64 `protobuf:"varint,5,opt,name=quarter,proto3" json:"quarter,omitempty"`
+	Year    int64 `protobuf:"varint,6,opt,name=year,proto3" json:"year,omitempty"`
+}
+
+func (x *Affected) Reset() {
+	*x = Affected{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_repository_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Affected) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Affected) ProtoMessage() {}
+
+func (x *Affected) ProtoReflect() protoreflect.Message {
+	mi := &file_repository_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Affected.ProtoReflect.Descriptor instead.
+func (*Affected) Descriptor() ([]byte, []int) {
+	return file_repository_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Affected) GetStock() int64 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+func (x *Affected) GetDay() int64 {
+	if x != nil {
+		return x.Day
+	}
+	return 0
+}
+
+func (x *Affected) GetWeek() int64 {
+	if x != nil {
+		return x.Week
+	}
+	return 0
+}
+
+func (x *Affected) GetMonth() int64 {
+	if x != nil {
+		return x.Month
+	}
+	return 0
+}
+
+func (x *Affected) GetQuarter() int64 {
+	if x != nil {
+		return x.Quarter
+	}
+	return 0
+}
+
+func (x *Affected) GetYear() int64 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+// Rejected 未能写入的数据及原因
+type Rejected struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code   string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Date   string `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *Rejected) Reset() {
+	*x = Rejected{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_repository_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Rejected) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rejected) ProtoMessage() {}
+
+func (x *Rejected) ProtoReflect() protoreflect.Message {
+	mi := &file_repository_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rejected.ProtoReflect.Descriptor instead.
+func (*Rejected) Descriptor() ([]byte, []int) {
+	return file_repository_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Rejected) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Rejected) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *Rejected) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type StockRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status StockRequest_Status `protobuf:"varint,1,opt,name=status,proto3,enum=repository.StockRequest_Status" json:"status,omitempty"`
+}
+
+func (x *StockRequest) Reset() {
+	*x = StockRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_repository_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockRequest) ProtoMessage() {}
+
+func (x *StockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_repository_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockRequest.ProtoReflect.Descriptor instead.
+func (*StockRequest) Descriptor() ([]byte, []int) {
+	return file_repository_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *StockRequest) GetStatus() StockRequest_Status {
+	if x != nil {
+		return x.Status
+	}
+	return StockRequest_ALL
+}
+
+type SuspensionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Date string `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+}
+
+func (x *SuspensionRequest) Reset() {
+	*x = SuspensionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_repository_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SuspensionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuspensionRequest) ProtoMessage() {}
+
+func (x *SuspensionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_repository_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuspensionRequest.ProtoReflect.Descriptor instead.
+func (*SuspensionRequest) Descriptor() ([]byte, []int) {
+	return file_repository_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SuspensionRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+type Stock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code    string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Name    string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Suspend string `protobuf:"bytes,3,opt,name=suspend,proto3" json:"suspend,omitempty"`
+}
+
+func (x *Stock) Reset() {
+	*x = Stock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_repository_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Stock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stock) ProtoMessage() {}
+
+func (x *Stock) ProtoReflect() protoreflect.Message {
+	mi := &file_repository_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stock.ProtoReflect.Descriptor instead.
+func (*Stock) Descriptor() ([]byte, []int) {
+	return file_repository_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Stock) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Stock) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Stock) GetSuspend() string {
+	if x != nil {
+		return x.Suspend
+	}
+	return ""
+}
+
+type Quote struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code            string  `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
 	Open            float64 `protobuf:"fixed64,2,opt,name=open,proto3" json:"open,omitempty"`
 	Close           float64 `protobuf:"fixed64,3,opt,name=close,proto3" json:"close,omitempty"`
 	High            float64 `protobuf:"fixed64,4,opt,name=high,proto3" json:"high,omitempty"`
@@ -417,23 +1800,141 @@ type Quote struct {
 	NumOfYear       int32   `protobuf:"varint,10,opt,name=num_of_year,json=numOfYear,proto3" json:"num_of_year,omitempty"`
 }
 
-func (x *Quote) Reset() {
-	*x = Quote{}
+func (x *Quote) Reset() {
+	*x = Quote{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_repository_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Quote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quote) ProtoMessage() {}
+
+func (x *Quote) ProtoReflect() protoreflect.Message {
+	mi := &file_repository_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quote.ProtoReflect.Descriptor instead.
+func (*Quote) Descriptor() ([]byte, []int) {
+	return file_repository_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Quote) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Quote) GetOpen() float64 {
+	if x != nil {
+		return x.Open
+	}
+	return 0
+}
+
+func (x *Quote) GetClose() float64 {
+	if x != nil {
+		return x.Close
+	}
+	return 0
+}
+
+func (x *Quote) GetHigh() float64 {
+	if x != nil {
+		return x.High
+	}
+	return 0
+}
+
+func (x *Quote) GetLow() float64 {
+	if x != nil {
+		return x.Low
+	}
+	return 0
+}
+
+func (x *Quote) GetYesterdayClosed() float64 {
+	if x != nil {
+		return x.YesterdayClosed
+	}
+	return 0
+}
+
+func (x *Quote) GetVolume() uint64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *Quote) GetAccount() float64 {
+	if x != nil {
+		return x.Account
+	}
+	return 0
+}
+
+func (x *Quote) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *Quote) GetNumOfYear() int32 {
+	if x != nil {
+		return x.NumOfYear
+	}
+	return 0
+}
+
+type Task struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Date          string            `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	MetadataCount int64             `protobuf:"varint,2,opt,name=metadata_count,json=metadataCount,proto3" json:"metadata_count,omitempty"`
+	StockCount    int64             `protobuf:"varint,3,opt,name=stock_count,json=stockCount,proto3" json:"stock_count,omitempty"`
+	DayCount      int64             `protobuf:"varint,4,opt,name=day_count,json=dayCount,proto3" json:"day_count,omitempty"`
+	WeekCount     int64             `protobuf:"varint,5,opt,name=week_count,json=weekCount,proto3" json:"week_count,omitempty"`
+	CallbackUrl   string            `protobuf:"bytes,6,opt,name=callback_url,json=callbackUrl,proto3" json:"callback_url,omitempty"`
+	CallbackMode  Task_CallbackMode `protobuf:"varint,7,opt,name=callback_mode,json=callbackMode,proto3,enum=repository.Task_CallbackMode" json:"callback_mode,omitempty"`
+	// callback_secret POST 回调的签名密钥，签名为 sha256=hex(HMAC-SHA256(callback_secret, body))，通过 X-Robber-Signature 头传递
+	CallbackSecret string `protobuf:"bytes,8,opt,name=callback_secret,json=callbackSecret,proto3" json:"callback_secret,omitempty"`
+}
+
+func (x *Task) Reset() {
+	*x = Task{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_repository_proto_msgTypes[4]
+		mi := &file_repository_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Quote) String() string {
+func (x *Task) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Quote) ProtoMessage() {}
+func (*Task) ProtoMessage() {}
 
-func (x *Quote) ProtoReflect() protoreflect.Message {
-	mi := &file_repository_proto_msgTypes[4]
+func (x *Task) ProtoReflect() protoreflect.Message {
+	mi := &file_repository_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -444,111 +1945,402 @@ func (x *Quote) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Quote.ProtoReflect.Descriptor instead.
-func (*Quote) Descriptor() ([]byte, []int) {
-	return file_repository_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use Task.ProtoReflect.Descriptor instead.
+func (*Task) Descriptor() ([]byte, []int) {
+	return file_repository_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *Quote) GetCode() string {
+func (x *Task) GetDate() string {
 	if x != nil {
-		return x.Code
+		return x.Date
 	}
 	return ""
 }
 
-func (x *Quote) GetOpen() float64 {
+func (x *Task) GetMetadataCount() int64 {
 	if x != nil {
-		return x.Open
+		return x.MetadataCount
 	}
 	return 0
 }
 
-func (x *Quote) GetClose() float64 {
+func (x *Task) GetStockCount() int64 {
 	if x != nil {
-		return x.Close
+		return x.StockCount
 	}
 	return 0
 }
 
-func (x *Quote) GetHigh() float64 {
+func (x *Task) GetDayCount() int64 {
 	if x != nil {
-		return x.High
+		return x.DayCount
 	}
 	return 0
 }
 
-func (x *Quote) GetLow() float64 {
+func (x *Task) GetWeekCount() int64 {
 	if x != nil {
-		return x.Low
+		return x.WeekCount
 	}
 	return 0
 }
 
-func (x *Quote) GetYesterdayClosed() float64 {
+func (x *Task) GetCallbackUrl() string {
 	if x != nil {
-		return x.YesterdayClosed
+		return x.CallbackUrl
+	}
+	return ""
+}
+
+func (x *Task) GetCallbackMode() Task_CallbackMode {
+	if x != nil {
+		return x.CallbackMode
+	}
+	return Task_GET
+}
+
+func (x *Task) GetCallbackSecret() string {
+	if x != nil {
+		return x.CallbackSecret
+	}
+	return ""
+}
+
+type TaskListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// from、to 为空时不限制，格式为 2006-01-02
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// state 为空时查询全部，可选值为 created、ingesting、verifying、callback_pending、completed、failed
+	State  string `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	Offset int64  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	// limit 小于等于 0 时不限制
+	Limit int64 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *TaskListRequest) Reset() {
+	*x = TaskListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_repository_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaskListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskListRequest) ProtoMessage() {}
+
+func (x *TaskListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_repository_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskListRequest.ProtoReflect.Descriptor instead.
+func (*TaskListRequest) Descriptor() ([]byte, []int) {
+	return file_repository_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *TaskListRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *TaskListRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *TaskListRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *TaskListRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
 	}
 	return 0
 }
 
-func (x *Quote) GetVolume() uint64 {
+func (x *TaskListRequest) GetLimit() int64 {
 	if x != nil {
-		return x.Volume
+		return x.Limit
 	}
 	return 0
 }
 
-func (x *Quote) GetAccount() float64 {
+// TaskInfo task 的状态及各状态的时间，未进入过的状态时间为空
+type TaskInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Date                     string `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	State                    string `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	LastError                string `protobuf:"bytes,3,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	MetadataCount            int64  `protobuf:"varint,4,opt,name=metadata_count,json=metadataCount,proto3" json:"metadata_count,omitempty"`
+	StockCount               int64  `protobuf:"varint,5,opt,name=stock_count,json=stockCount,proto3" json:"stock_count,omitempty"`
+	DayCount                 int64  `protobuf:"varint,6,opt,name=day_count,json=dayCount,proto3" json:"day_count,omitempty"`
+	WeekCount                int64  `protobuf:"varint,7,opt,name=week_count,json=weekCount,proto3" json:"week_count,omitempty"`
+	CallbackUrl              string `protobuf:"bytes,8,opt,name=callback_url,json=callbackUrl,proto3" json:"callback_url,omitempty"`
+	CreateTimestamp          string `protobuf:"bytes,9,opt,name=create_timestamp,json=createTimestamp,proto3" json:"create_timestamp,omitempty"`
+	IngestingTimestamp       string `protobuf:"bytes,10,opt,name=ingesting_timestamp,json=ingestingTimestamp,proto3" json:"ingesting_timestamp,omitempty"`
+	VerifyingTimestamp       string `protobuf:"bytes,11,opt,name=verifying_timestamp,json=verifyingTimestamp,proto3" json:"verifying_timestamp,omitempty"`
+	CallbackPendingTimestamp string `protobuf:"bytes,12,opt,name=callback_pending_timestamp,json=callbackPendingTimestamp,proto3" json:"callback_pending_timestamp,omitempty"`
+	CompletedTimestamp       string `protobuf:"bytes,13,opt,name=completed_timestamp,json=completedTimestamp,proto3" json:"completed_timestamp,omitempty"`
+	FailedTimestamp          string `protobuf:"bytes,14,opt,name=failed_timestamp,json=failedTimestamp,proto3" json:"failed_timestamp,omitempty"`
+	ModifyTimestamp          string `protobuf:"bytes,15,opt,name=modify_timestamp,json=modifyTimestamp,proto3" json:"modify_timestamp,omitempty"`
+	// callback_attempts 本轮已投递的回调次数，next_callback_timestamp 下次投递时间
+	CallbackAttempts      int64  `protobuf:"varint,16,opt,name=callback_attempts,json=callbackAttempts,proto3" json:"callback_attempts,omitempty"`
+	NextCallbackTimestamp string `protobuf:"bytes,17,opt,name=next_callback_timestamp,json=nextCallbackTimestamp,proto3" json:"next_callback_timestamp,omitempty"`
+	// callback_mode 回调方式，可选值为 get、post
+	CallbackMode string `protobuf:"bytes,18,opt,name=callback_mode,json=callbackMode,proto3" json:"callback_mode,omitempty"`
+	// actual_xxx_count 为 Complete 时服务端按 date 统计的数量，与上报数量的差异超出容差时 inconsistent 为 true
+	ActualStockCount int64 `protobuf:"varint,19,opt,name=actual_stock_count,json=actualStockCount,proto3" json:"actual_stock_count,omitempty"`
+	ActualDayCount   int64 `protobuf:"varint,20,opt,name=actual_day_count,json=actualDayCount,proto3" json:"actual_day_count,omitempty"`
+	ActualWeekCount  int64 `protobuf:"varint,21,opt,name=actual_week_count,json=actualWeekCount,proto3" json:"actual_week_count,omitempty"`
+	Inconsistent     bool  `protobuf:"varint,22,opt,name=inconsistent,proto3" json:"inconsistent,omitempty"`
+}
+
+func (x *TaskInfo) Reset() {
+	*x = TaskInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_repository_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaskInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskInfo) ProtoMessage() {}
+
+func (x *TaskInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_repository_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskInfo.ProtoReflect.Descriptor instead.
+func (*TaskInfo) Descriptor() ([]byte, []int) {
+	return file_repository_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *TaskInfo) GetDate() string {
 	if x != nil {
-		return x.Account
+		return x.Date
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetMetadataCount() int64 {
+	if x != nil {
+		return x.MetadataCount
 	}
 	return 0
 }
 
-func (x *Quote) GetDate() string {
+func (x *TaskInfo) GetStockCount() int64 {
+	if x != nil {
+		return x.StockCount
+	}
+	return 0
+}
+
+func (x *TaskInfo) GetDayCount() int64 {
+	if x != nil {
+		return x.DayCount
+	}
+	return 0
+}
+
+func (x *TaskInfo) GetWeekCount() int64 {
+	if x != nil {
+		return x.WeekCount
+	}
+	return 0
+}
+
+func (x *TaskInfo) GetCallbackUrl() string {
+	if x != nil {
+		return x.CallbackUrl
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetCreateTimestamp() string {
+	if x != nil {
+		return x.CreateTimestamp
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetIngestingTimestamp() string {
+	if x != nil {
+		return x.IngestingTimestamp
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetVerifyingTimestamp() string {
+	if x != nil {
+		return x.VerifyingTimestamp
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetCallbackPendingTimestamp() string {
+	if x != nil {
+		return x.CallbackPendingTimestamp
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetCompletedTimestamp() string {
+	if x != nil {
+		return x.CompletedTimestamp
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetFailedTimestamp() string {
+	if x != nil {
+		return x.FailedTimestamp
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetModifyTimestamp() string {
+	if x != nil {
+		return x.ModifyTimestamp
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetCallbackAttempts() int64 {
+	if x != nil {
+		return x.CallbackAttempts
+	}
+	return 0
+}
+
+func (x *TaskInfo) GetNextCallbackTimestamp() string {
+	if x != nil {
+		return x.NextCallbackTimestamp
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetCallbackMode() string {
+	if x != nil {
+		return x.CallbackMode
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetActualStockCount() int64 {
+	if x != nil {
+		return x.ActualStockCount
+	}
+	return 0
+}
+
+func (x *TaskInfo) GetActualDayCount() int64 {
+	if x != nil {
+		return x.ActualDayCount
+	}
+	return 0
+}
+
+func (x *TaskInfo) GetActualWeekCount() int64 {
 	if x != nil {
-		return x.Date
+		return x.ActualWeekCount
 	}
-	return ""
+	return 0
 }
 
-func (x *Quote) GetNumOfYear() int32 {
+func (x *TaskInfo) GetInconsistent() bool {
 	if x != nil {
-		return x.NumOfYear
+		return x.Inconsistent
 	}
-	return 0
+	return false
 }
 
-type Task struct {
+// CallbackAttempt task 的一次回调投递，status_code 为 0 表示请求未得到响应，latency 单位为毫秒
+type CallbackAttempt struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Date          string `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
-	MetadataCount int64  `protobuf:"varint,2,opt,name=metadata_count,json=metadataCount,proto3" json:"metadata_count,omitempty"`
-	StockCount    int64  `protobuf:"varint,3,opt,name=stock_count,json=stockCount,proto3" json:"stock_count,omitempty"`
-	DayCount      int64  `protobuf:"varint,4,opt,name=day_count,json=dayCount,proto3" json:"day_count,omitempty"`
-	WeekCount     int64  `protobuf:"varint,5,opt,name=week_count,json=weekCount,proto3" json:"week_count,omitempty"`
-	CallbackUrl   string `protobuf:"bytes,6,opt,name=callback_url,json=callbackUrl,proto3" json:"callback_url,omitempty"`
+	Id              int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Date            string `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	Attempt         int64  `protobuf:"varint,3,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	Url             string `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	StatusCode      int64  `protobuf:"varint,5,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	Body            string `protobuf:"bytes,6,opt,name=body,proto3" json:"body,omitempty"`
+	Latency         int64  `protobuf:"varint,7,opt,name=latency,proto3" json:"latency,omitempty"`
+	Error           string `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	CreateTimestamp string `protobuf:"bytes,9,opt,name=create_timestamp,json=createTimestamp,proto3" json:"create_timestamp,omitempty"`
 }
 
-func (x *Task) Reset() {
-	*x = Task{}
+func (x *CallbackAttempt) Reset() {
+	*x = CallbackAttempt{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_repository_proto_msgTypes[5]
+		mi := &file_repository_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Task) String() string {
+func (x *CallbackAttempt) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Task) ProtoMessage() {}
+func (*CallbackAttempt) ProtoMessage() {}
 
-func (x *Task) ProtoReflect() protoreflect.Message {
-	mi := &file_repository_proto_msgTypes[5]
+func (x *CallbackAttempt) ProtoReflect() protoreflect.Message {
+	mi := &file_repository_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -559,49 +2351,70 @@ func (x *Task) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Task.ProtoReflect.Descriptor instead.
-func (*Task) Descriptor() ([]byte, []int) {
-	return file_repository_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use CallbackAttempt.ProtoReflect.Descriptor instead.
+func (*CallbackAttempt) Descriptor() ([]byte, []int) {
+	return file_repository_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *Task) GetDate() string {
+func (x *CallbackAttempt) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CallbackAttempt) GetDate() string {
 	if x != nil {
 		return x.Date
 	}
 	return ""
 }
 
-func (x *Task) GetMetadataCount() int64 {
+func (x *CallbackAttempt) GetAttempt() int64 {
 	if x != nil {
-		return x.MetadataCount
+		return x.Attempt
 	}
 	return 0
 }
 
-func (x *Task) GetStockCount() int64 {
+func (x *CallbackAttempt) GetUrl() string {
 	if x != nil {
-		return x.StockCount
+		return x.Url
 	}
-	return 0
+	return ""
 }
 
-func (x *Task) GetDayCount() int64 {
+func (x *CallbackAttempt) GetStatusCode() int64 {
 	if x != nil {
-		return x.DayCount
+		return x.StatusCode
 	}
 	return 0
 }
 
-func (x *Task) GetWeekCount() int64 {
+func (x *CallbackAttempt) GetBody() string {
 	if x != nil {
-		return x.WeekCount
+		return x.Body
+	}
+	return ""
+}
+
+func (x *CallbackAttempt) GetLatency() int64 {
+	if x != nil {
+		return x.Latency
 	}
 	return 0
 }
 
-func (x *Task) GetCallbackUrl() string {
+func (x *CallbackAttempt) GetError() string {
 	if x != nil {
-		return x.CallbackUrl
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CallbackAttempt) GetCreateTimestamp() string {
+	if x != nil {
+		return x.CreateTimestamp
 	}
 	return ""
 }
@@ -614,7 +2427,7 @@ var file_repository_proto_rawDesc = []byte{
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
 	0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b,
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
-	0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x9a, 0x01, 0x0a, 0x0c,
+	0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe8, 0x01, 0x0a, 0x0c,
 	0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
 	0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65,
 	0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
@@ -622,89 +2435,394 @@ var file_repository_proto_rawDesc = []byte{
 	0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x31, 0x0a, 0x04, 0x6d, 0x6f,
 	0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73,
 	0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x22, 0x19, 0x0a,
-	0x04, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x44, 0x61, 0x79, 0x10, 0x00, 0x12, 0x08,
-	0x0a, 0x04, 0x57, 0x65, 0x65, 0x6b, 0x10, 0x01, 0x22, 0xa3, 0x02, 0x0a, 0x08, 0x4d, 0x65, 0x74,
-	0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a,
-	0x04, 0x6f, 0x70, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x6f, 0x70, 0x65,
-	0x6e, 0x12, 0x29, 0x0a, 0x10, 0x79, 0x65, 0x73, 0x74, 0x65, 0x72, 0x64, 0x61, 0x79, 0x5f, 0x63,
-	0x6c, 0x6f, 0x73, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x79, 0x65, 0x73,
-	0x74, 0x65, 0x72, 0x64, 0x61, 0x79, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06,
-	0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x6c, 0x61,
-	0x74, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x69, 0x67, 0x68, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x01, 0x52, 0x04, 0x68, 0x69, 0x67, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x6f, 0x77, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6c, 0x6f, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f,
-	0x6c, 0x75, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75,
-	0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x09, 0x20,
-	0x01, 0x28, 0x01, 0x52, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04,
-	0x64, 0x61, 0x74, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65,
-	0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x74, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x18,
-	0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x22, 0x43,
-	0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x6f, 0x63, 0x6b,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x12, 0x10, 0x0a,
-	0x03, 0x64, 0x61, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x64, 0x61, 0x79, 0x12,
-	0x12, 0x0a, 0x04, 0x77, 0x65, 0x65, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x77,
-	0x65, 0x65, 0x6b, 0x22, 0x49, 0x0a, 0x05, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x12, 0x12, 0x0a, 0x04,
+	0x73, 0x74, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x2a, 0x0a,
+	0x06, 0x61, 0x64, 0x6a, 0x75, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e,
+	0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x41, 0x64, 0x6a, 0x75, 0x73,
+	0x74, 0x52, 0x06, 0x61, 0x64, 0x6a, 0x75, 0x73, 0x74, 0x22, 0x3b, 0x0a, 0x04, 0x4d, 0x6f, 0x64,
+	0x65, 0x12, 0x07, 0x0a, 0x03, 0x44, 0x61, 0x79, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x57, 0x65,
+	0x65, 0x6b, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x4d, 0x6f, 0x6e, 0x74, 0x68, 0x10, 0x02, 0x12,
+	0x0b, 0x0a, 0x07, 0x51, 0x75, 0x61, 0x72, 0x74, 0x65, 0x72, 0x10, 0x03, 0x12, 0x08, 0x0a, 0x04,
+	0x59, 0x65, 0x61, 0x72, 0x10, 0x04, 0x22, 0xae, 0x01, 0x0a, 0x11, 0x51, 0x75, 0x6f, 0x74, 0x65,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
 	0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65,
-	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x22, 0xfc,
-	0x01, 0x0a, 0x05, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04,
-	0x6f, 0x70, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x6f, 0x70, 0x65, 0x6e,
-	0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52,
-	0x05, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x69, 0x67, 0x68, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x68, 0x69, 0x67, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x6f,
-	0x77, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6c, 0x6f, 0x77, 0x12, 0x29, 0x0a, 0x10,
-	0x79, 0x65, 0x73, 0x74, 0x65, 0x72, 0x64, 0x61, 0x79, 0x5f, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x79, 0x65, 0x73, 0x74, 0x65, 0x72, 0x64, 0x61,
-	0x79, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d,
-	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12,
-	0x18, 0x0a, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01,
-	0x52, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74,
-	0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x0a,
-	0x0b, 0x6e, 0x75, 0x6d, 0x5f, 0x6f, 0x66, 0x5f, 0x79, 0x65, 0x61, 0x72, 0x18, 0x0a, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x09, 0x6e, 0x75, 0x6d, 0x4f, 0x66, 0x59, 0x65, 0x61, 0x72, 0x22, 0xc1, 0x01,
-	0x0a, 0x04, 0x54, 0x61, 0x73, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x0d, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x75, 0x6e,
-	0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x43, 0x6f, 0x75,
-	0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x61, 0x79, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x61, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
-	0x1d, 0x0a, 0x0a, 0x77, 0x65, 0x65, 0x6b, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x09, 0x77, 0x65, 0x65, 0x6b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x21,
-	0x0a, 0x0c, 0x63, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x55, 0x72,
-	0x6c, 0x32, 0xf9, 0x02, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x41, 0x0a,
-	0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x1a, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x00,
-	0x12, 0x38, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x61, 0x73, 0x6b, 0x12, 0x10,
-	0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x54, 0x61, 0x73, 0x6b,
-	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x08, 0x43, 0x6f,
-	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x10, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
-	0x6f, 0x72, 0x79, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x22, 0x00, 0x12, 0x37, 0x0a, 0x08, 0x50, 0x75, 0x73, 0x68, 0x44, 0x61, 0x74, 0x61, 0x12, 0x14,
-	0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x4d, 0x65, 0x74, 0x61,
-	0x64, 0x61, 0x74, 0x61, 0x1a, 0x11, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72,
-	0x79, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x00, 0x28, 0x01, 0x12, 0x3d, 0x0a, 0x0c, 0x47,
-	0x65, 0x74, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x46, 0x75, 0x6c, 0x6c, 0x12, 0x16, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79,
-	0x2e, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x22, 0x00, 0x30, 0x01, 0x12, 0x41, 0x0a, 0x0e, 0x47, 0x65,
-	0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x4c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x12, 0x18, 0x2e, 0x72,
-	0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
-	0x6f, 0x72, 0x79, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x22, 0x00, 0x30, 0x01, 0x42, 0x07, 0x5a,
-	0x05, 0x2e, 0x2f, 0x3b, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x12, 0x14, 0x0a, 0x05, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x31, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x6f, 0x72, 0x79, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x61,
+	0x64, 0x6a, 0x75, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x72, 0x65,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x41, 0x64, 0x6a, 0x75, 0x73, 0x74, 0x52,
+	0x06, 0x61, 0x64, 0x6a, 0x75, 0x73, 0x74, 0x22, 0xc4, 0x01, 0x0a, 0x11, 0x51, 0x75, 0x6f, 0x74,
+	0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6f,
+	0x64, 0x65, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x6c, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x03, 0x61, 0x6c, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12,
+	0x31, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e,
+	0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f,
+	0x64, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x61, 0x64, 0x6a, 0x75, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x12, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e,
+	0x41, 0x64, 0x6a, 0x75, 0x73, 0x74, 0x52, 0x06, 0x61, 0x64, 0x6a, 0x75, 0x73, 0x74, 0x22, 0x4b,
+	0x0a, 0x0a, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x12, 0x0a, 0x04,
+	0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65,
+	0x12, 0x29, 0x0a, 0x06, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x51, 0x75,
+	0x6f, 0x74, 0x65, 0x52, 0x06, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x73, 0x22, 0x88, 0x01, 0x0a, 0x0f,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64,
+	0x61, 0x74, 0x65, 0x12, 0x35, 0x0a, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79,
+	0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x6f,
+	0x64, 0x65, 0x52, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x2a, 0x0a, 0x06, 0x61, 0x64,
+	0x6a, 0x75, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x72, 0x65, 0x70,
+	0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x41, 0x64, 0x6a, 0x75, 0x73, 0x74, 0x52, 0x06,
+	0x61, 0x64, 0x6a, 0x75, 0x73, 0x74, 0x22, 0x75, 0x0a, 0x08, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75,
+	0x73, 0x70, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x73,
+	0x70, 0x65, 0x6e, 0x64, 0x12, 0x27, 0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79,
+	0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x22, 0xd3, 0x01,
+	0x0a, 0x0f, 0x43, 0x6f, 0x72, 0x70, 0x6f, 0x72, 0x61, 0x74, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x34, 0x0a, 0x04, 0x6b, 0x69, 0x6e,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x6f, 0x72, 0x79, 0x2e, 0x43, 0x6f, 0x72, 0x70, 0x6f, 0x72, 0x61, 0x74, 0x65, 0x41, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x22, 0x36, 0x0a, 0x04, 0x4b,
+	0x69, 0x6e, 0x64, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x69, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x64, 0x10,
+	0x00, 0x12, 0x09, 0x0a, 0x05, 0x42, 0x6f, 0x6e, 0x75, 0x73, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05,
+	0x53, 0x70, 0x6c, 0x69, 0x74, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x52, 0x69, 0x67, 0x68, 0x74,
+	0x73, 0x10, 0x03, 0x22, 0x54, 0x0a, 0x16, 0x43, 0x6f, 0x72, 0x70, 0x6f, 0x72, 0x61, 0x74, 0x65,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x22, 0x31, 0x0a, 0x07, 0x48, 0x6f, 0x6c,
+	0x69, 0x64, 0x61, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x38, 0x0a, 0x0e,
+	0x48, 0x6f, 0x6c, 0x69, 0x64, 0x61, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x62,
+	0x65, 0x67, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x22, 0x52, 0x0a, 0x14, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f,
+	0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x22, 0x53, 0x0a, 0x0f, 0x52, 0x65,
+	0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22,
+	0xc2, 0x01, 0x0a, 0x10, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x30, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x6f, 0x72, 0x79, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x29,
+	0x0a, 0x10, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x29, 0x0a, 0x10, 0x6d, 0x6f, 0x64,
+	0x69, 0x66, 0x79, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0f, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x22, 0x33, 0x0a, 0x0d, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x03, 0x52, 0x03, 0x69, 0x64, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x6c, 0x6c, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x61, 0x6c, 0x6c, 0x22, 0xa3, 0x02, 0x0a, 0x08, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x6f, 0x70, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x6f, 0x70,
+	0x65, 0x6e, 0x12, 0x29, 0x0a, 0x10, 0x79, 0x65, 0x73, 0x74, 0x65, 0x72, 0x64, 0x61, 0x79, 0x5f,
+	0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x79, 0x65,
+	0x73, 0x74, 0x65, 0x72, 0x64, 0x61, 0x79, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x6c,
+	0x61, 0x74, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x69, 0x67, 0x68, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x04, 0x68, 0x69, 0x67, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x6f, 0x77,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6c, 0x6f, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x76,
+	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x76, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x73, 0x70, 0x65, 0x6e, 0x64,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x22,
+	0x9b, 0x02, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x6f,
+	0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x12,
+	0x10, 0x0a, 0x03, 0x64, 0x61, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x64, 0x61,
+	0x79, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x65, 0x65, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x04, 0x77, 0x65, 0x65, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x71,
+	0x75, 0x61, 0x72, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x71, 0x75,
+	0x61, 0x72, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x79, 0x65, 0x61, 0x72, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x04, 0x79, 0x65, 0x61, 0x72, 0x12, 0x30, 0x0a, 0x08, 0x72, 0x65, 0x6a,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x72, 0x65,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x52, 0x08, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x30, 0x0a, 0x08, 0x69,
+	0x6e, 0x73, 0x65, 0x72, 0x74, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x41, 0x66, 0x66, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x65, 0x64, 0x12, 0x2e, 0x0a,
+	0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x41, 0x66, 0x66, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x52, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x22, 0x8a, 0x01,
+	0x0a, 0x08, 0x41, 0x66, 0x66, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74,
+	0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x6f, 0x63, 0x6b,
+	0x12, 0x10, 0x0a, 0x03, 0x64, 0x61, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x64,
+	0x61, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x65, 0x65, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x04, 0x77, 0x65, 0x65, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x12, 0x18, 0x0a, 0x07,
+	0x71, 0x75, 0x61, 0x72, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x71,
+	0x75, 0x61, 0x72, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x79, 0x65, 0x61, 0x72, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x79, 0x65, 0x61, 0x72, 0x22, 0x4a, 0x0a, 0x08, 0x52, 0x65,
+	0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x75, 0x0a, 0x0c, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x6f, 0x72, 0x79, 0x2e, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22,
+	0x2c, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x4c, 0x4c,
+	0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x4e, 0x4f, 0x52, 0x4d, 0x41, 0x4c, 0x10, 0x01, 0x12, 0x0d,
+	0x0a, 0x09, 0x53, 0x55, 0x53, 0x50, 0x45, 0x4e, 0x44, 0x45, 0x44, 0x10, 0x02, 0x22, 0x27, 0x0a,
+	0x11, 0x53, 0x75, 0x73, 0x70, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x22, 0x49, 0x0a, 0x05, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x12,
+	0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63,
+	0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x73, 0x70, 0x65,
+	0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x73, 0x70, 0x65, 0x6e,
+	0x64, 0x22, 0xfc, 0x01, 0x0a, 0x05, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x6f, 0x70, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x6f,
+	0x70, 0x65, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x05, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x69, 0x67,
+	0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x68, 0x69, 0x67, 0x68, 0x12, 0x10, 0x0a,
+	0x03, 0x6c, 0x6f, 0x77, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6c, 0x6f, 0x77, 0x12,
+	0x29, 0x0a, 0x10, 0x79, 0x65, 0x73, 0x74, 0x65, 0x72, 0x64, 0x61, 0x79, 0x5f, 0x63, 0x6c, 0x6f,
+	0x73, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x79, 0x65, 0x73, 0x74, 0x65,
+	0x72, 0x64, 0x61, 0x79, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x64, 0x61, 0x74, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65,
+	0x12, 0x1e, 0x0a, 0x0b, 0x6e, 0x75, 0x6d, 0x5f, 0x6f, 0x66, 0x5f, 0x79, 0x65, 0x61, 0x72, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6e, 0x75, 0x6d, 0x4f, 0x66, 0x59, 0x65, 0x61, 0x72,
+	0x22, 0xd1, 0x02, 0x0a, 0x04, 0x54, 0x61, 0x73, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x25, 0x0a,
+	0x0e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x5f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x74, 0x6f, 0x63, 0x6b,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x61, 0x79, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x61, 0x79, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x65, 0x65, 0x6b, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x77, 0x65, 0x65, 0x6b, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x75, 0x72,
+	0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63,
+	0x6b, 0x55, 0x72, 0x6c, 0x12, 0x42, 0x0a, 0x0d, 0x63, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b,
+	0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x72, 0x65,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e, 0x43, 0x61,
+	0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x0c, 0x63, 0x61, 0x6c, 0x6c,
+	0x62, 0x61, 0x63, 0x6b, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x61, 0x6c, 0x6c,
+	0x62, 0x61, 0x63, 0x6b, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x63, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x53, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x22, 0x21, 0x0a, 0x0c, 0x43, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x4d, 0x6f, 0x64,
+	0x65, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x45, 0x54, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x4f,
+	0x53, 0x54, 0x10, 0x01, 0x22, 0x79, 0x0a, 0x0f, 0x54, 0x61, 0x73, 0x6b, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74,
+	0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22,
+	0xfe, 0x06, 0x0a, 0x08, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04,
+	0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b,
+	0x73, 0x74, 0x6f, 0x63, 0x6b, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0a, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1b, 0x0a,
+	0x09, 0x64, 0x61, 0x79, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x64, 0x61, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x65,
+	0x65, 0x6b, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x77, 0x65, 0x65, 0x6b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x61, 0x6c,
+	0x6c, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x63, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x55, 0x72, 0x6c, 0x12, 0x29, 0x0a, 0x10,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x2f, 0x0a, 0x13, 0x69, 0x6e, 0x67, 0x65, 0x73,
+	0x74, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x69, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x2f, 0x0a, 0x13, 0x76, 0x65, 0x72, 0x69,
+	0x66, 0x79, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x69, 0x6e, 0x67,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x3c, 0x0a, 0x1a, 0x63, 0x61, 0x6c,
+	0x6c, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x18, 0x63,
+	0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x2f, 0x0a, 0x13, 0x63, 0x6f, 0x6d, 0x70, 0x6c,
+	0x65, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x29, 0x0a, 0x10, 0x66, 0x61, 0x69, 0x6c,
+	0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0f, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x12, 0x29, 0x0a, 0x10, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x6d,
+	0x6f, 0x64, 0x69, 0x66, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x2b,
+	0x0a, 0x11, 0x63, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x61, 0x74, 0x74, 0x65, 0x6d,
+	0x70, 0x74, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x63, 0x61, 0x6c, 0x6c, 0x62,
+	0x61, 0x63, 0x6b, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x36, 0x0a, 0x17, 0x6e,
+	0x65, 0x78, 0x74, 0x5f, 0x63, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x6e, 0x65,
+	0x78, 0x74, 0x43, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x5f,
+	0x6d, 0x6f, 0x64, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x6c, 0x6c,
+	0x62, 0x61, 0x63, 0x6b, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x61, 0x63, 0x74, 0x75,
+	0x61, 0x6c, 0x5f, 0x73, 0x74, 0x6f, 0x63, 0x6b, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x13,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x53, 0x74, 0x6f, 0x63,
+	0x6b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c,
+	0x5f, 0x64, 0x61, 0x79, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x14, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0e, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x44, 0x61, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x2a, 0x0a, 0x11, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x5f, 0x77, 0x65, 0x65, 0x6b, 0x5f,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x15, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x61, 0x63, 0x74,
+	0x75, 0x61, 0x6c, 0x57, 0x65, 0x65, 0x6b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0c,
+	0x69, 0x6e, 0x63, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x16, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0c, 0x69, 0x6e, 0x63, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x74,
+	0x22, 0xf1, 0x01, 0x0a, 0x0f, 0x43, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x41, 0x74, 0x74,
+	0x65, 0x6d, 0x70, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x74, 0x74, 0x65,
+	0x6d, 0x70, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x61, 0x74, 0x74, 0x65, 0x6d,
+	0x70, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x75, 0x72, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x61, 0x74,
+	0x65, 0x6e, 0x63, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6c, 0x61, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x2a, 0x2d, 0x0a, 0x06, 0x41, 0x64, 0x6a, 0x75, 0x73, 0x74, 0x12, 0x0b,
+	0x0a, 0x07, 0x46, 0x4f, 0x52, 0x57, 0x41, 0x52, 0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x4e,
+	0x4f, 0x4e, 0x45, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x42, 0x41, 0x43, 0x4b, 0x57, 0x41, 0x52,
+	0x44, 0x10, 0x02, 0x32, 0xb6, 0x0c, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x41, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x1a, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x22, 0x00, 0x12, 0x38, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x61, 0x73, 0x6b,
+	0x12, 0x10, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x54, 0x61,
+	0x73, 0x6b, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x08,
+	0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x10, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x11, 0x52, 0x65, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65,
+	0x72, 0x43, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
+	0x00, 0x12, 0x55, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63,
+	0x6b, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x1b, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x6f, 0x72, 0x79, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x41, 0x74, 0x74,
+	0x65, 0x6d, 0x70, 0x74, 0x22, 0x00, 0x30, 0x01, 0x12, 0x3f, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x54,
+	0x61, 0x73, 0x6b, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x1a, 0x14, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x54,
+	0x61, 0x73, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x09, 0x4c, 0x69, 0x73,
+	0x74, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x1b, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x6f, 0x72, 0x79, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79,
+	0x2e, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x00, 0x30, 0x01, 0x12, 0x37, 0x0a,
+	0x08, 0x50, 0x75, 0x73, 0x68, 0x44, 0x61, 0x74, 0x61, 0x12, 0x14, 0x2e, 0x72, 0x65, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a,
+	0x11, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x22, 0x00, 0x28, 0x01, 0x12, 0x3f, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x53, 0x74, 0x6f,
+	0x63, 0x6b, 0x46, 0x75, 0x6c, 0x6c, 0x12, 0x18, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x6f, 0x72, 0x79, 0x2e, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x11, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x53, 0x74,
+	0x6f, 0x63, 0x6b, 0x22, 0x00, 0x30, 0x01, 0x12, 0x46, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x53, 0x75,
+	0x73, 0x70, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1d, 0x2e, 0x72, 0x65, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x53, 0x75, 0x73, 0x70, 0x65, 0x6e, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x22, 0x00, 0x30, 0x01, 0x12,
+	0x41, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x4c, 0x61, 0x74, 0x65, 0x73,
+	0x74, 0x12, 0x18, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x51,
+	0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x72, 0x65,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x22, 0x00,
+	0x30, 0x01, 0x12, 0x45, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x12, 0x1d, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79,
+	0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x11, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e,
+	0x51, 0x75, 0x6f, 0x74, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x50, 0x0a, 0x13, 0x47, 0x65, 0x74,
+	0x51, 0x75, 0x6f, 0x74, 0x65, 0x4c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x12, 0x1d, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x51, 0x75,
+	0x6f, 0x74, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x16, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x51, 0x75, 0x6f,
+	0x74, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x22, 0x00, 0x30, 0x01, 0x12, 0x4a, 0x0a, 0x11, 0x47,
+	0x65, 0x74, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x12, 0x1b, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e,
+	0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x22, 0x00, 0x30, 0x01, 0x12, 0x53, 0x0a, 0x13, 0x50, 0x75, 0x73, 0x68, 0x43,
+	0x6f, 0x72, 0x70, 0x6f, 0x72, 0x61, 0x74, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b,
+	0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x43, 0x6f, 0x72, 0x70,
+	0x6f, 0x72, 0x61, 0x74, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x1b, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e,
+	0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x00, 0x28, 0x01, 0x12, 0x59, 0x0a, 0x12,
+	0x47, 0x65, 0x74, 0x43, 0x6f, 0x72, 0x70, 0x6f, 0x72, 0x61, 0x74, 0x65, 0x41, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x22, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e,
+	0x43, 0x6f, 0x72, 0x70, 0x6f, 0x72, 0x61, 0x74, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x6f, 0x72, 0x79, 0x2e, 0x43, 0x6f, 0x72, 0x70, 0x6f, 0x72, 0x61, 0x74, 0x65, 0x41, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x22, 0x00, 0x30, 0x01, 0x12, 0x4e, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x20, 0x2e, 0x72, 0x65,
+	0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e,
+	0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x22, 0x00, 0x30, 0x01, 0x12, 0x4d, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x1b, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x6f, 0x72, 0x79, 0x2e, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72,
+	0x79, 0x2e, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x22, 0x00, 0x30, 0x01, 0x12, 0x4a, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x52, 0x65, 0x6a,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x1a, 0x1c, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e,
+	0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x22, 0x00, 0x12, 0x40, 0x0a, 0x0e, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x6a, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x12, 0x19, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72,
+	0x79, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x11, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x2e, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0b, 0x50, 0x75, 0x73, 0x68, 0x48, 0x6f, 0x6c, 0x69,
+	0x64, 0x61, 0x79, 0x12, 0x13, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79,
+	0x2e, 0x48, 0x6f, 0x6c, 0x69, 0x64, 0x61, 0x79, 0x1a, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x36, 0x34,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x00, 0x28, 0x01, 0x12, 0x41, 0x0a, 0x0a, 0x47, 0x65, 0x74,
+	0x48, 0x6f, 0x6c, 0x69, 0x64, 0x61, 0x79, 0x12, 0x1a, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x6f, 0x72, 0x79, 0x2e, 0x48, 0x6f, 0x6c, 0x69, 0x64, 0x61, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79,
+	0x2e, 0x48, 0x6f, 0x6c, 0x69, 0x64, 0x61, 0x79, 0x22, 0x00, 0x30, 0x01, 0x42, 0x07, 0x5a, 0x05,
+	0x2e, 0x2f, 0x3b, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -719,38 +2837,111 @@ func file_repository_proto_rawDescGZIP() []byte {
 	return file_repository_proto_rawDescData
 }
 
-var file_repository_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_repository_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_repository_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_repository_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_repository_proto_goTypes = []interface{}{
-	(QuoteRequest_Mode)(0),         // 0: repository.QuoteRequest.Mode
-	(*QuoteRequest)(nil),           // 1: repository.QuoteRequest
-	(*Metadata)(nil),               // 2: repository.Metadata
-	(*Count)(nil),                  // 3: repository.Count
-	(*Stock)(nil),                  // 4: repository.Stock
-	(*Quote)(nil),                  // 5: repository.Quote
-	(*Task)(nil),                   // 6: repository.Task
-	(*emptypb.Empty)(nil),          // 7: google.protobuf.Empty
-	(*wrapperspb.StringValue)(nil), // 8: google.protobuf.StringValue
+	(Adjust)(0),                    // 0: repository.Adjust
+	(QuoteRequest_Mode)(0),         // 1: repository.QuoteRequest.Mode
+	(CorporateAction_Kind)(0),      // 2: repository.CorporateAction.Kind
+	(StockRequest_Status)(0),       // 3: repository.StockRequest.Status
+	(Task_CallbackMode)(0),         // 4: repository.Task.CallbackMode
+	(*QuoteRequest)(nil),           // 5: repository.QuoteRequest
+	(*QuoteRangeRequest)(nil),      // 6: repository.QuoteRangeRequest
+	(*QuoteBatchRequest)(nil),      // 7: repository.QuoteBatchRequest
+	(*QuoteGroup)(nil),             // 8: repository.QuoteGroup
+	(*SnapshotRequest)(nil),        // 9: repository.SnapshotRequest
+	(*Snapshot)(nil),               // 10: repository.Snapshot
+	(*CorporateAction)(nil),        // 11: repository.CorporateAction
+	(*CorporateActionRequest)(nil), // 12: repository.CorporateActionRequest
+	(*Holiday)(nil),                // 13: repository.Holiday
+	(*HolidayRequest)(nil),         // 14: repository.HolidayRequest
+	(*MetadataRangeRequest)(nil),   // 15: repository.MetadataRangeRequest
+	(*RejectedRequest)(nil),        // 16: repository.RejectedRequest
+	(*RejectedMetadata)(nil),       // 17: repository.RejectedMetadata
+	(*ReplayRequest)(nil),          // 18: repository.ReplayRequest
+	(*Metadata)(nil),               // 19: repository.Metadata
+	(*Count)(nil),                  // 20: repository.Count
+	(*Affected)(nil),               // 21: repository.Affected
+	(*Rejected)(nil),               // 22: repository.Rejected
+	(*StockRequest)(nil),           // 23: repository.StockRequest
+	(*SuspensionRequest)(nil),      // 24: repository.SuspensionRequest
+	(*Stock)(nil),                  // 25: repository.Stock
+	(*Quote)(nil),                  // 26: repository.Quote
+	(*Task)(nil),                   // 27: repository.Task
+	(*TaskListRequest)(nil),        // 28: repository.TaskListRequest
+	(*TaskInfo)(nil),               // 29: repository.TaskInfo
+	(*CallbackAttempt)(nil),        // 30: repository.CallbackAttempt
+	(*emptypb.Empty)(nil),          // 31: google.protobuf.Empty
+	(*wrapperspb.StringValue)(nil), // 32: google.protobuf.StringValue
+	(*wrapperspb.Int64Value)(nil),  // 33: google.protobuf.Int64Value
 }
 var file_repository_proto_depIdxs = []int32{
-	0, // 0: repository.QuoteRequest.mode:type_name -> repository.QuoteRequest.Mode
-	7, // 1: repository.Service.Version:input_type -> google.protobuf.Empty
-	6, // 2: repository.Service.CreateTask:input_type -> repository.Task
-	6, // 3: repository.Service.Complete:input_type -> repository.Task
-	2, // 4: repository.Service.PushData:input_type -> repository.Metadata
-	7, // 5: repository.Service.GetStockFull:input_type -> google.protobuf.Empty
-	1, // 6: repository.Service.GetQuoteLatest:input_type -> repository.QuoteRequest
-	8, // 7: repository.Service.Version:output_type -> google.protobuf.StringValue
-	7, // 8: repository.Service.CreateTask:output_type -> google.protobuf.Empty
-	7, // 9: repository.Service.Complete:output_type -> google.protobuf.Empty
-	3, // 10: repository.Service.PushData:output_type -> repository.Count
-	4, // 11: repository.Service.GetStockFull:output_type -> repository.Stock
-	5, // 12: repository.Service.GetQuoteLatest:output_type -> repository.Quote
-	7, // [7:13] is the sub-list for method output_type
-	1, // [1:7] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	1,  // 0: repository.QuoteRequest.mode:type_name -> repository.QuoteRequest.Mode
+	0,  // 1: repository.QuoteRequest.adjust:type_name -> repository.Adjust
+	1,  // 2: repository.QuoteRangeRequest.mode:type_name -> repository.QuoteRequest.Mode
+	0,  // 3: repository.QuoteRangeRequest.adjust:type_name -> repository.Adjust
+	1,  // 4: repository.QuoteBatchRequest.mode:type_name -> repository.QuoteRequest.Mode
+	0,  // 5: repository.QuoteBatchRequest.adjust:type_name -> repository.Adjust
+	26, // 6: repository.QuoteGroup.quotes:type_name -> repository.Quote
+	1,  // 7: repository.SnapshotRequest.period:type_name -> repository.QuoteRequest.Mode
+	0,  // 8: repository.SnapshotRequest.adjust:type_name -> repository.Adjust
+	26, // 9: repository.Snapshot.quote:type_name -> repository.Quote
+	2,  // 10: repository.CorporateAction.kind:type_name -> repository.CorporateAction.Kind
+	19, // 11: repository.RejectedMetadata.metadata:type_name -> repository.Metadata
+	22, // 12: repository.Count.rejected:type_name -> repository.Rejected
+	21, // 13: repository.Count.inserted:type_name -> repository.Affected
+	21, // 14: repository.Count.updated:type_name -> repository.Affected
+	3,  // 15: repository.StockRequest.status:type_name -> repository.StockRequest.Status
+	4,  // 16: repository.Task.callback_mode:type_name -> repository.Task.CallbackMode
+	31, // 17: repository.Service.Version:input_type -> google.protobuf.Empty
+	27, // 18: repository.Service.CreateTask:input_type -> repository.Task
+	27, // 19: repository.Service.Complete:input_type -> repository.Task
+	32, // 20: repository.Service.RedeliverCallback:input_type -> google.protobuf.StringValue
+	32, // 21: repository.Service.ListCallbackAttempts:input_type -> google.protobuf.StringValue
+	32, // 22: repository.Service.GetTask:input_type -> google.protobuf.StringValue
+	28, // 23: repository.Service.ListTasks:input_type -> repository.TaskListRequest
+	19, // 24: repository.Service.PushData:input_type -> repository.Metadata
+	23, // 25: repository.Service.GetStockFull:input_type -> repository.StockRequest
+	24, // 26: repository.Service.GetSuspensions:input_type -> repository.SuspensionRequest
+	5,  // 27: repository.Service.GetQuoteLatest:input_type -> repository.QuoteRequest
+	6,  // 28: repository.Service.GetQuoteRange:input_type -> repository.QuoteRangeRequest
+	7,  // 29: repository.Service.GetQuoteLatestBatch:input_type -> repository.QuoteBatchRequest
+	9,  // 30: repository.Service.GetMarketSnapshot:input_type -> repository.SnapshotRequest
+	11, // 31: repository.Service.PushCorporateAction:input_type -> repository.CorporateAction
+	12, // 32: repository.Service.GetCorporateAction:input_type -> repository.CorporateActionRequest
+	15, // 33: repository.Service.GetMetadataRange:input_type -> repository.MetadataRangeRequest
+	16, // 34: repository.Service.ListRejected:input_type -> repository.RejectedRequest
+	33, // 35: repository.Service.GetRejected:input_type -> google.protobuf.Int64Value
+	18, // 36: repository.Service.ReplayRejected:input_type -> repository.ReplayRequest
+	13, // 37: repository.Service.PushHoliday:input_type -> repository.Holiday
+	14, // 38: repository.Service.GetHoliday:input_type -> repository.HolidayRequest
+	32, // 39: repository.Service.Version:output_type -> google.protobuf.StringValue
+	31, // 40: repository.Service.CreateTask:output_type -> google.protobuf.Empty
+	31, // 41: repository.Service.Complete:output_type -> google.protobuf.Empty
+	31, // 42: repository.Service.RedeliverCallback:output_type -> google.protobuf.Empty
+	30, // 43: repository.Service.ListCallbackAttempts:output_type -> repository.CallbackAttempt
+	29, // 44: repository.Service.GetTask:output_type -> repository.TaskInfo
+	29, // 45: repository.Service.ListTasks:output_type -> repository.TaskInfo
+	20, // 46: repository.Service.PushData:output_type -> repository.Count
+	25, // 47: repository.Service.GetStockFull:output_type -> repository.Stock
+	25, // 48: repository.Service.GetSuspensions:output_type -> repository.Stock
+	26, // 49: repository.Service.GetQuoteLatest:output_type -> repository.Quote
+	26, // 50: repository.Service.GetQuoteRange:output_type -> repository.Quote
+	8,  // 51: repository.Service.GetQuoteLatestBatch:output_type -> repository.QuoteGroup
+	10, // 52: repository.Service.GetMarketSnapshot:output_type -> repository.Snapshot
+	33, // 53: repository.Service.PushCorporateAction:output_type -> google.protobuf.Int64Value
+	11, // 54: repository.Service.GetCorporateAction:output_type -> repository.CorporateAction
+	19, // 55: repository.Service.GetMetadataRange:output_type -> repository.Metadata
+	17, // 56: repository.Service.ListRejected:output_type -> repository.RejectedMetadata
+	17, // 57: repository.Service.GetRejected:output_type -> repository.RejectedMetadata
+	20, // 58: repository.Service.ReplayRejected:output_type -> repository.Count
+	33, // 59: repository.Service.PushHoliday:output_type -> google.protobuf.Int64Value
+	13, // 60: repository.Service.GetHoliday:output_type -> repository.Holiday
+	39, // [39:61] is the sub-list for method output_type
+	17, // [17:39] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
 }
 
 func init() { file_repository_proto_init() }
@@ -772,7 +2963,7 @@ func file_repository_proto_init() {
 			}
 		}
 		file_repository_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Metadata); i {
+			switch v := v.(*QuoteRangeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -784,7 +2975,7 @@ func file_repository_proto_init() {
 			}
 		}
 		file_repository_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Count); i {
+			switch v := v.(*QuoteBatchRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -796,7 +2987,7 @@ func file_repository_proto_init() {
 			}
 		}
 		file_repository_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Stock); i {
+			switch v := v.(*QuoteGroup); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -808,7 +2999,7 @@ func file_repository_proto_init() {
 			}
 		}
 		file_repository_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Quote); i {
+			switch v := v.(*SnapshotRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -820,6 +3011,210 @@ func file_repository_proto_init() {
 			}
 		}
 		file_repository_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Snapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_repository_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CorporateAction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_repository_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CorporateActionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_repository_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Holiday); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_repository_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HolidayRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_repository_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MetadataRangeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_repository_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RejectedRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_repository_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RejectedMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_repository_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplayRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_repository_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Metadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_repository_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Count); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_repository_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Affected); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_repository_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Rejected); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_repository_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StockRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_repository_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SuspensionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_repository_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Stock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_repository_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Quote); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_repository_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Task); i {
 			case 0:
 				return &v.state
@@ -831,14 +3226,50 @@ func file_repository_proto_init() {
 				return nil
 			}
 		}
+		file_repository_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_repository_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_repository_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CallbackAttempt); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_repository_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   6,
+			NumEnums:      5,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
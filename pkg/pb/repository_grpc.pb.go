@@ -26,10 +26,39 @@ const _ = grpc.SupportPackageIsVersion7
 type ServiceClient interface {
 	Version(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*wrapperspb.StringValue, error)
 	CreateTask(ctx context.Context, in *Task, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Complete 统计 date 的实际数量与上报数量比较后 task 进入 callback_pending，回调由后台投递，失败时按指数退避重试
 	Complete(ctx context.Context, in *Task, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// RedeliverCallback 重新投递 date 对应 task 的回调，仅回调投递失败的 failed 及 callback_pending 的 task 可重新投递
+	RedeliverCallback(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListCallbackAttempts 查询 date 对应 task 的回调投递记录
+	ListCallbackAttempts(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (Service_ListCallbackAttemptsClient, error)
+	// GetTask 查询 date 对应的 task
+	GetTask(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*TaskInfo, error)
+	// ListTasks 按 date 降序分页查询 task
+	ListTasks(ctx context.Context, in *TaskListRequest, opts ...grpc.CallOption) (Service_ListTasksClient, error)
+	// PushData 可通过 gRPC metadata x-batch-id 指定批次 ID，重复提交同一批次时不再写入并返回首次处理结果
+	// 未能写入的数据通过 Count.rejected 逐条返回
 	PushData(ctx context.Context, opts ...grpc.CallOption) (Service_PushDataClient, error)
-	GetStockFull(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Service_GetStockFullClient, error)
+	// GetStockFull 可按当前停牌状态过滤，当前停牌状态以最近一次写入的 metadata 为准
+	GetStockFull(ctx context.Context, in *StockRequest, opts ...grpc.CallOption) (Service_GetStockFullClient, error)
+	// GetSuspensions 返回 date 当日停牌的 stock，按 code 升序
+	GetSuspensions(ctx context.Context, in *SuspensionRequest, opts ...grpc.CallOption) (Service_GetSuspensionsClient, error)
 	GetQuoteLatest(ctx context.Context, in *QuoteRequest, opts ...grpc.CallOption) (Service_GetQuoteLatestClient, error)
+	// GetQuoteRange 按 date 升序返回 [begin, end] 内的 quote，区间不超过 100 年
+	GetQuoteRange(ctx context.Context, in *QuoteRangeRequest, opts ...grpc.CallOption) (Service_GetQuoteRangeClient, error)
+	GetQuoteLatestBatch(ctx context.Context, in *QuoteBatchRequest, opts ...grpc.CallOption) (Service_GetQuoteLatestBatchClient, error)
+	GetMarketSnapshot(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (Service_GetMarketSnapshotClient, error)
+	PushCorporateAction(ctx context.Context, opts ...grpc.CallOption) (Service_PushCorporateActionClient, error)
+	GetCorporateAction(ctx context.Context, in *CorporateActionRequest, opts ...grpc.CallOption) (Service_GetCorporateActionClient, error)
+	// GetMetadataRange 按 date 及写入顺序返回归档的原始数据，同一 code、date 可能有多条
+	GetMetadataRange(ctx context.Context, in *MetadataRangeRequest, opts ...grpc.CallOption) (Service_GetMetadataRangeClient, error)
+	// 未能写入的 metadata，修复问题后可通过 ReplayRejected 重新写入，写入成功的记录会被删除
+	ListRejected(ctx context.Context, in *RejectedRequest, opts ...grpc.CallOption) (Service_ListRejectedClient, error)
+	GetRejected(ctx context.Context, in *wrapperspb.Int64Value, opts ...grpc.CallOption) (*RejectedMetadata, error)
+	ReplayRejected(ctx context.Context, in *ReplayRequest, opts ...grpc.CallOption) (*Count, error)
+	// 交易日历节假日，周六、周日默认休市无需写入；周期线以周期内最后一个交易日为准
+	PushHoliday(ctx context.Context, opts ...grpc.CallOption) (Service_PushHolidayClient, error)
+	GetHoliday(ctx context.Context, in *HolidayRequest, opts ...grpc.CallOption) (Service_GetHolidayClient, error)
 }
 
 type serviceClient struct {
@@ -67,271 +96,1106 @@ func (c *serviceClient) Complete(ctx context.Context, in *Task, opts ...grpc.Cal
 	return out, nil
 }
 
+func (c *serviceClient) RedeliverCallback(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/repository.Service/RedeliverCallback", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) ListCallbackAttempts(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (Service_ListCallbackAttemptsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[0], "/repository.Service/ListCallbackAttempts", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceListCallbackAttemptsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Service_ListCallbackAttemptsClient interface {
+	Recv() (*CallbackAttempt, error)
+	grpc.ClientStream
+}
+
+type serviceListCallbackAttemptsClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceListCallbackAttemptsClient) Recv() (*CallbackAttempt, error) {
+	m := new(CallbackAttempt)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *serviceClient) GetTask(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*TaskInfo, error) {
+	out := new(TaskInfo)
+	err := c.cc.Invoke(ctx, "/repository.Service/GetTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) ListTasks(ctx context.Context, in *TaskListRequest, opts ...grpc.CallOption) (Service_ListTasksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[1], "/repository.Service/ListTasks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceListTasksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Service_ListTasksClient interface {
+	Recv() (*TaskInfo, error)
+	grpc.ClientStream
+}
+
+type serviceListTasksClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceListTasksClient) Recv() (*TaskInfo, error) {
+	m := new(TaskInfo)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *serviceClient) PushData(ctx context.Context, opts ...grpc.CallOption) (Service_PushDataClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[0], "/repository.Service/PushData", opts...)
+	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[2], "/repository.Service/PushData", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &servicePushDataClient{stream}
+	return x, nil
+}
+
+type Service_PushDataClient interface {
+	Send(*Metadata) error
+	CloseAndRecv() (*Count, error)
+	grpc.ClientStream
+}
+
+type servicePushDataClient struct {
+	grpc.ClientStream
+}
+
+func (x *servicePushDataClient) Send(m *Metadata) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *servicePushDataClient) CloseAndRecv() (*Count, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(Count)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *serviceClient) GetStockFull(ctx context.Context, in *StockRequest, opts ...grpc.CallOption) (Service_GetStockFullClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[3], "/repository.Service/GetStockFull", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceGetStockFullClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Service_GetStockFullClient interface {
+	Recv() (*Stock, error)
+	grpc.ClientStream
+}
+
+type serviceGetStockFullClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceGetStockFullClient) Recv() (*Stock, error) {
+	m := new(Stock)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *serviceClient) GetSuspensions(ctx context.Context, in *SuspensionRequest, opts ...grpc.CallOption) (Service_GetSuspensionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[4], "/repository.Service/GetSuspensions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceGetSuspensionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Service_GetSuspensionsClient interface {
+	Recv() (*Stock, error)
+	grpc.ClientStream
+}
+
+type serviceGetSuspensionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceGetSuspensionsClient) Recv() (*Stock, error) {
+	m := new(Stock)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *serviceClient) GetQuoteLatest(ctx context.Context, in *QuoteRequest, opts ...grpc.CallOption) (Service_GetQuoteLatestClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[5], "/repository.Service/GetQuoteLatest", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceGetQuoteLatestClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Service_GetQuoteLatestClient interface {
+	Recv() (*Quote, error)
+	grpc.ClientStream
+}
+
+type serviceGetQuoteLatestClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceGetQuoteLatestClient) Recv() (*Quote, error) {
+	m := new(Quote)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *serviceClient) GetQuoteRange(ctx context.Context, in *QuoteRangeRequest, opts ...grpc.CallOption) (Service_GetQuoteRangeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[6], "/repository.Service/GetQuoteRange", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceGetQuoteRangeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Service_GetQuoteRangeClient interface {
+	Recv() (*Quote, error)
+	grpc.ClientStream
+}
+
+type serviceGetQuoteRangeClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceGetQuoteRangeClient) Recv() (*Quote, error) {
+	m := new(Quote)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *serviceClient) GetQuoteLatestBatch(ctx context.Context, in *QuoteBatchRequest, opts ...grpc.CallOption) (Service_GetQuoteLatestBatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[7], "/repository.Service/GetQuoteLatestBatch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceGetQuoteLatestBatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Service_GetQuoteLatestBatchClient interface {
+	Recv() (*QuoteGroup, error)
+	grpc.ClientStream
+}
+
+type serviceGetQuoteLatestBatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceGetQuoteLatestBatchClient) Recv() (*QuoteGroup, error) {
+	m := new(QuoteGroup)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *serviceClient) GetMarketSnapshot(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (Service_GetMarketSnapshotClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[8], "/repository.Service/GetMarketSnapshot", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceGetMarketSnapshotClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Service_GetMarketSnapshotClient interface {
+	Recv() (*Snapshot, error)
+	grpc.ClientStream
+}
+
+type serviceGetMarketSnapshotClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceGetMarketSnapshotClient) Recv() (*Snapshot, error) {
+	m := new(Snapshot)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *serviceClient) PushCorporateAction(ctx context.Context, opts ...grpc.CallOption) (Service_PushCorporateActionClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[9], "/repository.Service/PushCorporateAction", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &servicePushDataClient{stream}
-	return x, nil
+	x := &servicePushCorporateActionClient{stream}
+	return x, nil
+}
+
+type Service_PushCorporateActionClient interface {
+	Send(*CorporateAction) error
+	CloseAndRecv() (*wrapperspb.Int64Value, error)
+	grpc.ClientStream
+}
+
+type servicePushCorporateActionClient struct {
+	grpc.ClientStream
+}
+
+func (x *servicePushCorporateActionClient) Send(m *CorporateAction) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *servicePushCorporateActionClient) CloseAndRecv() (*wrapperspb.Int64Value, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(wrapperspb.Int64Value)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *serviceClient) GetCorporateAction(ctx context.Context, in *CorporateActionRequest, opts ...grpc.CallOption) (Service_GetCorporateActionClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[10], "/repository.Service/GetCorporateAction", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceGetCorporateActionClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Service_GetCorporateActionClient interface {
+	Recv() (*CorporateAction, error)
+	grpc.ClientStream
+}
+
+type serviceGetCorporateActionClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceGetCorporateActionClient) Recv() (*CorporateAction, error) {
+	m := new(CorporateAction)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *serviceClient) GetMetadataRange(ctx context.Context, in *MetadataRangeRequest, opts ...grpc.CallOption) (Service_GetMetadataRangeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[11], "/repository.Service/GetMetadataRange", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceGetMetadataRangeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Service_GetMetadataRangeClient interface {
+	Recv() (*Metadata, error)
+	grpc.ClientStream
+}
+
+type serviceGetMetadataRangeClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceGetMetadataRangeClient) Recv() (*Metadata, error) {
+	m := new(Metadata)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *serviceClient) ListRejected(ctx context.Context, in *RejectedRequest, opts ...grpc.CallOption) (Service_ListRejectedClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[12], "/repository.Service/ListRejected", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceListRejectedClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Service_ListRejectedClient interface {
+	Recv() (*RejectedMetadata, error)
+	grpc.ClientStream
+}
+
+type serviceListRejectedClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceListRejectedClient) Recv() (*RejectedMetadata, error) {
+	m := new(RejectedMetadata)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *serviceClient) GetRejected(ctx context.Context, in *wrapperspb.Int64Value, opts ...grpc.CallOption) (*RejectedMetadata, error) {
+	out := new(RejectedMetadata)
+	err := c.cc.Invoke(ctx, "/repository.Service/GetRejected", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) ReplayRejected(ctx context.Context, in *ReplayRequest, opts ...grpc.CallOption) (*Count, error) {
+	out := new(Count)
+	err := c.cc.Invoke(ctx, "/repository.Service/ReplayRejected", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) PushHoliday(ctx context.Context, opts ...grpc.CallOption) (Service_PushHolidayClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[13], "/repository.Service/PushHoliday", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &servicePushHolidayClient{stream}
+	return x, nil
+}
+
+type Service_PushHolidayClient interface {
+	Send(*Holiday) error
+	CloseAndRecv() (*wrapperspb.Int64Value, error)
+	grpc.ClientStream
+}
+
+type servicePushHolidayClient struct {
+	grpc.ClientStream
+}
+
+func (x *servicePushHolidayClient) Send(m *Holiday) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *servicePushHolidayClient) CloseAndRecv() (*wrapperspb.Int64Value, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(wrapperspb.Int64Value)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *serviceClient) GetHoliday(ctx context.Context, in *HolidayRequest, opts ...grpc.CallOption) (Service_GetHolidayClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[14], "/repository.Service/GetHoliday", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceGetHolidayClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Service_GetHolidayClient interface {
+	Recv() (*Holiday, error)
+	grpc.ClientStream
+}
+
+type serviceGetHolidayClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceGetHolidayClient) Recv() (*Holiday, error) {
+	m := new(Holiday)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ServiceServer is the server API for Service service.
+// All implementations must embed UnimplementedServiceServer
+// for forward compatibility
+type ServiceServer interface {
+	Version(context.Context, *emptypb.Empty) (*wrapperspb.StringValue, error)
+	CreateTask(context.Context, *Task) (*emptypb.Empty, error)
+	// Complete 统计 date 的实际数量与上报数量比较后 task 进入 callback_pending，回调由后台投递，失败时按指数退避重试
+	Complete(context.Context, *Task) (*emptypb.Empty, error)
+	// RedeliverCallback 重新投递 date 对应 task 的回调，仅回调投递失败的 failed 及 callback_pending 的 task 可重新投递
+	RedeliverCallback(context.Context, *wrapperspb.StringValue) (*emptypb.Empty, error)
+	// ListCallbackAttempts 查询 date 对应 task 的回调投递记录
+	ListCallbackAttempts(*wrapperspb.StringValue, Service_ListCallbackAttemptsServer) error
+	// GetTask 查询 date 对应的 task
+	GetTask(context.Context, *wrapperspb.StringValue) (*TaskInfo, error)
+	// ListTasks 按 date 降序分页查询 task
+	ListTasks(*TaskListRequest, Service_ListTasksServer) error
+	// PushData 可通过 gRPC metadata x-batch-id 指定批次 ID，重复提交同一批次时不再写入并返回首次处理结果
+	// 未能写入的数据通过 Count.rejected 逐条返回
+	PushData(Service_PushDataServer) error
+	// GetStockFull 可按当前停牌状态过滤，当前停牌状态以最近一次写入的 metadata 为准
+	GetStockFull(*StockRequest, Service_GetStockFullServer) error
+	// GetSuspensions 返回 date 当日停牌的 stock，按 code 升序
+	GetSuspensions(*SuspensionRequest, Service_GetSuspensionsServer) error
+	GetQuoteLatest(*QuoteRequest, Service_GetQuoteLatestServer) error
+	// GetQuoteRange 按 date 升序返回 [begin, end] 内的 quote，区间不超过 100 年
+	GetQuoteRange(*QuoteRangeRequest, Service_GetQuoteRangeServer) error
+	GetQuoteLatestBatch(*QuoteBatchRequest, Service_GetQuoteLatestBatchServer) error
+	GetMarketSnapshot(*SnapshotRequest, Service_GetMarketSnapshotServer) error
+	PushCorporateAction(Service_PushCorporateActionServer) error
+	GetCorporateAction(*CorporateActionRequest, Service_GetCorporateActionServer) error
+	// GetMetadataRange 按 date 及写入顺序返回归档的原始数据，同一 code、date 可能有多条
+	GetMetadataRange(*MetadataRangeRequest, Service_GetMetadataRangeServer) error
+	// 未能写入的 metadata，修复问题后可通过 ReplayRejected 重新写入，写入成功的记录会被删除
+	ListRejected(*RejectedRequest, Service_ListRejectedServer) error
+	GetRejected(context.Context, *wrapperspb.Int64Value) (*RejectedMetadata, error)
+	ReplayRejected(context.Context, *ReplayRequest) (*Count, error)
+	// 交易日历节假日，周六、周日默认休市无需写入；周期线以周期内最后一个交易日为准
+	PushHoliday(Service_PushHolidayServer) error
+	GetHoliday(*HolidayRequest, Service_GetHolidayServer) error
+	mustEmbedUnimplementedServiceServer()
+}
+
+// UnimplementedServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedServiceServer struct {
+}
+
+func (UnimplementedServiceServer) Version(context.Context, *emptypb.Empty) (*wrapperspb.StringValue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Version not implemented")
+}
+func (UnimplementedServiceServer) CreateTask(context.Context, *Task) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTask not implemented")
+}
+func (UnimplementedServiceServer) Complete(context.Context, *Task) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Complete not implemented")
+}
+func (UnimplementedServiceServer) RedeliverCallback(context.Context, *wrapperspb.StringValue) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeliverCallback not implemented")
+}
+func (UnimplementedServiceServer) ListCallbackAttempts(*wrapperspb.StringValue, Service_ListCallbackAttemptsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListCallbackAttempts not implemented")
+}
+func (UnimplementedServiceServer) GetTask(context.Context, *wrapperspb.StringValue) (*TaskInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTask not implemented")
+}
+func (UnimplementedServiceServer) ListTasks(*TaskListRequest, Service_ListTasksServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListTasks not implemented")
+}
+func (UnimplementedServiceServer) PushData(Service_PushDataServer) error {
+	return status.Errorf(codes.Unimplemented, "method PushData not implemented")
+}
+func (UnimplementedServiceServer) GetStockFull(*StockRequest, Service_GetStockFullServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetStockFull not implemented")
+}
+func (UnimplementedServiceServer) GetSuspensions(*SuspensionRequest, Service_GetSuspensionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetSuspensions not implemented")
+}
+func (UnimplementedServiceServer) GetQuoteLatest(*QuoteRequest, Service_GetQuoteLatestServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetQuoteLatest not implemented")
+}
+func (UnimplementedServiceServer) GetQuoteRange(*QuoteRangeRequest, Service_GetQuoteRangeServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetQuoteRange not implemented")
+}
+func (UnimplementedServiceServer) GetQuoteLatestBatch(*QuoteBatchRequest, Service_GetQuoteLatestBatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetQuoteLatestBatch not implemented")
+}
+func (UnimplementedServiceServer) GetMarketSnapshot(*SnapshotRequest, Service_GetMarketSnapshotServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetMarketSnapshot not implemented")
+}
+func (UnimplementedServiceServer) PushCorporateAction(Service_PushCorporateActionServer) error {
+	return status.Errorf(codes.Unimplemented, "method PushCorporateAction not implemented")
+}
+func (UnimplementedServiceServer) GetCorporateAction(*CorporateActionRequest, Service_GetCorporateActionServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetCorporateAction not implemented")
+}
+func (UnimplementedServiceServer) GetMetadataRange(*MetadataRangeRequest, Service_GetMetadataRangeServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetMetadataRange not implemented")
+}
+func (UnimplementedServiceServer) ListRejected(*RejectedRequest, Service_ListRejectedServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListRejected not implemented")
+}
+func (UnimplementedServiceServer) GetRejected(context.Context, *wrapperspb.Int64Value) (*RejectedMetadata, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRejected not implemented")
+}
+func (UnimplementedServiceServer) ReplayRejected(context.Context, *ReplayRequest) (*Count, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayRejected not implemented")
+}
+func (UnimplementedServiceServer) PushHoliday(Service_PushHolidayServer) error {
+	return status.Errorf(codes.Unimplemented, "method PushHoliday not implemented")
+}
+func (UnimplementedServiceServer) GetHoliday(*HolidayRequest, Service_GetHolidayServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetHoliday not implemented")
+}
+func (UnimplementedServiceServer) mustEmbedUnimplementedServiceServer() {}
+
+// UnsafeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ServiceServer will
+// result in compilation errors.
+type UnsafeServiceServer interface {
+	mustEmbedUnimplementedServiceServer()
+}
+
+func RegisterServiceServer(s grpc.ServiceRegistrar, srv ServiceServer) {
+	s.RegisterService(&Service_ServiceDesc, srv)
+}
+
+func _Service_Version_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).Version(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/repository.Service/Version",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).Version(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_CreateTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Task)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).CreateTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/repository.Service/CreateTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).CreateTask(ctx, req.(*Task))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_Complete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Task)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).Complete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/repository.Service/Complete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).Complete(ctx, req.(*Task))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_RedeliverCallback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(wrapperspb.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).RedeliverCallback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/repository.Service/RedeliverCallback",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).RedeliverCallback(ctx, req.(*wrapperspb.StringValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ListCallbackAttempts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(wrapperspb.StringValue)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServiceServer).ListCallbackAttempts(m, &serviceListCallbackAttemptsServer{stream})
+}
+
+type Service_ListCallbackAttemptsServer interface {
+	Send(*CallbackAttempt) error
+	grpc.ServerStream
+}
+
+type serviceListCallbackAttemptsServer struct {
+	grpc.ServerStream
+}
+
+func (x *serviceListCallbackAttemptsServer) Send(m *CallbackAttempt) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Service_GetTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(wrapperspb.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/repository.Service/GetTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetTask(ctx, req.(*wrapperspb.StringValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ListTasks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TaskListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServiceServer).ListTasks(m, &serviceListTasksServer{stream})
+}
+
+type Service_ListTasksServer interface {
+	Send(*TaskInfo) error
+	grpc.ServerStream
+}
+
+type serviceListTasksServer struct {
+	grpc.ServerStream
+}
+
+func (x *serviceListTasksServer) Send(m *TaskInfo) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Service_PushData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ServiceServer).PushData(&servicePushDataServer{stream})
+}
+
+type Service_PushDataServer interface {
+	SendAndClose(*Count) error
+	Recv() (*Metadata, error)
+	grpc.ServerStream
+}
+
+type servicePushDataServer struct {
+	grpc.ServerStream
+}
+
+func (x *servicePushDataServer) SendAndClose(m *Count) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *servicePushDataServer) Recv() (*Metadata, error) {
+	m := new(Metadata)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Service_GetStockFull_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StockRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServiceServer).GetStockFull(m, &serviceGetStockFullServer{stream})
+}
+
+type Service_GetStockFullServer interface {
+	Send(*Stock) error
+	grpc.ServerStream
+}
+
+type serviceGetStockFullServer struct {
+	grpc.ServerStream
+}
+
+func (x *serviceGetStockFullServer) Send(m *Stock) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Service_GetSuspensions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SuspensionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServiceServer).GetSuspensions(m, &serviceGetSuspensionsServer{stream})
+}
+
+type Service_GetSuspensionsServer interface {
+	Send(*Stock) error
+	grpc.ServerStream
+}
+
+type serviceGetSuspensionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *serviceGetSuspensionsServer) Send(m *Stock) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Service_GetQuoteLatest_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(QuoteRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServiceServer).GetQuoteLatest(m, &serviceGetQuoteLatestServer{stream})
+}
+
+type Service_GetQuoteLatestServer interface {
+	Send(*Quote) error
+	grpc.ServerStream
+}
+
+type serviceGetQuoteLatestServer struct {
+	grpc.ServerStream
+}
+
+func (x *serviceGetQuoteLatestServer) Send(m *Quote) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Service_GetQuoteRange_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(QuoteRangeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServiceServer).GetQuoteRange(m, &serviceGetQuoteRangeServer{stream})
 }
 
-type Service_PushDataClient interface {
-	Send(*Metadata) error
-	CloseAndRecv() (*Count, error)
-	grpc.ClientStream
+type Service_GetQuoteRangeServer interface {
+	Send(*Quote) error
+	grpc.ServerStream
 }
 
-type servicePushDataClient struct {
-	grpc.ClientStream
+type serviceGetQuoteRangeServer struct {
+	grpc.ServerStream
 }
 
-func (x *servicePushDataClient) Send(m *Metadata) error {
-	return x.ClientStream.SendMsg(m)
+func (x *serviceGetQuoteRangeServer) Send(m *Quote) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func (x *servicePushDataClient) CloseAndRecv() (*Count, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	m := new(Count)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func _Service_GetQuoteLatestBatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(QuoteBatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return m, nil
+	return srv.(ServiceServer).GetQuoteLatestBatch(m, &serviceGetQuoteLatestBatchServer{stream})
 }
 
-func (c *serviceClient) GetStockFull(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Service_GetStockFullClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[1], "/repository.Service/GetStockFull", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &serviceGetStockFullClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	return x, nil
+type Service_GetQuoteLatestBatchServer interface {
+	Send(*QuoteGroup) error
+	grpc.ServerStream
 }
 
-type Service_GetStockFullClient interface {
-	Recv() (*Stock, error)
-	grpc.ClientStream
+type serviceGetQuoteLatestBatchServer struct {
+	grpc.ServerStream
 }
 
-type serviceGetStockFullClient struct {
-	grpc.ClientStream
+func (x *serviceGetQuoteLatestBatchServer) Send(m *QuoteGroup) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func (x *serviceGetStockFullClient) Recv() (*Stock, error) {
-	m := new(Stock)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func _Service_GetMarketSnapshot_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SnapshotRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return m, nil
+	return srv.(ServiceServer).GetMarketSnapshot(m, &serviceGetMarketSnapshotServer{stream})
 }
 
-func (c *serviceClient) GetQuoteLatest(ctx context.Context, in *QuoteRequest, opts ...grpc.CallOption) (Service_GetQuoteLatestClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[2], "/repository.Service/GetQuoteLatest", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &serviceGetQuoteLatestClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	return x, nil
+type Service_GetMarketSnapshotServer interface {
+	Send(*Snapshot) error
+	grpc.ServerStream
 }
 
-type Service_GetQuoteLatestClient interface {
-	Recv() (*Quote, error)
-	grpc.ClientStream
+type serviceGetMarketSnapshotServer struct {
+	grpc.ServerStream
 }
 
-type serviceGetQuoteLatestClient struct {
-	grpc.ClientStream
+func (x *serviceGetMarketSnapshotServer) Send(m *Snapshot) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func (x *serviceGetQuoteLatestClient) Recv() (*Quote, error) {
-	m := new(Quote)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func _Service_PushCorporateAction_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ServiceServer).PushCorporateAction(&servicePushCorporateActionServer{stream})
+}
+
+type Service_PushCorporateActionServer interface {
+	SendAndClose(*wrapperspb.Int64Value) error
+	Recv() (*CorporateAction, error)
+	grpc.ServerStream
+}
+
+type servicePushCorporateActionServer struct {
+	grpc.ServerStream
+}
+
+func (x *servicePushCorporateActionServer) SendAndClose(m *wrapperspb.Int64Value) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *servicePushCorporateActionServer) Recv() (*CorporateAction, error) {
+	m := new(CorporateAction)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-// ServiceServer is the server API for Service service.
-// All implementations must embed UnimplementedServiceServer
-// for forward compatibility
-type ServiceServer interface {
-	Version(context.Context, *emptypb.Empty) (*wrapperspb.StringValue, error)
-	CreateTask(context.Context, *Task) (*emptypb.Empty, error)
-	Complete(context.Context, *Task) (*emptypb.Empty, error)
-	PushData(Service_PushDataServer) error
-	GetStockFull(*emptypb.Empty, Service_GetStockFullServer) error
-	GetQuoteLatest(*QuoteRequest, Service_GetQuoteLatestServer) error
-	mustEmbedUnimplementedServiceServer()
+func _Service_GetCorporateAction_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CorporateActionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServiceServer).GetCorporateAction(m, &serviceGetCorporateActionServer{stream})
 }
 
-// UnimplementedServiceServer must be embedded to have forward compatible implementations.
-type UnimplementedServiceServer struct {
+type Service_GetCorporateActionServer interface {
+	Send(*CorporateAction) error
+	grpc.ServerStream
 }
 
-func (UnimplementedServiceServer) Version(context.Context, *emptypb.Empty) (*wrapperspb.StringValue, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Version not implemented")
+type serviceGetCorporateActionServer struct {
+	grpc.ServerStream
 }
-func (UnimplementedServiceServer) CreateTask(context.Context, *Task) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateTask not implemented")
+
+func (x *serviceGetCorporateActionServer) Send(m *CorporateAction) error {
+	return x.ServerStream.SendMsg(m)
 }
-func (UnimplementedServiceServer) Complete(context.Context, *Task) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Complete not implemented")
+
+func _Service_GetMetadataRange_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MetadataRangeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServiceServer).GetMetadataRange(m, &serviceGetMetadataRangeServer{stream})
 }
-func (UnimplementedServiceServer) PushData(Service_PushDataServer) error {
-	return status.Errorf(codes.Unimplemented, "method PushData not implemented")
+
+type Service_GetMetadataRangeServer interface {
+	Send(*Metadata) error
+	grpc.ServerStream
 }
-func (UnimplementedServiceServer) GetStockFull(*emptypb.Empty, Service_GetStockFullServer) error {
-	return status.Errorf(codes.Unimplemented, "method GetStockFull not implemented")
+
+type serviceGetMetadataRangeServer struct {
+	grpc.ServerStream
 }
-func (UnimplementedServiceServer) GetQuoteLatest(*QuoteRequest, Service_GetQuoteLatestServer) error {
-	return status.Errorf(codes.Unimplemented, "method GetQuoteLatest not implemented")
+
+func (x *serviceGetMetadataRangeServer) Send(m *Metadata) error {
+	return x.ServerStream.SendMsg(m)
 }
-func (UnimplementedServiceServer) mustEmbedUnimplementedServiceServer() {}
 
-// UnsafeServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to ServiceServer will
-// result in compilation errors.
-type UnsafeServiceServer interface {
-	mustEmbedUnimplementedServiceServer()
+func _Service_ListRejected_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RejectedRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServiceServer).ListRejected(m, &serviceListRejectedServer{stream})
 }
 
-func RegisterServiceServer(s grpc.ServiceRegistrar, srv ServiceServer) {
-	s.RegisterService(&Service_ServiceDesc, srv)
+type Service_ListRejectedServer interface {
+	Send(*RejectedMetadata) error
+	grpc.ServerStream
 }
 
-func _Service_Version_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(ServiceServer).Version(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/repository.Service/Version",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceServer).Version(ctx, req.(*emptypb.Empty))
-	}
-	return interceptor(ctx, in, info, handler)
+type serviceListRejectedServer struct {
+	grpc.ServerStream
 }
 
-func _Service_CreateTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Task)
+func (x *serviceListRejectedServer) Send(m *RejectedMetadata) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Service_GetRejected_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(wrapperspb.Int64Value)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ServiceServer).CreateTask(ctx, in)
+		return srv.(ServiceServer).GetRejected(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/repository.Service/CreateTask",
+		FullMethod: "/repository.Service/GetRejected",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceServer).CreateTask(ctx, req.(*Task))
+		return srv.(ServiceServer).GetRejected(ctx, req.(*wrapperspb.Int64Value))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Service_Complete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Task)
+func _Service_ReplayRejected_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ServiceServer).Complete(ctx, in)
+		return srv.(ServiceServer).ReplayRejected(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/repository.Service/Complete",
+		FullMethod: "/repository.Service/ReplayRejected",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServiceServer).Complete(ctx, req.(*Task))
+		return srv.(ServiceServer).ReplayRejected(ctx, req.(*ReplayRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Service_PushData_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(ServiceServer).PushData(&servicePushDataServer{stream})
+func _Service_PushHoliday_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ServiceServer).PushHoliday(&servicePushHolidayServer{stream})
 }
 
-type Service_PushDataServer interface {
-	SendAndClose(*Count) error
-	Recv() (*Metadata, error)
+type Service_PushHolidayServer interface {
+	SendAndClose(*wrapperspb.Int64Value) error
+	Recv() (*Holiday, error)
 	grpc.ServerStream
 }
 
-type servicePushDataServer struct {
+type servicePushHolidayServer struct {
 	grpc.ServerStream
 }
 
-func (x *servicePushDataServer) SendAndClose(m *Count) error {
+func (x *servicePushHolidayServer) SendAndClose(m *wrapperspb.Int64Value) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func (x *servicePushDataServer) Recv() (*Metadata, error) {
-	m := new(Metadata)
+func (x *servicePushHolidayServer) Recv() (*Holiday, error) {
+	m := new(Holiday)
 	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func _Service_GetStockFull_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(emptypb.Empty)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
-	}
-	return srv.(ServiceServer).GetStockFull(m, &serviceGetStockFullServer{stream})
-}
-
-type Service_GetStockFullServer interface {
-	Send(*Stock) error
-	grpc.ServerStream
-}
-
-type serviceGetStockFullServer struct {
-	grpc.ServerStream
-}
-
-func (x *serviceGetStockFullServer) Send(m *Stock) error {
-	return x.ServerStream.SendMsg(m)
-}
-
-func _Service_GetQuoteLatest_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(QuoteRequest)
+func _Service_GetHoliday_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(HolidayRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(ServiceServer).GetQuoteLatest(m, &serviceGetQuoteLatestServer{stream})
+	return srv.(ServiceServer).GetHoliday(m, &serviceGetHolidayServer{stream})
 }
 
-type Service_GetQuoteLatestServer interface {
-	Send(*Quote) error
+type Service_GetHolidayServer interface {
+	Send(*Holiday) error
 	grpc.ServerStream
 }
 
-type serviceGetQuoteLatestServer struct {
+type serviceGetHolidayServer struct {
 	grpc.ServerStream
 }
 
-func (x *serviceGetQuoteLatestServer) Send(m *Quote) error {
+func (x *serviceGetHolidayServer) Send(m *Holiday) error {
 	return x.ServerStream.SendMsg(m)
 }
 
@@ -354,8 +1218,34 @@ var Service_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Complete",
 			Handler:    _Service_Complete_Handler,
 		},
+		{
+			MethodName: "RedeliverCallback",
+			Handler:    _Service_RedeliverCallback_Handler,
+		},
+		{
+			MethodName: "GetTask",
+			Handler:    _Service_GetTask_Handler,
+		},
+		{
+			MethodName: "GetRejected",
+			Handler:    _Service_GetRejected_Handler,
+		},
+		{
+			MethodName: "ReplayRejected",
+			Handler:    _Service_ReplayRejected_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListCallbackAttempts",
+			Handler:       _Service_ListCallbackAttempts_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListTasks",
+			Handler:       _Service_ListTasks_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "PushData",
 			Handler:       _Service_PushData_Handler,
@@ -366,11 +1256,61 @@ var Service_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Service_GetStockFull_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetSuspensions",
+			Handler:       _Service_GetSuspensions_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "GetQuoteLatest",
 			Handler:       _Service_GetQuoteLatest_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetQuoteRange",
+			Handler:       _Service_GetQuoteRange_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetQuoteLatestBatch",
+			Handler:       _Service_GetQuoteLatestBatch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetMarketSnapshot",
+			Handler:       _Service_GetMarketSnapshot_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "PushCorporateAction",
+			Handler:       _Service_PushCorporateAction_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GetCorporateAction",
+			Handler:       _Service_GetCorporateAction_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetMetadataRange",
+			Handler:       _Service_GetMetadataRange_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListRejected",
+			Handler:       _Service_ListRejected_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "PushHoliday",
+			Handler:       _Service_PushHoliday_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GetHoliday",
+			Handler:       _Service_GetHoliday_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "repository.proto",
 }